@@ -0,0 +1,303 @@
+// battlesim 在本地模拟大量AI之间的战斗，用于数值平衡测试，不需要网络和数据库
+//
+//	go run ./cmd/battlesim -spec cmd/battlesim/example.json -out csv
+package main
+
+import (
+	"TowberGoServer/internal/game/objects"
+	"TowberGoServer/internal/list"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+)
+
+var (
+	specPath = flag.String("spec", "cmd/battlesim/example.json", "Path to the battle spec file")
+	battles  = flag.Int("n", 0, "Number of battles, overrides the spec")
+	output   = flag.String("out", "json", "Output format, csv or json")
+	outPath  = flag.String("o", "", "Output file, defaults to stdout")
+)
+
+// PetSpec 队伍中的一只宠物，Skills 为空时装备最后解锁的技能
+type PetSpec struct {
+	Pet    uint32   `json:"pet"`
+	Level  int      `json:"level"`
+	Skills []uint32 `json:"skills"`
+}
+
+type TeamSpec struct {
+	Name string    `json:"name"`
+	Pets []PetSpec `json:"pets"`
+}
+
+// Spec 模拟的配置，两支队伍分别由一个AI控制
+type Spec struct {
+	Battles    int        `json:"battles"`
+	Format     string     `json:"format"`
+	Difficulty string     `json:"difficulty"`
+	MaxRounds  int        `json:"max_rounds"`
+	Teams      []TeamSpec `json:"teams"`
+}
+
+var formats = map[string]objects.BattleFormat{
+	"":        objects.FormatSingles,
+	"singles": objects.FormatSingles,
+	"doubles": objects.FormatDoubles,
+}
+
+var difficulties = map[string]objects.AIDifficulty{
+	"easy":   objects.AIEasy,
+	"":       objects.AINormal,
+	"normal": objects.AINormal,
+	"hard":   objects.AIHard,
+}
+
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{Battles: 1000, MaxRounds: 200}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, err
+	}
+	if len(spec.Teams) != 2 {
+		return nil, errors.New("spec needs exactly two teams")
+	}
+	if _, ok := formats[spec.Format]; !ok {
+		return nil, fmt.Errorf("unknown format %q", spec.Format)
+	}
+	if _, ok := difficulties[spec.Difficulty]; !ok {
+		return nil, fmt.Errorf("unknown difficulty %q", spec.Difficulty)
+	}
+	for _, team := range spec.Teams {
+		if len(team.Pets) == 0 || len(team.Pets) > 5 {
+			return nil, fmt.Errorf("team %s needs 1-5 pets", team.Name)
+		}
+		for _, v := range team.Pets {
+			if list.PetList[v.Pet] == nil {
+				return nil, fmt.Errorf("no such pet %d", v.Pet)
+			}
+			if len(v.Skills) > 4 {
+				return nil, fmt.Errorf("pet %d has more than 4 skills", v.Pet)
+			}
+			for _, k := range v.Skills {
+				if list.SkillsList[k] == nil {
+					return nil, fmt.Errorf("no such skill %d", k)
+				}
+			}
+		}
+	}
+	return spec, nil
+}
+
+// newTeam 每场战斗重新生成队伍，天赋随机
+func newTeam(spec TeamSpec) []objects.Pet {
+	pets := make([]objects.Pet, 0, len(spec.Pets))
+	for _, v := range spec.Pets {
+		pet := objects.PetManager.CreateWildPet(v.Pet, v.Level)
+		if len(v.Skills) > 0 {
+			for i := range 4 {
+				var skill objects.Skill
+				if i < len(v.Skills) {
+					skill = list.SkillsList[v.Skills[i]]
+				}
+				pet.SetSkill(i, skill)
+			}
+		}
+		pets = append(pets, pet)
+	}
+	return pets
+}
+
+// skillKey 按照队伍和技能统计伤害
+type skillKey struct {
+	team  int
+	skill uint32
+}
+
+type skillStats struct {
+	uses    int
+	damages []int
+}
+
+type Result struct {
+	Battles       int         `json:"battles"`
+	Draws         int         `json:"draws"`
+	AverageRounds float64     `json:"average_rounds"`
+	Teams         []TeamStats `json:"teams"`
+	Skills        []SkillStat `json:"skills"`
+}
+
+type TeamStats struct {
+	Team    int     `json:"team"`
+	Name    string  `json:"name"`
+	Wins    int     `json:"wins"`
+	WinRate float64 `json:"win_rate"`
+}
+
+// SkillStat 技能的伤害分布，只统计命中目标的直接伤害
+type SkillStat struct {
+	Team        int     `json:"team"`
+	SkillID     uint32  `json:"skill_id"`
+	Skill       string  `json:"skill"`
+	Uses        int     `json:"uses"`
+	Hits        int     `json:"hits"`
+	TotalDamage int     `json:"total_damage"`
+	Mean        float64 `json:"mean"`
+	Min         int     `json:"min"`
+	P50         int     `json:"p50"`
+	P90         int     `json:"p90"`
+	Max         int     `json:"max"`
+}
+
+func simulate(spec *Spec) (*Result, error) {
+	result := &Result{Battles: spec.Battles}
+	wins := [2]int{}
+	rounds := 0
+	stats := make(map[skillKey]*skillStats)
+	stat := func(key skillKey) *skillStats {
+		if stats[key] == nil {
+			stats[key] = &skillStats{}
+		}
+		return stats[key]
+	}
+	format := formats[spec.Format]
+	for range spec.Battles {
+		players := []objects.BattlePlayer{
+			objects.NewAIBattlePlayer(0, difficulties[spec.Difficulty], spec.Teams[0].Name, newTeam(spec.Teams[0])...),
+			objects.NewAIBattlePlayer(1, difficulties[spec.Difficulty], spec.Teams[1].Name, newTeam(spec.Teams[1])...),
+		}
+		room, err := objects.NewBattleRoom(players, objects.WithFormat(format), func(room *objects.BattleRoom) {
+			room.Subscribe(objects.SkillUsed, func(event *objects.BattleEvent) {
+				stat(skillKey{room.TeamOf(event.Number), event.Skill.ID()}).uses++
+			})
+			room.Subscribe(objects.DamageDealt, func(event *objects.BattleEvent) {
+				info := event.Attack
+				if info == nil || event.Pet != info.To || event.Source != info.From {
+					return
+				}
+				s := stat(skillKey{room.TeamOf(room.NumberOf(info.From)), info.Skill})
+				s.damages = append(s.damages, event.Damage)
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		if room.RunSync(spec.MaxRounds) {
+			wins[room.Winner()]++
+		} else {
+			result.Draws++
+		}
+		rounds += room.Round() + 1
+	}
+
+	if spec.Battles > 0 {
+		result.AverageRounds = float64(rounds) / float64(spec.Battles)
+	}
+	for i, team := range spec.Teams {
+		v := TeamStats{Team: i, Name: team.Name, Wins: wins[i]}
+		if spec.Battles > 0 {
+			v.WinRate = float64(wins[i]) / float64(spec.Battles)
+		}
+		result.Teams = append(result.Teams, v)
+	}
+	for key, v := range stats {
+		s := SkillStat{Team: key.team, SkillID: key.skill, Uses: v.uses, Hits: len(v.damages)}
+		if skill := list.SkillsList[key.skill]; skill != nil {
+			s.Skill = skill.Name()
+		}
+		if len(v.damages) > 0 {
+			slices.Sort(v.damages)
+			for _, k := range v.damages {
+				s.TotalDamage += k
+			}
+			s.Mean = float64(s.TotalDamage) / float64(len(v.damages))
+			s.Min, s.Max = v.damages[0], v.damages[len(v.damages)-1]
+			s.P50 = v.damages[len(v.damages)*50/100]
+			s.P90 = v.damages[len(v.damages)*90/100]
+		}
+		result.Skills = append(result.Skills, s)
+	}
+	slices.SortFunc(result.Skills, func(a, b SkillStat) int {
+		if a.Team != b.Team {
+			return a.Team - b.Team
+		}
+		return int(a.SkillID) - int(b.SkillID)
+	})
+	return result, nil
+}
+
+func writeCSV(w io.Writer, result *Result) error {
+	writer := csv.NewWriter(w)
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 4, 64) }
+	writer.Write([]string{"team", "name", "battles", "wins", "draws", "win_rate", "average_rounds"})
+	for _, v := range result.Teams {
+		writer.Write([]string{
+			strconv.Itoa(v.Team), v.Name, strconv.Itoa(result.Battles), strconv.Itoa(v.Wins),
+			strconv.Itoa(result.Draws), f(v.WinRate), f(result.AverageRounds),
+		})
+	}
+	writer.Write(nil)
+	writer.Write([]string{"team", "skill_id", "skill", "uses", "hits", "total_damage", "mean", "min", "p50", "p90", "max"})
+	for _, v := range result.Skills {
+		writer.Write([]string{
+			strconv.Itoa(v.Team), strconv.Itoa(int(v.SkillID)), v.Skill, strconv.Itoa(v.Uses), strconv.Itoa(v.Hits),
+			strconv.Itoa(v.TotalDamage), f(v.Mean), strconv.Itoa(v.Min), strconv.Itoa(v.P50), strconv.Itoa(v.P90), strconv.Itoa(v.Max),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func main() {
+	flag.Parse()
+	spec, err := loadSpec(*specPath)
+	if err != nil {
+		log.Fatalf("failed to load spec: %v", err)
+	}
+	if *battles > 0 {
+		spec.Battles = *battles
+	}
+
+	// 只初始化战斗需要的管理器，不连接数据库
+	objects.PetManager = objects.NewPetManager(nil, list.PetList)
+	objects.SkillManager = &objects.SkillManagerStruct{SkillList: list.SkillsList}
+	objects.BuffManager = &objects.BuffManagerStruct{BuffList: list.BuffList}
+	objects.PetItemManager = &objects.PetItemManagerStruct{PetItemList: list.PetItemList}
+
+	result, err := simulate(spec)
+	if err != nil {
+		log.Fatalf("simulation failed: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *outPath != "" {
+		file, err := os.Create(*outPath)
+		if err != nil {
+			log.Fatalf("failed to create output file: %v", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	switch *output {
+	case "csv":
+		err = writeCSV(w, result)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(result)
+	default:
+		err = fmt.Errorf("unknown output format %q", *output)
+	}
+	if err != nil {
+		log.Fatalf("failed to write result: %v", err)
+	}
+}
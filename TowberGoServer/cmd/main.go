@@ -82,9 +82,33 @@ func main() {
 	// 创建SkillManager并进行初始化
 	objects.SkillManager = &objects.SkillManagerStruct{SkillList: list.SkillsList}
 
+	// 创建BuffManager并进行初始化
+	objects.BuffManager = &objects.BuffManagerStruct{BuffList: list.BuffList}
+
 	// 创建LootManager并进行初始化
 	objects.LootManager = &objects.LootManagerStruct{}
 
+	// 创建ReplayManager
+	objects.ReplayManager = &objects.ReplayManagerStruct{}
+
+	// 创建LeaderboardManager
+	objects.LeaderboardManager = &objects.LeaderboardManagerStruct{}
+
+	// 创建RankManager
+	objects.RankManager = objects.NewRankManager(hub.Db)
+
+	// 创建WagerManager，退还服务器重启前没有结算的押注
+	objects.WagerManager = objects.NewWagerManager(hub.Db)
+	objects.WagerManager.RefundPending()
+
+	// 创建匹配管理器，所有允许战斗的区域共用，匹配的战斗为排位战斗
+	areas.MatchmakingManager = areas.NewMatchmakingManager()
+	areas.MatchmakingManager.Rating = objects.RankManager.Rating
+	areas.MatchmakingManager.Options = []func(room *objects.BattleRoom){func(room *objects.BattleRoom) {
+		room.Config.Disconnect = objects.DisconnectWait
+	}, objects.WithTimer(objects.RankedBattleTimer), objects.RankManager.Ranked}
+	go areas.MatchmakingManager.Start()
+
 	// 开启定时任务
 	runAtMidnight()
 
@@ -128,6 +152,10 @@ func runAtMidnight() {
 
 			// 执行任务
 			// 1、清除所有用户的每日挑战记录
+			// 2、检查排位赛季是否结束
+			objects.RankManager.CheckSeason()
+			// 3、清空每日以及每周排行榜
+			objects.LeaderboardManager.Reset(time.Now())
 
 		}
 	}()
@@ -28,6 +28,24 @@ func UpdateStructs(db *gorm.DB) error {
 	if err := db.AutoMigrate(&EquippedPets{}); err != nil {
 		return err
 	}
+	if err := db.AutoMigrate(&PetLearnedSkills{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&Seasons{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&PlayerRatings{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&BattleRecords{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&Wagers{}); err != nil {
+		return err
+	}
+	if err := db.AutoMigrate(&WagerLogs{}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -54,6 +72,13 @@ type PetSkills struct {
 	Slot4 uint32
 }
 
+// PetLearnedSkills 宠物通过技能书学会的技能
+type PetLearnedSkills struct {
+	ID      uint64 `gorm:"primaryKey"`
+	Pet     uint64 `gorm:"Index"`
+	SkillID uint32
+}
+
 type PetStats struct {
 	ID           uint64 `gorm:"primaryKey"`
 	MaxHP        int
@@ -64,6 +89,14 @@ type PetStats struct {
 	Intelligence int
 	Speed        int
 	Defense      int
+	// 个体天赋，创建后不再修改
+	Nature      uint32
+	TalentHP    int
+	TalentMana  int
+	TalentStr   int
+	TalentInt   int
+	TalentSpeed int
+	TalentDef   int
 }
 
 type EquippedPets struct {
@@ -75,6 +108,59 @@ type EquippedPets struct {
 	Slot5 uint64
 }
 
+// Seasons 排位赛季，Rewarded 为赛季奖励是否已经发放
+type Seasons struct {
+	ID        uint32 `gorm:"primaryKey"`
+	StartTime time.Time
+	EndTime   time.Time
+	Rewarded  bool
+}
+
+// PlayerRatings 玩家在每个赛季的排位积分
+type PlayerRatings struct {
+	UID       uint32 `gorm:"primaryKey"`
+	Season    uint32 `gorm:"primaryKey;Index:idx_season_rating,priority:1"`
+	Rating    int    `gorm:"Index:idx_season_rating,priority:2"`
+	Wins      int
+	Losses    int
+	UpdatedAt time.Time
+}
+
+// BattleRecords 排位战斗记录，RatingChange 为胜者增加以及败者减少的积分
+type BattleRecords struct {
+	ID           uint64 `gorm:"primaryKey"`
+	Season       uint32
+	Winner       uint32 `gorm:"Index"`
+	Loser        uint32 `gorm:"Index"`
+	WinnerRating int
+	LoserRating  int
+	RatingChange int
+	CreatedAt    time.Time
+}
+
+// Wagers 押注对战，Status 1为押注中，2为已经发放给胜者，3为已经退还
+type Wagers struct {
+	ID        uint64 `gorm:"primaryKey"`
+	Inviter   uint32 `gorm:"Index"`
+	Target    uint32 `gorm:"Index"`
+	Status    uint8  `gorm:"Index"`
+	Winner    uint32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WagerLogs 押注物品的每一次流动，用于处理纠纷，Action 为 escrow、rollback、payout 或 refund
+type WagerLogs struct {
+	ID        uint64 `gorm:"primaryKey"`
+	Wager     uint64 `gorm:"Index"`
+	UID       uint32 `gorm:"Index"`
+	Action    string `gorm:"size:16"`
+	ItemType  uint32
+	ItemID    uint32
+	Count     uint32
+	CreatedAt time.Time
+}
+
 type PlayerTaskProgress struct {
 	PlayerID   int64  `gorm:"primaryKey"`
 	TaskID     int    `gorm:"primaryKey"`
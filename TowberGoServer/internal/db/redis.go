@@ -8,7 +8,8 @@ import (
 
 var Rdb *redis.Client
 
-func init() {
+// InitRedis 连接redis，只有服务器需要调用，模拟战斗等工具不需要redis
+func InitRedis() error {
 	Rdb = redis.NewClient(&redis.Options{
 		Addr:     "localhost:6379",
 		Password: "861214959",
@@ -16,7 +17,8 @@ func init() {
 	})
 	pong, err := Rdb.Ping(context.Background()).Result()
 	if err != nil {
-		panic(err)
+		return err
 	}
 	fmt.Println(pong)
+	return nil
 }
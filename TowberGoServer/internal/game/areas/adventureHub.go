@@ -2,7 +2,9 @@ package areas
 
 import (
 	"TowberGoServer/internal/containers"
+	"TowberGoServer/internal/game/loots"
 	"TowberGoServer/internal/game/objects"
+	"TowberGoServer/internal/states"
 	"TowberGoServer/pkg/packets"
 	"fmt"
 )
@@ -10,12 +12,32 @@ import (
 type AdventureHub struct {
 	objects.BaseArea
 	rooms *BattleWaitRooms
+	zones []*objects.EncounterZone
 }
 
 func (a *AdventureHub) Initialize() {
 	a.BaseArea.Initialize(a)
 	a.rooms = &BattleWaitRooms{waitMap: make(map[uint32]*BattleUnit)}
 	go a.rooms.Start()
+	// 入口下方的草地
+	a.zones = []*objects.EncounterZone{
+		{
+			Min:  containers.Vector2{X: 0, Y: 288},
+			Max:  containers.Vector2{X: 320, Y: 480},
+			Rate: 0.05,
+			Entries: []objects.EncounterEntry{
+				{PetID: 1, MinLevel: 1, MaxLevel: 3, Weight: 1},
+			},
+			Looter: loots.Wild{},
+		},
+	}
+}
+
+// RemovePlayer 离开区域时同时离开匹配队列，并取消所有未处理的战斗邀请
+func (a *AdventureHub) RemovePlayer(uid uint32) {
+	a.BaseArea.RemovePlayer(uid)
+	MatchmakingManager.Leave(uid)
+	a.rooms.RemovePlayer(uid)
 }
 
 func (a *AdventureHub) GetEntrance(id uint32) containers.Vector2 {
@@ -27,29 +49,71 @@ func (a *AdventureHub) Name() string {
 }
 
 func (a *AdventureHub) ProcessMessage(sender *objects.Player, message packets.Msg) {
-	if a.BaseArea.ProcessMessage(sender, message) {
+	if a.BaseArea.ProcessMessage(sender, message) || MatchmakingManager.ProcessMessage(sender, message) {
 		return
 	}
 	switch message := message.(type) {
 	case *packets.Packet_BattleRequest:
-		target, ok := a.Players.Get(message.BattleRequest.GetTarget())
-		if !ok {
-			deny := &packets.Packet_DenyResponse{DenyResponse: &packets.DenyResponseMessage{Reason: "error request"}}
-			sender.Client.SocketSend(deny)
-			return
-		}
-		// 创建房间，并向对方发送请求
-		a.rooms.CreateRoom(sender, target)
+		a.handleBattleRequest(sender, message.BattleRequest)
+	case *packets.Packet_CancelBattleInvite:
+		a.rooms.CancelRoom(message.CancelBattleInvite.RoomID, sender.UID)
+	case *packets.Packet_SpectateRequest:
+		a.handleSpectate(sender, message.SpectateRequest.Target)
 	case *packets.Packet_BattleInvitingResponse:
 		fmt.Println("收到战斗邀请回应,", message.BattleInvitingResponse)
 		if message.BattleInvitingResponse.Accepted {
 			a.rooms.AcceptRoom(message.BattleInvitingResponse.RoomID, sender.UID)
 		} else {
-			a.rooms.RejectRoom(message.BattleInvitingResponse.RoomID)
+			a.rooms.RejectRoom(message.BattleInvitingResponse.RoomID, sender.UID)
 		}
 	}
 }
 
+// handleBattleRequest 创建邀请房间，并向对方发送请求
+func (a *AdventureHub) handleBattleRequest(sender *objects.Player, request *packets.BattleRequestMessage) {
+	rsp := &packets.BattleRequestResponseMessage{Success: true}
+	defer func() {
+		sender.Client.SocketSend(&packets.Packet_BattleRequestResponse{BattleRequestResponse: rsp})
+	}()
+	target, ok := a.Players.Get(request.GetTarget())
+	if !ok {
+		rsp.Success, rsp.Reason = false, "error request"
+		return
+	}
+	format := objects.FormatSingles
+	if request.Format == 1 {
+		format = objects.FormatDoubles
+	}
+	id, err := a.rooms.CreateRoom(sender, target, format, newStake(request.Stake))
+	if err != nil {
+		rsp.Success, rsp.Reason = false, err.Error()
+		return
+	}
+	rsp.RoomID, rsp.Timeout = id, int64(inviteTimeout.Seconds())
+}
+
+// handleSpectate 观战区域内正在战斗的玩家
+func (a *AdventureHub) handleSpectate(sender *objects.Player, target uint32) {
+	rsp := &packets.SpectateResponseMessage{Success: true}
+	defer func() {
+		sender.Client.SocketSend(&packets.Packet_SpectateResponse{SpectateResponse: rsp})
+	}()
+	var room *objects.BattleRoom
+	if _, ok := a.Players.Get(target); ok {
+		room = objects.BattleManager.GetPlayerRoom(target)
+	}
+	if room == nil {
+		rsp.Success, rsp.Reason = false, "the player is not in battle"
+		return
+	}
+	state := &states.Spectating{Player: sender, BattleRoom: room, SavedState: sender.Client.GetState()}
+	// 加入成功后才切换到观战状态，观战状态需要在收到快照之前生效
+	err := room.AddSpectator(state, func() { sender.Client.SetState(state) })
+	if err != nil {
+		rsp.Success, rsp.Reason = false, err.Error()
+	}
+}
+
 func (a *AdventureHub) CheckCanEnter(player *objects.Player) (bool, string) {
 	return true, ""
 }
@@ -57,3 +121,7 @@ func (a *AdventureHub) CheckCanEnter(player *objects.Player) (bool, string) {
 func (a *AdventureHub) GetNPCs() []objects.NPC {
 	return nil
 }
+
+func (a *AdventureHub) GetEncounterZones() []*objects.EncounterZone {
+	return a.zones
+}
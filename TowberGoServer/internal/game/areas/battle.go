@@ -0,0 +1,30 @@
+package areas
+
+import (
+	"TowberGoServer/internal/game/objects"
+	"TowberGoServer/internal/states"
+	"TowberGoServer/pkg/packets"
+)
+
+// startBattle 发送开始战斗，将所有玩家转换为战斗状态并创建战斗房间，赛制由options设置，默认为单打
+func startBattle(players []*objects.Player, options ...func(room *objects.BattleRoom)) *objects.BattleRoom {
+	battlePlayers := make([]objects.BattlePlayer, len(players))
+	saved := make([]*states.InBattle, len(players))
+	for i, v := range players {
+		msg := &packets.StartBattleMessage{Number: int64(i)}
+		v.Client.ProcessMessage(0, &packets.Packet_StartBattle{StartBattle: msg})
+		state := &states.InBattle{Player: v, Num: i, SavedState: v.Client.GetState()}
+		v.Client.SetState(state)
+		battlePlayers[i], saved[i] = state, state
+	}
+	room, err := objects.BattleManager.CreateRoom(battlePlayers, options...)
+	if err != nil {
+		// 房间创建失败时恢复玩家原来的状态
+		for i, v := range players {
+			v.Client.SetState(saved[i].SavedState)
+			v.Client.SocketSend(&packets.Packet_DenyResponse{DenyResponse: &packets.DenyResponseMessage{Reason: err.Error()}})
+		}
+		return nil
+	}
+	return room
+}
@@ -2,79 +2,202 @@ package areas
 
 import (
 	"TowberGoServer/internal/game/objects"
-	"TowberGoServer/internal/states"
 	"TowberGoServer/pkg/packets"
+	"errors"
 	"sync"
 	"time"
 )
 
+// inviteTimeout 战斗邀请的有效时间
+const inviteTimeout = 10 * time.Second
+
+const (
+	// InviteRejected 对方拒绝了邀请
+	InviteRejected uint32 = iota + 1
+	// InviteExpired 邀请超时
+	InviteExpired
+	// InviteCancelled 发起方取消了邀请或者离开了区域
+	InviteCancelled
+	// InviteBusy 接受邀请时有一方已经不能开始战斗
+	InviteBusy
+	// InviteStakeFailed 接受邀请时有一方押注的物品不足
+	InviteStakeFailed
+)
+
 type BattleWaitRooms struct {
 	currentID uint32
 	waitMap   map[uint32]*BattleUnit
 	lock      sync.Mutex
 }
 
+// Start 每秒清理一次超时的邀请，并通知双方
 func (b *BattleWaitRooms) Start() {
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 	for {
 		<-ticker.C
 		b.lock.Lock()
 		now := time.Now()
 		for id, unit := range b.waitMap {
-			if now.Sub(unit.startTime) > 10*time.Second {
-				delete(b.waitMap, id)
+			if now.Sub(unit.startTime) > inviteTimeout {
+				b.close(id, InviteExpired, unit.Players[0], unit.Players[1])
 			}
 		}
 		b.lock.Unlock()
 	}
 }
 
-func (b *BattleWaitRooms) CreateRoom(master *objects.Player, target *objects.Player) {
+// CreateRoom 创建邀请并向目标发送请求，返回邀请的编号，stake 不为空时为押注对战
+func (b *BattleWaitRooms) CreateRoom(master *objects.Player, target *objects.Player, format objects.BattleFormat, stake []objects.MailItem) (uint32, error) {
+	if master.UID == target.UID {
+		return 0, errors.New("cannot invite yourself")
+	}
+	if !canBattle(master) {
+		return 0, errors.New("you cannot battle now")
+	}
+	if !canBattle(target) {
+		return 0, errors.New("the player is busy")
+	}
+	if len(stake) > 0 {
+		if err := objects.WagerManager.CheckStake(master, stake); err != nil {
+			return 0, err
+		}
+	}
 	b.lock.Lock()
 	defer b.lock.Unlock()
+	for _, unit := range b.waitMap {
+		if unit.has(master.UID) && unit.has(target.UID) {
+			return 0, errors.New("already invited")
+		}
+	}
 	b.currentID += 1
-	b.waitMap[b.currentID] = &BattleUnit{Players: [2]*objects.Player{master, target}, startTime: time.Now()}
+	b.waitMap[b.currentID] = &BattleUnit{Players: [2]*objects.Player{master, target}, format: format, stake: stake, startTime: time.Now()}
 
 	// 向目标发送请求
 	msg := &packets.Packet_BattleInviting{BattleInviting: &packets.BattleInvitingMessage{
 		RoomID:   b.currentID,
 		UserName: master.UserName,
+		Timeout:  int64(inviteTimeout.Seconds()),
+		Stake:    newWagerItemMessages(stake),
 	}}
 	target.Client.SocketSend(msg)
+	return b.currentID, nil
 }
 
+// AcceptRoom 目标接受邀请，双方都可以战斗时开始战斗，押注对战先扣除双方的押注
 func (b *BattleWaitRooms) AcceptRoom(roomID uint32, playerID uint32) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 	unit := b.waitMap[roomID]
-	if unit != nil && unit.Players[1].UID == playerID {
-		// 发送开始战斗，将两人转换为战斗状态
-
-		msg := &packets.StartBattleMessage{Number: 0}
-		unit.Players[0].Client.ProcessMessage(0, &packets.Packet_StartBattle{StartBattle: msg})
-		state1 := &states.InBattle{Player: unit.Players[0], Num: 0, SavedState: unit.Players[0].Client.GetState()}
-		unit.Players[0].Client.SetState(state1)
+	if unit == nil || unit.Players[1].UID != playerID {
+		return
+	}
+	if !canBattle(unit.Players[0]) || !canBattle(unit.Players[1]) {
+		b.close(roomID, InviteBusy, unit.Players[0], unit.Players[1])
+		return
+	}
+	options := []func(room *objects.BattleRoom){objects.WithFormat(unit.format), objects.WithTimer(objects.FriendlyBattleTimer)}
+	var wager *objects.Wager
+	if len(unit.stake) > 0 {
+		var err error
+		if wager, err = objects.WagerManager.Escrow(unit.Players, unit.stake); err != nil {
+			b.close(roomID, InviteStakeFailed, unit.Players[0], unit.Players[1])
+			return
+		}
+		options = append(options, objects.WagerManager.Wagered(wager))
+	}
+	// 删除邀请房间
+	delete(b.waitMap, roomID)
+	if startBattle(unit.Players[:], options...) == nil && wager != nil {
+		objects.WagerManager.Abort(wager)
+	}
+}
 
-		msg.Number = 1
-		unit.Players[1].Client.ProcessMessage(0, &packets.Packet_StartBattle{StartBattle: msg})
-		state2 := &states.InBattle{Player: unit.Players[1], Num: 1, SavedState: unit.Players[1].Client.GetState()}
-		unit.Players[1].Client.SetState(state2)
+// RejectRoom 目标拒绝邀请，通知发起方
+func (b *BattleWaitRooms) RejectRoom(roomID uint32, playerID uint32) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	unit := b.waitMap[roomID]
+	if unit == nil || unit.Players[1].UID != playerID {
+		return
+	}
+	b.close(roomID, InviteRejected, unit.Players[0])
+}
 
-		_ = objects.BattleManager.CreateRoom([2]objects.BattlePlayer{state1, state2})
-		// 删除邀请房间
-		delete(b.waitMap, roomID)
+// CancelRoom 发起方取消邀请，通知目标
+func (b *BattleWaitRooms) CancelRoom(roomID uint32, playerID uint32) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	unit := b.waitMap[roomID]
+	if unit == nil || unit.Players[0].UID != playerID {
+		return
 	}
+	b.close(roomID, InviteCancelled, unit.Players[1])
 }
 
-func (b *BattleWaitRooms) RejectRoom(roomID uint32) {
+// RemovePlayer 玩家离开区域时取消所有与其有关的邀请，并通知另一方
+func (b *BattleWaitRooms) RemovePlayer(uid uint32) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
+	for id, unit := range b.waitMap {
+		if unit.Players[0].UID == uid {
+			b.close(id, InviteCancelled, unit.Players[1])
+		} else if unit.Players[1].UID == uid {
+			b.close(id, InviteRejected, unit.Players[0])
+		}
+	}
+}
+
+// close 删除邀请并通知notify中的玩家，调用时需要持有锁
+func (b *BattleWaitRooms) close(roomID uint32, reason uint32, notify ...*objects.Player) {
+	unit := b.waitMap[roomID]
+	if unit == nil {
+		return
+	}
 	delete(b.waitMap, roomID)
+	for _, v := range notify {
+		other := unit.Players[0]
+		if other.UID == v.UID {
+			other = unit.Players[1]
+		}
+		v.Client.SocketSend(&packets.Packet_BattleInviteClosed{BattleInviteClosed: &packets.BattleInviteClosedMessage{
+			RoomID:   roomID,
+			Reason:   reason,
+			UserName: other.UserName,
+		}})
+	}
+}
+
+// canBattle 只有在区域中自由活动的玩家才能开始战斗，战斗中和观战中的玩家不能被邀请
+func canBattle(player *objects.Player) bool {
+	state := player.Client.GetState()
+	return state != nil && state.Name() == "InGame"
 }
 
 type BattleUnit struct {
 	Players   [2]*objects.Player
 	ready     [2]bool
+	format    objects.BattleFormat
+	stake     []objects.MailItem
 	startTime time.Time
 }
+
+func (u *BattleUnit) has(uid uint32) bool {
+	return u.Players[0].UID == uid || u.Players[1].UID == uid
+}
+
+func newWagerItemMessages(stake []objects.MailItem) []*packets.WagerItemMessage {
+	res := make([]*packets.WagerItemMessage, 0, len(stake))
+	for _, v := range stake {
+		res = append(res, &packets.WagerItemMessage{Id: v.ID, Count: v.Count, Type: v.Type})
+	}
+	return res
+}
+
+func newStake(messages []*packets.WagerItemMessage) []objects.MailItem {
+	res := make([]objects.MailItem, 0, len(messages))
+	for _, v := range messages {
+		res = append(res, objects.MailItem{ID: v.GetId(), Count: v.GetCount(), Type: v.GetType()})
+	}
+	return res
+}
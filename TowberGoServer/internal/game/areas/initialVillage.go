@@ -58,3 +58,7 @@ func (v *InitialVillage) CheckCanEnter(player *objects.Player) (bool, string) {
 func (v *InitialVillage) GetNPCs() []objects.NPC {
 	return v.npcs
 }
+
+func (v *InitialVillage) GetEncounterZones() []*objects.EncounterZone {
+	return nil
+}
@@ -0,0 +1,280 @@
+package areas
+
+import (
+	"TowberGoServer/internal/game/objects"
+	"TowberGoServer/pkg/packets"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRating 没有积分系统时所有玩家的积分
+	DefaultRating = 1000
+	// readyTimeout 匹配成功后确认的时间
+	readyTimeout = 15 * time.Second
+)
+
+var MatchmakingManager *MatchmakingManagerStruct
+
+// MatchmakingManagerStruct 随机匹配，按照积分和队伍等级配对，等待时间越长允许的差距越大
+type MatchmakingManagerStruct struct {
+	// Rating 获得玩家的积分
+	Rating func(player *objects.Player) int
+	// Options 匹配成功后创建战斗房间时的设置
+	Options []func(room *objects.BattleRoom)
+
+	queue   map[uint32]*matchEntry
+	matches map[uint32]*readyCheck
+	id      uint32
+	lock    sync.Mutex
+}
+
+type matchEntry struct {
+	player   *objects.Player
+	rating   int
+	level    int
+	joinTime time.Time
+}
+
+// ratingTolerance 允许的积分差距，每等待5秒扩大25，最多500
+func (e *matchEntry) ratingTolerance(now time.Time) int {
+	return min(50+int(now.Sub(e.joinTime)/(5*time.Second))*25, 500)
+}
+
+// levelTolerance 允许的队伍平均等级差距，每等待10秒扩大1级
+func (e *matchEntry) levelTolerance(now time.Time) int {
+	return 2 + int(now.Sub(e.joinTime)/(10*time.Second))
+}
+
+// readyCheck 匹配成功后等待双方确认
+type readyCheck struct {
+	entries  [2]*matchEntry
+	ready    [2]bool
+	deadline time.Time
+}
+
+func NewMatchmakingManager() *MatchmakingManagerStruct {
+	return &MatchmakingManagerStruct{
+		queue:   make(map[uint32]*matchEntry),
+		matches: make(map[uint32]*readyCheck),
+	}
+}
+
+func (m *MatchmakingManagerStruct) Start() {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+	for {
+		<-ticker.C
+		m.lock.Lock()
+		now := time.Now()
+		for id, match := range m.matches {
+			if now.After(match.deadline) {
+				m.cancel(id, "ready check timeout")
+			}
+		}
+		m.pair(now)
+		m.lock.Unlock()
+	}
+}
+
+// ProcessMessage 处理匹配相关的消息，允许战斗的区域在自己的ProcessMessage中调用，返回是否已经处理
+func (m *MatchmakingManagerStruct) ProcessMessage(sender *objects.Player, message packets.Msg) bool {
+	switch message := message.(type) {
+	case *packets.Packet_JoinMatchmaking:
+		rsp := &packets.MatchmakingResponseMessage{Success: true, Queued: true}
+		if err := m.Join(sender); err != nil {
+			rsp.Success, rsp.Reason, rsp.Queued = false, err.Error(), false
+		}
+		sender.Client.SocketSend(&packets.Packet_MatchmakingResponse{MatchmakingResponse: rsp})
+	case *packets.Packet_LeaveMatchmaking:
+		m.Leave(sender.UID)
+		sender.Client.SocketSend(&packets.Packet_MatchmakingResponse{MatchmakingResponse: &packets.MatchmakingResponseMessage{
+			Success: true,
+		}})
+	case *packets.Packet_MatchReady:
+		m.Ready(sender, message.MatchReady.MatchId, message.MatchReady.Accepted)
+	default:
+		return false
+	}
+	return true
+}
+
+// Join 加入匹配队列，至少需要一只存活的宠物
+func (m *MatchmakingManagerStruct) Join(player *objects.Player) error {
+	level, alive := teamLevel(player)
+	if !alive {
+		return errors.New("no pet can battle")
+	}
+	rating := DefaultRating
+	if m.Rating != nil {
+		rating = m.Rating(player)
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.queue[player.UID]; ok {
+		return errors.New("already in queue")
+	}
+	for _, match := range m.matches {
+		if match.entries[0].player.UID == player.UID || match.entries[1].player.UID == player.UID {
+			return errors.New("already matched")
+		}
+	}
+	m.queue[player.UID] = &matchEntry{player: player, rating: rating, level: level, joinTime: time.Now()}
+	return nil
+}
+
+// Leave 离开匹配队列，正在确认中的匹配视为拒绝
+func (m *MatchmakingManagerStruct) Leave(uid uint32) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.queue, uid)
+	for id, match := range m.matches {
+		for i, v := range match.entries {
+			if v.player.UID == uid {
+				match.ready[i] = false
+				m.cancel(id, "the opponent left")
+				break
+			}
+		}
+	}
+}
+
+// Ready 确认或者拒绝匹配，双方都确认后开始战斗
+func (m *MatchmakingManagerStruct) Ready(player *objects.Player, matchID uint32, accepted bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	match := m.matches[matchID]
+	if match == nil {
+		return
+	}
+	number := -1
+	for i, v := range match.entries {
+		if v.player.UID == player.UID {
+			number = i
+		}
+	}
+	if number < 0 {
+		return
+	}
+	if !accepted {
+		m.cancel(matchID, "the opponent declined")
+		return
+	}
+	match.ready[number] = true
+	if !match.ready[0] || !match.ready[1] {
+		return
+	}
+	delete(m.matches, matchID)
+	// 确认期间进入了其它状态的玩家不能开始战斗
+	for i, v := range match.entries {
+		if v.player.Client.GetState() == nil || v.player.Client.GetState().Name() != "InGame" {
+			match.ready[i] = false
+		}
+	}
+	if !match.ready[0] || !match.ready[1] {
+		m.matches[matchID] = match
+		m.cancel(matchID, "the opponent is busy")
+		return
+	}
+	startBattle([]*objects.Player{match.entries[0].player, match.entries[1].player}, m.Options...)
+}
+
+// pair 按加入时间依次为队列中的玩家寻找差距最小的对手
+func (m *MatchmakingManagerStruct) pair(now time.Time) {
+	entries := make([]*matchEntry, 0, len(m.queue))
+	for _, v := range m.queue {
+		entries = append(entries, v)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].joinTime.Before(entries[j].joinTime)
+	})
+	paired := make(map[uint32]bool)
+	for i, a := range entries {
+		if paired[a.player.UID] {
+			continue
+		}
+		var best *matchEntry
+		bestDiff := 0
+		for _, b := range entries[i+1:] {
+			if paired[b.player.UID] {
+				continue
+			}
+			diff := abs(a.rating - b.rating)
+			levelDiff := abs(a.level - b.level)
+			if diff > min(a.ratingTolerance(now), b.ratingTolerance(now)) ||
+				levelDiff > min(a.levelTolerance(now), b.levelTolerance(now)) {
+				continue
+			}
+			if best == nil || diff < bestDiff {
+				best, bestDiff = b, diff
+			}
+		}
+		if best == nil {
+			continue
+		}
+		paired[a.player.UID], paired[best.player.UID] = true, true
+		delete(m.queue, a.player.UID)
+		delete(m.queue, best.player.UID)
+
+		m.id++
+		m.matches[m.id] = &readyCheck{entries: [2]*matchEntry{a, best}, deadline: now.Add(readyTimeout)}
+		for k, v := range [2]*matchEntry{a, best} {
+			opponent := best
+			if k == 1 {
+				opponent = a
+			}
+			v.player.Client.SocketSend(&packets.Packet_MatchFound{MatchFound: &packets.MatchFoundMessage{
+				MatchId:  m.id,
+				Opponent: opponent.player.UserName,
+				Timeout:  int64(readyTimeout.Seconds()),
+			}})
+		}
+	}
+}
+
+// cancel 取消匹配，已经确认的一方重新回到队列并保留原来的等待时间
+func (m *MatchmakingManagerStruct) cancel(matchID uint32, reason string) {
+	match := m.matches[matchID]
+	if match == nil {
+		return
+	}
+	delete(m.matches, matchID)
+	for i, v := range match.entries {
+		msg := &packets.MatchCancelledMessage{MatchId: matchID, Reason: reason}
+		if match.ready[i] {
+			m.queue[v.player.UID] = v
+			msg.Requeued = true
+		}
+		v.player.Client.SocketSend(&packets.Packet_MatchCancelled{MatchCancelled: msg})
+	}
+}
+
+// teamLevel 队伍中宠物的平均等级，以及是否有存活的宠物
+func teamLevel(player *objects.Player) (int, bool) {
+	player.PetBagLock.RLock()
+	defer player.PetBagLock.RUnlock()
+	total, count, alive := 0, 0, false
+	for _, v := range player.EquippedPets {
+		if v == nil {
+			continue
+		}
+		total += v.Level()
+		count++
+		if v.Stats().HP > 0 {
+			alive = true
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / count, alive
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,53 @@
+package buffs
+
+import "TowberGoServer/internal/game/objects"
+
+// AttackUp 攻击提升，每层提升10%的伤害
+type AttackUp struct {
+	objects.BaseBuff
+}
+
+func (a *AttackUp) ID() int {
+	return 4
+}
+
+func (a *AttackUp) Use(attack *objects.AttackInfo) {
+	rate := 1 + 0.1*float64(a.Level())
+	attack.PhysicalDamage = int(float64(attack.PhysicalDamage) * rate)
+	attack.MagicDamage = int(float64(attack.MagicDamage) * rate)
+}
+
+func (a *AttackUp) IsPositive() bool {
+	return true
+}
+
+func (a *AttackUp) Clone(level int) objects.Buff {
+	res := &AttackUp{}
+	res.Initialize(level, 3)
+	return res
+}
+
+// AttackDown 攻击降低，每层降低10%的伤害
+type AttackDown struct {
+	objects.BaseBuff
+}
+
+func (a *AttackDown) ID() int {
+	return 5
+}
+
+func (a *AttackDown) Use(attack *objects.AttackInfo) {
+	rate := 1 - 0.1*float64(a.Level())
+	attack.PhysicalDamage = int(float64(attack.PhysicalDamage) * rate)
+	attack.MagicDamage = int(float64(attack.MagicDamage) * rate)
+}
+
+func (a *AttackDown) IsPositive() bool {
+	return false
+}
+
+func (a *AttackDown) Clone(level int) objects.Buff {
+	res := &AttackDown{}
+	res.Initialize(level, 3)
+	return res
+}
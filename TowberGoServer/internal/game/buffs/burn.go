@@ -0,0 +1,30 @@
+package buffs
+
+import "TowberGoServer/internal/game/objects"
+
+// Burn 灼烧，每回合开始时损失最大生命值的1/16，并且造成的物理伤害减半
+type Burn struct {
+	objects.BaseBuff
+}
+
+func (b *Burn) ID() int {
+	return 2
+}
+
+func (b *Burn) Use(attack *objects.AttackInfo) {
+	attack.PhysicalDamage /= 2
+}
+
+func (b *Burn) Tick() int {
+	return max(1, b.Pet().Stats().MaxHP*b.Level()/16)
+}
+
+func (b *Burn) IsPositive() bool {
+	return false
+}
+
+func (b *Burn) Clone(level int) objects.Buff {
+	res := &Burn{}
+	res.Initialize(level, 3)
+	return res
+}
@@ -0,0 +1,26 @@
+package buffs
+
+import "TowberGoServer/internal/game/objects"
+
+// Poison 中毒，每回合开始时损失最大生命值的1/16，每层叠加一次
+type Poison struct {
+	objects.BaseBuff
+}
+
+func (p *Poison) ID() int {
+	return 1
+}
+
+func (p *Poison) Tick() int {
+	return max(1, p.Pet().Stats().MaxHP*p.Level()/16)
+}
+
+func (p *Poison) IsPositive() bool {
+	return false
+}
+
+func (p *Poison) Clone(level int) objects.Buff {
+	res := &Poison{}
+	res.Initialize(level, 3)
+	return res
+}
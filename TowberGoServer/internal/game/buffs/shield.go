@@ -0,0 +1,44 @@
+package buffs
+
+import "TowberGoServer/internal/game/objects"
+
+// Shield 护盾，每层吸收10点伤害，护盾耗尽后失效
+type Shield struct {
+	objects.BaseBuff
+	absorb int
+}
+
+func (s *Shield) ID() int {
+	return 6
+}
+
+func (s *Shield) Update(updateLevel int) {
+	s.BaseBuff.Update(updateLevel)
+	s.absorb = s.Level() * 10
+}
+
+func (s *Shield) Defend(attack *objects.AttackInfo) {
+	if attack.Missed || s.absorb <= 0 {
+		return
+	}
+	physical := min(s.absorb, attack.PhysicalDamage)
+	attack.PhysicalDamage -= physical
+	s.absorb -= physical
+	magic := min(s.absorb, attack.MagicDamage)
+	attack.MagicDamage -= magic
+	s.absorb -= magic
+	if s.absorb <= 0 {
+		s.Expire()
+	}
+}
+
+func (s *Shield) IsPositive() bool {
+	return true
+}
+
+func (s *Shield) Clone(level int) objects.Buff {
+	res := &Shield{}
+	res.Initialize(level, 3)
+	res.absorb = res.Level() * 10
+	return res
+}
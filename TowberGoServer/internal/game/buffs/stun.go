@@ -0,0 +1,28 @@
+package buffs
+
+import "TowberGoServer/internal/game/objects"
+
+// Stun 眩晕，本回合以及下一回合无法行动，不可叠加
+type Stun struct {
+	objects.BaseBuff
+}
+
+func (s *Stun) ID() int {
+	return 3
+}
+
+func (s *Stun) Update(updateLevel int) {}
+
+func (s *Stun) BlockAction() bool {
+	return true
+}
+
+func (s *Stun) IsPositive() bool {
+	return false
+}
+
+func (s *Stun) Clone(level int) objects.Buff {
+	res := &Stun{}
+	res.Initialize(1, 2)
+	return res
+}
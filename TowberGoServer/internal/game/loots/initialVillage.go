@@ -4,14 +4,14 @@ import "TowberGoServer/internal/game/objects"
 
 type InitialVillageHead struct{}
 
-func (i InitialVillageHead) getTableSize() int {
+func (i InitialVillageHead) GetTableSize() int {
 	return 1
 }
 
-func (i InitialVillageHead) getTable() []*objects.Loot {
+func (i InitialVillageHead) GetTable() []*objects.Loot {
 	return []*objects.Loot{{ID: 1, Type: 1, Weight: 1}}
 }
 
-func (i InitialVillageHead) getCount() int {
+func (i InitialVillageHead) GetCount() int {
 	return 1
 }
@@ -0,0 +1,18 @@
+package loots
+
+import "TowberGoServer/internal/game/objects"
+
+// Wild 战胜野生宠物后的战利品
+type Wild struct{}
+
+func (w Wild) GetTableSize() int {
+	return 3
+}
+
+func (w Wild) GetTable() []*objects.Loot {
+	return []*objects.Loot{{ID: 1, Type: 1, Weight: 10}, {ID: 3, Type: 1, Weight: 5}, {ID: 2, Type: 1, Weight: 1}}
+}
+
+func (w Wild) GetCount() int {
+	return 2
+}
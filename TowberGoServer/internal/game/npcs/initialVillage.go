@@ -74,6 +74,7 @@ func (i *InitialVillageHealer) ProcessInteractPacket(player *objects.Player, msg
 		for _, v := range player.EquippedPets {
 			if v != nil {
 				v.Stats().HP = v.Stats().MaxHP
+				v.Stats().Mana = v.Stats().MaxMana
 			}
 		}
 	}
@@ -0,0 +1,246 @@
+package objects
+
+import "TowberGoServer/pkg/packets"
+
+type AIDifficulty int
+
+const (
+	// AIEasy 随机释放可用的技能，被迫换宠时选择第一只存活的宠物
+	AIEasy AIDifficulty = iota
+	// AINormal 大部分时候释放评分最高的技能，被迫换宠时选择克制对方的宠物
+	AINormal
+	// AIHard 总是释放评分最高的技能，处于劣势时主动更换宠物
+	AIHard
+)
+
+// AIBattlePlayer 由服务器控制的一方，可以用于npc训练师、野生宠物以及掉线玩家的托管
+type AIBattlePlayer struct {
+	Number      int
+	Difficulty  AIDifficulty
+	name        string
+	player      *Player
+	room        *BattleRoom
+	currentPet  *BattlePet
+	equippedPet [5]*BattlePet
+}
+
+func NewAIBattlePlayer(number int, difficulty AIDifficulty, name string, pets ...Pet) *AIBattlePlayer {
+	a := &AIBattlePlayer{Number: number, Difficulty: difficulty, name: name}
+	for i, v := range pets {
+		if i >= len(a.equippedPet) {
+			break
+		}
+		a.equippedPet[i] = &BattlePet{Pet: v}
+	}
+	a.currentPet = a.equippedPet[0]
+	return a
+}
+
+// NewTakeoverAIBattlePlayer 接管已有一方的队伍，保留宠物在战斗中的状态
+// 保留原来的玩家只用于战斗结果，例如排位和押注的胜负，托管的一方在结算时不获得经验和战利品
+func NewTakeoverAIBattlePlayer(number int, difficulty AIDifficulty, origin BattlePlayer) *AIBattlePlayer {
+	return &AIBattlePlayer{
+		Number:      number,
+		Difficulty:  difficulty,
+		name:        origin.UserName(),
+		player:      origin.GetPlayer(),
+		currentPet:  origin.CurrentPet(),
+		equippedPet: origin.EquippedPets(),
+	}
+}
+
+// ProcessMessage 在战斗协程中调用，因此指令需要通过 PushCommand 发送，否则会阻塞房间
+func (a *AIBattlePlayer) ProcessMessage(message packets.BattleMsg) {
+	if a.room == nil {
+		return
+	}
+	switch message := message.(type) {
+	case *packets.BattlePacket_StartNextRound:
+		// 每个存活的在场宠物各发送一条指令，同一回合不会让两个位置换上同一只宠物
+		chosen := make(map[int]bool)
+		for slot, pet := range a.room.ActivePets(a.Number) {
+			if alive(pet) {
+				a.send(a.command(slot, pet, chosen))
+			}
+		}
+	case *packets.BattlePacket_ChangePetRequest:
+		slot := int(message.ChangePetRequest.GetSlot())
+		if pos := a.choosePet(a.room.ActivePet(a.Number, slot), true, nil); pos >= 0 {
+			a.send(a.changePet(pos, slot))
+		}
+	case *packets.BattlePacket_RoundEnd:
+		a.room.PushReady(a.Number)
+	}
+}
+
+func (a *AIBattlePlayer) send(cmd *Command) {
+	a.room.PushCommand(cmd)
+}
+
+// command 根据难度选择一个位置本回合的指令
+func (a *AIBattlePlayer) command(slot int, pet *BattlePet, chosen map[int]bool) *Command {
+	enemies := a.room.Enemies(a.Number)
+	if a.Difficulty == AIHard && a.losing(pet, enemies) {
+		if pos := a.choosePet(pet, false, chosen); pos >= 0 {
+			chosen[pos] = true
+			return a.changePet(pos, slot)
+		}
+	}
+
+	usable := make([]int, 0)
+	for i, v := range pet.EquippedSkills() {
+		if v != nil && pet.CheckSkill(v) == nil {
+			usable = append(usable, i)
+		}
+	}
+	if len(usable) == 0 || len(enemies) == 0 {
+		if pos := a.choosePet(pet, true, chosen); pos >= 0 {
+			chosen[pos] = true
+			return a.changePet(pos, slot)
+		}
+		return &Command{Msg: &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_Runaway{}, Slot: int64(slot)}, Number: a.Number}
+	}
+
+	pos, target := usable[a.room.inputRng.IntN(len(usable))], enemies[a.room.inputRng.IntN(len(enemies))]
+	if a.Difficulty == AIHard || a.Difficulty == AINormal && a.room.inputRng.IntN(4) != 0 {
+		best := -1.0
+		for _, v := range usable {
+			skill := pet.EquippedSkills()[v]
+			candidates := enemies
+			// 对己方释放的技能只考虑对自身使用
+			if skill.Target() == TargetSelf || skill.Target() == TargetAlly {
+				candidates = []*BattlePet{pet}
+			}
+			for _, k := range candidates {
+				if score := a.scoreSkill(pet, skill, k); score > best {
+					pos, target, best = v, k, score
+				}
+			}
+		}
+	}
+	number := a.room.NumberOf(target)
+	return &Command{
+		Msg: &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_Attack{
+			Attack: &packets.Attack{SkillPos: int64(pos), Target: &packets.BattleTarget{
+				Number: int64(number),
+				Slot:   int64(a.room.SlotOf(number, target)),
+			}}}, Slot: int64(slot)},
+		Number: a.Number,
+	}
+}
+
+func (a *AIBattlePlayer) changePet(pos int, slot int) *Command {
+	return &Command{
+		Msg: &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_ChangePet{
+			ChangePet: &packets.ChangePet{PetPosition: int64(pos)}}, Slot: int64(slot)},
+		Number: a.Number,
+	}
+}
+
+// scoreSkill 估算技能的收益：期望伤害、治疗、buff价值，并扣除自身伤害和魔力消耗，魔力越少消耗的代价越大
+func (a *AIBattlePlayer) scoreSkill(pet *BattlePet, skill Skill, target *BattlePet) float64 {
+	if target == nil || target.Pet == nil {
+		return 0
+	}
+	friendly := a.room.TeamOf(a.room.NumberOf(target)) == a.room.TeamOf(a.Number)
+	score, damage := 0.0, 0.0
+	for _, info := range skill.Use(pet, target) {
+		if info == nil {
+			continue
+		}
+		if !friendly {
+			estimate := *info
+			estimate.From, estimate.To = pet, target
+			a.room.Calculator.Attack(&estimate, pet, target)
+			a.room.Calculator.Mitigate(&estimate, pet, target)
+			damage += float64(estimate.PhysicalDamage+estimate.MagicDamage) * float64(skill.Accuracy()) / 100
+		}
+		// 只计算实际能够恢复的部分
+		score += float64(min(info.Heal, target.Stats().MaxHP-target.Stats().HP))
+		score += float64(min(info.ManaRestore, target.Stats().MaxMana-target.Stats().Mana)) / 2
+		score -= float64(min(info.SelfDamage, pet.Stats().HP))
+		for _, v := range info.BuffDamage {
+			score += float64(10 * v.Level)
+		}
+	}
+	// 能够直接击败对方时优先释放
+	if damage >= float64(target.Stats().HP) {
+		damage *= 2
+	}
+	score += damage
+	if skill.Cost() > 0 {
+		mana := max(pet.Stats().Mana, 1)
+		score -= float64(skill.Cost()) * float64(pet.Stats().MaxMana) / float64(mana) / 4
+	}
+	return max(score, 0)
+}
+
+// matchup 宠物与对方宠物的对位评分，大于0为优势
+func (a *AIBattlePlayer) matchup(pet *BattlePet, enemies []*BattlePet) float64 {
+	score := 0.0
+	for _, enemy := range enemies {
+		for _, v := range pet.Elements() {
+			score += Effectiveness(v, enemy.Elements())
+		}
+		for _, v := range enemy.Elements() {
+			score -= Effectiveness(v, pet.Elements())
+		}
+	}
+	return score
+}
+
+// losing 宠物血量较低且被对方克制时认为处于劣势
+func (a *AIBattlePlayer) losing(pet *BattlePet, enemies []*BattlePet) bool {
+	if !alive(pet) || len(enemies) == 0 {
+		return false
+	}
+	return pet.Stats().HP*2 < pet.Stats().MaxHP && a.matchup(pet, enemies) < 0
+}
+
+// choosePet 选择一只可以替换pet上场的宠物，没有时返回-1，force 为false时只有比当前宠物更有优势才会更换
+func (a *AIBattlePlayer) choosePet(pet *BattlePet, force bool, chosen map[int]bool) int {
+	enemies := a.room.Enemies(a.Number)
+	res, best := -1, 0.0
+	for i, v := range a.equippedPet {
+		if !alive(v) || a.room.SlotOf(a.Number, v) >= 0 || chosen[i] {
+			continue
+		}
+		if a.Difficulty == AIEasy || len(enemies) == 0 {
+			return i
+		}
+		score := a.matchup(v, enemies) + float64(v.Stats().HP)/float64(max(v.Stats().MaxHP, 1))
+		if res < 0 || score > best {
+			res, best = i, score
+		}
+	}
+	if !force && res >= 0 && alive(pet) && len(enemies) > 0 {
+		if a.matchup(a.equippedPet[res], enemies) <= a.matchup(pet, enemies) {
+			return -1
+		}
+	}
+	return res
+}
+
+func (a *AIBattlePlayer) CurrentPet() *BattlePet {
+	return a.currentPet
+}
+
+func (a *AIBattlePlayer) SetCurrentPet(pet *BattlePet) {
+	a.currentPet = pet
+}
+
+func (a *AIBattlePlayer) EquippedPets() [5]*BattlePet {
+	return a.equippedPet
+}
+
+func (a *AIBattlePlayer) SetBattleRoom(room *BattleRoom) {
+	a.room = room
+}
+
+func (a *AIBattlePlayer) UserName() string {
+	return a.name
+}
+
+func (a *AIBattlePlayer) GetPlayer() *Player {
+	return a.player
+}
@@ -18,6 +18,8 @@ type Area interface {
 	CheckCanEnter(player *Player) (bool, string)
 	GetAreaInfo(player *Player)
 	GetNPCs() []NPC
+	// GetEncounterZones 区域中的野生宠物出没范围
+	GetEncounterZones() []*EncounterZone
 }
 
 var AreaMgr *AreaManager
@@ -2,35 +2,68 @@ package objects
 
 import (
 	"TowberGoServer/pkg/packets"
+	"errors"
 	"fmt"
 	"math"
+	"math/rand/v2"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
-var BattleManager *BattleManagerStruct = &BattleManagerStruct{rooms: make(map[uint32]*BattleRoom)}
+var BattleManager *BattleManagerStruct = &BattleManagerStruct{
+	rooms:      make(map[uint32]*BattleRoom),
+	reconnects: make(map[uint32]*ReconnectingBattlePlayer),
+}
 
 type BattleManagerStruct struct {
 	rooms    map[uint32]*BattleRoom
 	roomLock sync.Mutex
 	id       uint32
+	// 等待重连的玩家
+	reconnects map[uint32]*ReconnectingBattlePlayer
 }
 
-func (b *BattleManagerStruct) CreateRoom(players [2]BattlePlayer) *BattleRoom {
-	b.roomLock.Lock()
-	defer b.roomLock.Unlock()
-	b.id += 1
-	room := BattleRoom{
-		ID:            b.id,
+// NewBattleRoom 创建战斗房间但不开始战斗，options 会对房间进行设置，例如设置赛制、战利品和订阅事件
+// 不需要通道和计时的场景可以直接调用引擎的方法驱动战斗
+func NewBattleRoom(players []BattlePlayer, options ...func(room *BattleRoom)) (*BattleRoom, error) {
+	room := &BattleRoom{
 		Players:       players,
 		round:         0,
 		NextRoundChan: make(chan int),
 		CommandChan:   make(chan *Command),
+		disconnects:   make(chan int),
+		joins:         make(chan *spectatorJoin),
+		done:          make(chan struct{}),
+		Calculator:    DefaultCalculator,
+		Config:        DefaultBattleConfig,
+		Format:        FormatSingles,
+		Clock:         SystemClock,
+	}
+	WithSeed(rand.Uint64())(room)
+	for _, option := range options {
+		option(room)
+	}
+	if err := room.Format.check(len(players)); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// CreateRoom 创建战斗房间并在新的协程中开始战斗
+func (b *BattleManagerStruct) CreateRoom(players []BattlePlayer, options ...func(room *BattleRoom)) (*BattleRoom, error) {
+	room, err := NewBattleRoom(players, options...)
+	if err != nil {
+		return nil, err
 	}
-	b.rooms[b.id] = &room
+	b.roomLock.Lock()
+	defer b.roomLock.Unlock()
+	b.id += 1
+	room.ID = b.id
+	b.rooms[b.id] = room
 	go room.Start()
-	return &room
+	return room, nil
 }
 
 func (b *BattleManagerStruct) DeleteRoom(id uint32) {
@@ -39,10 +72,24 @@ func (b *BattleManagerStruct) DeleteRoom(id uint32) {
 	delete(b.rooms, id)
 }
 
+// GetPlayerRoom 获得玩家正在进行的战斗
+func (b *BattleManagerStruct) GetPlayerRoom(uid uint32) *BattleRoom {
+	b.roomLock.Lock()
+	defer b.roomLock.Unlock()
+	for _, room := range b.rooms {
+		for _, v := range room.Players {
+			if v.GetPlayer() != nil && v.GetPlayer().UID == uid {
+				return room
+			}
+		}
+	}
+	return nil
+}
+
 type BattleRoom struct {
 	ID            uint32
-	Players       [2]BattlePlayer
-	ready         [2]bool
+	Players       []BattlePlayer
+	ready         []bool
 	round         int
 	NextRoundChan chan int
 	CommandChan   chan *Command
@@ -51,13 +98,55 @@ type BattleRoom struct {
 	CurrentStage  atomic.Int32
 	Looter        LootTable
 	EndChan       []chan *BattleSummary
-}
-
+	Calculator    DamageCalculator
+	Config        BattleConfig
+	Format        BattleFormat
+	// active 每一方在场的宠物，下标为位置
+	active [][]*BattlePet
+	// timeouts 每一方连续等待指令超时的回合数
+	timeouts []int
+	// disconnects 和 joins 将其他协程的掉线和观战请求交给战斗协程处理，done 在战斗协程退出时关闭
+	disconnects chan int
+	joins       chan *spectatorJoin
+	done        chan struct{}
+	// 观战者
+	spectators    map[uint32]Spectator
+	spectatorLock sync.Mutex
+	// Seed 战斗随机数种子，相同的种子和指令会得到相同的战斗过程
+	Seed uint64
+	rng  *rand.Rand
+	// inputRng 由种子派生，用于超时的默认行动和AI的选择，这些输入会记录到回放中，回放时不会再次抽取
+	inputRng *rand.Rand
+	// Clock 驱动等待输入时使用的时钟
+	Clock Clock
+	// 战斗引擎的状态，phase 每次切换阶段时增加
+	stage      BattleStage
+	phase      int
+	commands   []*Command
+	switches   []pendingSwitch
+	switchNext BattleStage
+	emitted    []*BattleEvent
+	// sync 为true时房间由 RunSync 在当前协程中驱动，inbox 为还没有提交的指令
+	sync  bool
+	inbox []*Command
+	// recorder 记录战斗过程用于回放，replay 不为空时房间正在播放回放
+	recorder *packets.BattleReplay
+	replay   *replaySource
+	// 事件订阅
+	handlers       map[BattleEventType][]*battleSubscription
+	subscriptionID int
+	eventLock      sync.Mutex
+}
+
+// BattleSummary 战斗结果，Winner 和 Loser 为双方队伍中的第一名玩家
 type BattleSummary struct {
-	Winner *Player
-	Loser  *Player
+	Winner  *Player
+	Loser   *Player
+	Winners []*Player
+	Losers  []*Player
 }
 
+// GetTheOtherPlayer 两人战斗时对方的编号
 func (r *BattleRoom) GetTheOtherPlayer(num int) int {
 	if num == 0 {
 		return 1
@@ -66,142 +155,43 @@ func (r *BattleRoom) GetTheOtherPlayer(num int) int {
 	}
 }
 
-// SendEvent 发送事件
-// 1、进入战斗 2、新回合开始 3、回合结束 4、受伤 5、获得负面buff 6、获得正面buff 7、死亡
-func (r *BattleRoom) SendEvent(event int, target int) {
-	r.GetEvent(event, target)
-
-	for i, v := range r.Players {
-		self := target == i
-		for _, k := range v.EquippedPets() {
-			if k.Pet == nil {
-				continue
-			}
-			k.GetEvent(event, self, r)
+// Start 在战斗协程中驱动战斗引擎，从通道读取玩家的输入并负责计时，战斗结束后删除房间
+func (r *BattleRoom) Start() {
+	r.deliver(r.Begin())
+	for r.stage != StageEnded {
+		switch r.stage {
+		case StageRoundStart:
+			r.waitReconnect(0)
+			r.deliver(r.StartRound())
+		case StageCommand:
+			r.waitInput(r.Config.Timer.CommandTimeout)
+		case StageSwitch:
+			r.waitInput(r.Config.Timer.SwitchTimeout)
+		case StageNextRound:
+			r.WaitNextRound()
 		}
 	}
-}
-
-func (r *BattleRoom) GetEvent(event int, target int) {
-	if event == 7 {
-		// 当宠物死亡后，检查是否全部阵亡
-		end := true
-		for _, v := range r.Players[target].EquippedPets() {
-			if v.Pet != nil && v.Stats().HP != 0 {
-				end = false
-			}
-		}
-		if end {
-			r.EndBattle(r.GetTheOtherPlayer(target))
-			return
-		}
-
-		r.Players[target].ProcessMessage(&packets.BattlePacket_ChangePetRequest{})
-		timer := time.After(10 * time.Second)
-		for {
-			select {
-			case cmd := <-r.CommandChan:
-				// 判断是否是目标玩家且是更换宠物指令
-				if cmd.Number == target {
-					if changeCmd, ok := cmd.Msg.Command.(*packets.RoundCommandMessage_ChangePet); ok {
-						pet := r.Players[target].EquippedPets()[changeCmd.ChangePet.PetPosition]
-						if pet != nil && pet.Stats().HP > 0 {
-							r.Players[target].SetCurrentPet(pet)
-							return
-						} else {
-							deny := &packets.BattlePacket_DenyCommand{DenyCommand: &packets.DenyCommandMessage{Reason: "pet error"}}
-							r.Players[cmd.Number].ProcessMessage(deny)
-						}
-					}
-				}
-			case <-timer:
-				// 超时自动选择一个存活宠物
-				for _, pet := range r.Players[target].EquippedPets() {
-					if pet != nil && pet.Stats().HP > 0 {
-						r.Players[target].SetCurrentPet(pet)
-						return
-					}
-				}
-				r.EndBattle(r.GetTheOtherPlayer(target))
-			}
-		}
+	// 战斗结束消息已经发送给观战者
+	r.spectatorLock.Lock()
+	r.spectators = nil
+	r.spectatorLock.Unlock()
+	// 不关闭指令通道，仍在发送的协程通过 done 退出
+	close(r.done)
+	BattleManager.DeleteRoom(r.ID)
+	fmt.Println("stop room...")
+
+	// 发送战斗结束信号
+	summary := r.Summary()
+	for _, v := range r.EndChan {
+		v <- summary
 	}
 }
 
-func (r *BattleRoom) Start() {
-	defer func() {
-		close(r.NextRoundChan)
-		close(r.CommandChan)
-		BattleManager.DeleteRoom(r.ID)
-		fmt.Println("stop room...")
-		msg := &packets.BattlePacket_BattleEnd{BattleEnd: &packets.BattleEndMessage{Winner: int64(r.winner)}}
-		for _, v := range r.Players {
-			v.ProcessMessage(msg)
-		}
-
-		// 总结奖励
-		if r.Looter != nil && r.Players[r.winner].GetPlayer() != nil {
-			err := LootManager.GetLoot(r.Players[r.winner].GetPlayer(), r.Looter, 0.5)
-			if err != nil {
-				r.Players[r.winner].GetPlayer().Client.SocketSend(&packets.Packet_DenyResponse{
-					DenyResponse: &packets.DenyResponseMessage{Reason: err.Error()}},
-				)
-			}
-		}
-
-		// 发送战斗结束信号
-		battleSummary := BattleSummary{
-			Winner: r.Players[r.winner].GetPlayer(),
-			Loser:  r.Players[r.GetTheOtherPlayer(r.winner)].GetPlayer(),
-		}
-		for _, v := range r.EndChan {
-			v <- &battleSummary
-		}
-
-	}()
-	r.Players[0].SetBattleRoom(r)
-	r.Players[1].SetBattleRoom(r)
-
-	// 同步双方的信息
-	r.SyncPlayerInformation(0)
-	r.SyncPlayerInformation(1)
-
-	r.SendEvent(1, 0)
-	for {
-		r.SendEvent(2, 0)
-		r.ready[0], r.ready[1] = false, false
-		// 发送下一回合通知
-		nextRound := &packets.BattlePacket_StartNextRound{StartNextRound: &packets.StartNextRoundMessage{}}
-		for _, v := range r.Players {
-			v.ProcessMessage(nextRound)
-		}
-
-		// 启动回合，等待玩家的指令
-		commands := r.WaitCommand()
-
-		// 处理指令
-		r.ProcessCommand(commands)
-
-		// 当前回合已经结束
-		roundEnd := packets.BattlePacket_RoundEnd{RoundEnd: &packets.RoundEndMessage{}}
-		for _, v := range r.Players {
-			v.ProcessMessage(&roundEnd)
-		}
-
-		// 等待玩家请求下一回合
-		r.WaitNextRound()
-
-		// 检查战斗是否结束
-		if r.End {
-			return
-		}
-
-		r.round++
-		r.SendEvent(3, 0)
-	}
+func (r *BattleRoom) SyncPlayerInformation(number int) {
+	r.Broadcast(&packets.BattlePacket_SyncBattleInformation{SyncBattleInformation: r.syncMessage(number)})
 }
 
-func (r *BattleRoom) SyncPlayerInformation(number int) {
+func (r *BattleRoom) syncMessage(number int) *packets.SyncBattleInformationMessage {
 	petMessages := make([]*packets.PetMessage, 5)
 	for i, v := range r.Players[number].EquippedPets() {
 		if v == nil {
@@ -209,194 +199,505 @@ func (r *BattleRoom) SyncPlayerInformation(number int) {
 		}
 		petMessages[i] = newPetMessage(v.Pet)
 	}
-	msg := packets.SyncBattleInformationMessage{
+	return &packets.SyncBattleInformationMessage{
 		Number:      int64(number),
 		PlayerName:  r.Players[number].UserName(),
 		PetMessages: petMessages,
 	}
-	r.Players[0].ProcessMessage(&packets.BattlePacket_SyncBattleInformation{SyncBattleInformation: &msg})
-	r.Players[1].ProcessMessage(&packets.BattlePacket_SyncBattleInformation{SyncBattleInformation: &msg})
 }
 
+// WaitNextRound 等待所有玩家确认进入下一回合
 func (r *BattleRoom) WaitNextRound() {
 	r.CurrentStage.Store(2)
 	defer func() { r.CurrentStage.Store(0) }()
-	for {
+	for phase := r.phase; r.phase == phase; {
 		select {
-		case num := <-r.NextRoundChan:
-			r.ready[num] = true
-			if r.ready[0] == true && r.ready[1] == true {
-				return
-			}
+		case number := <-r.NextRoundChan:
+			r.deliver(r.Ready(number))
+		case number := <-r.disconnects:
+			r.replacePlayer(number)
+		case join := <-r.joins:
+			r.addSpectator(join)
 		}
 	}
 }
 
-// EndBattle 结束战斗，销毁房间，发送战斗统计信息
+// EndBattle 结束战斗，winner 为获胜的队伍
 func (r *BattleRoom) EndBattle(winner int) {
 	r.End = true
 	r.winner = winner
 }
 
-func (r *BattleRoom) WaitCommand() [2]*Command {
-	commands := [2]*Command{}
-	valid := [2]bool{}
-	timer := time.After(15 * time.Second)
-	r.CurrentStage.Store(1)
-	defer func() { r.CurrentStage.Store(0) }()
-	for {
+// waitInput 读取当前阶段的指令直到阶段改变，超时后由引擎执行默认行动
+// 有玩家等待重连时暂停计时，重连后继续剩余的时间
+func (r *BattleRoom) waitInput(timeout time.Duration) {
+	if r.stage == StageCommand {
+		r.CurrentStage.Store(1)
+		defer func() { r.CurrentStage.Store(0) }()
+	}
+	remaining := timeout
+	for phase := r.phase; r.phase == phase; {
+		if r.reconnecting() {
+			r.waitReconnect(remaining)
+			continue
+		}
+		start := r.Clock.Now()
+		timer := r.Clock.After(remaining)
 		select {
 		case cmd := <-r.CommandChan:
-			if r.isValid(cmd) && valid[cmd.Number] == false {
-				commands[cmd.Number] = cmd
-				valid[cmd.Number] = true
-			} else {
-				deny := &packets.BattlePacket_DenyCommand{DenyCommand: &packets.DenyCommandMessage{Reason: "command error"}}
+			events, err := r.Submit(cmd)
+			r.deliver(events)
+			if err != nil && cmd.Number >= 0 && cmd.Number < len(r.Players) {
+				deny := &packets.BattlePacket_DenyCommand{DenyCommand: &packets.DenyCommandMessage{Reason: err.Error()}}
 				r.Players[cmd.Number].ProcessMessage(deny)
 			}
-			if valid[0] && valid[1] {
-				return commands
-			}
 		case <-timer:
-			return commands
+			r.deliver(r.Timeout())
+		case number := <-r.disconnects:
+			r.replacePlayer(number)
+		case join := <-r.joins:
+			r.addSpectator(join)
 		}
+		remaining -= r.Clock.Now().Sub(start)
 	}
 }
 
-func (r *BattleRoom) isValid(command *Command) bool {
-	switch cmd := command.Msg.Command.(type) {
-	case *packets.RoundCommandMessage_ChangePet:
-		if r.Players[command.Number].EquippedPets()[cmd.ChangePet.PetPosition] != nil {
-			return true
-		} else {
+// commandsReady 所有存活的在场宠物都已经收到指令
+func (r *BattleRoom) commandsReady(commands []*Command) bool {
+	for i := range r.Players {
+		if r.waitingFor(commands, i) {
 			return false
 		}
 	}
 	return true
 }
 
-func (r *BattleRoom) ProcessCommand(commands [2]*Command) {
-	skills := [2]Skill{}
-	for i := range commands {
-		if commands[i] == nil {
+// isValid 检查指令是否合法，不合法时返回拒绝的原因
+func (r *BattleRoom) isValid(command *Command) error {
+	if command.Number < 0 || command.Number >= len(r.Players) {
+		return errors.New("command error")
+	}
+	slot := int(command.Msg.GetSlot())
+	if slot < 0 || slot >= r.Format.ActivePets {
+		return errors.New("no such slot")
+	}
+	if _, ok := command.Msg.Command.(*packets.RoundCommandMessage_Runaway); ok {
+		return nil
+	}
+	if !alive(r.ActivePet(command.Number, slot)) {
+		return errors.New("the pet cannot act")
+	}
+	switch cmd := command.Msg.Command.(type) {
+	case *packets.RoundCommandMessage_ChangePet:
+		pos := cmd.ChangePet.PetPosition
+		if pos < 0 || pos >= 5 || !alive(r.Players[command.Number].EquippedPets()[pos]) {
+			return errors.New("pet error")
+		}
+		if r.SlotOf(command.Number, r.Players[command.Number].EquippedPets()[pos]) >= 0 {
+			return errors.New("the pet is already in battle")
+		}
+	case *packets.RoundCommandMessage_Attack:
+		pet := r.ActivePet(command.Number, slot)
+		pos := cmd.Attack.SkillPos
+		if pos < 0 || pos >= 4 || pet.EquippedSkills()[pos] == nil {
+			return errors.New("no such skill")
+		}
+		if target := cmd.Attack.Target; target != nil && r.ActivePet(int(target.Number), int(target.Slot)) == nil {
+			return errors.New("no such target")
+		}
+		return pet.CheckSkill(pet.EquippedSkills()[pos])
+	case *packets.RoundCommandMessage_Capture:
+		if !r.Config.AllowCapture || len(r.Players) != 2 || r.Players[command.Number].GetPlayer() == nil {
+			return errors.New("cannot capture in this battle")
+		}
+		if _, ok := PetItemManager.PetItemList[cmd.Capture.ItemId].(CaptureItem); !ok {
+			return errors.New("not a capture item")
+		}
+		target := r.ActivePet(r.GetTheOtherPlayer(command.Number), 0)
+		if !alive(target) || target.Owner() != nil {
+			return errors.New("cannot capture this pet")
+		}
+	}
+	return nil
+}
+
+// battleAction 本回合需要释放的技能
+type battleAction struct {
+	number int
+	slot   int
+	pet    *BattlePet
+	skill  Skill
+	target *packets.BattleTarget
+}
+
+// ProcessCommand 先处理逃跑、换宠和捕捉，再按照技能优先级和速度依次释放所有技能
+func (r *BattleRoom) ProcessCommand(commands []*Command) {
+	actions := make([]*battleAction, 0, len(commands))
+	for _, v := range commands {
+		if v == nil {
 			continue
 		}
-		switch command := commands[i].Msg.Command.(type) {
+		slot := int(v.Msg.GetSlot())
+		switch command := v.Msg.Command.(type) {
 		case *packets.RoundCommandMessage_Attack:
-			skill := r.Players[i].CurrentPet().EquippedSkills()[command.Attack.SkillPos]
-			skills[i] = skill
+			pet := r.ActivePet(v.Number, slot)
+			pos := command.Attack.SkillPos
+			if pet == nil || pet.Pet == nil || pos < 0 || pos >= 4 || pet.EquippedSkills()[pos] == nil {
+				continue
+			}
+			actions = append(actions, &battleAction{
+				number: v.Number,
+				slot:   slot,
+				pet:    pet,
+				skill:  pet.EquippedSkills()[pos],
+				target: command.Attack.Target,
+			})
 		case *packets.RoundCommandMessage_Runaway:
 			fmt.Println("逃跑")
-			r.EndBattle(r.GetTheOtherPlayer(commands[i].Number))
+			r.Defeat(v.Number)
 			return
 		case *packets.RoundCommandMessage_ChangePet:
-			pet := r.Players[i].EquippedPets()[command.ChangePet.PetPosition]
-			r.Players[i].SetCurrentPet(pet)
+			pet := r.Players[v.Number].EquippedPets()[command.ChangePet.PetPosition]
+			// 同一回合两个位置选择了同一只宠物时只处理第一个
+			if alive(pet) && r.SlotOf(v.Number, pet) < 0 {
+				r.switchPet(v.Number, slot, pet)
+			}
+		case *packets.RoundCommandMessage_Capture:
+			if r.capture(v.Number, command.Capture.ItemId) {
+				return
+			}
 		}
 	}
 
-	// 处理技能
-	if skills[0] != nil && skills[1] != nil {
-		first, second := 0, 1
-		// 比较速度
-		if skills[0].Speed() < skills[1].Speed() {
-			first, second = 1, 0
-		} else if skills[0].Speed() == skills[1].Speed() {
-			if r.Players[0].CurrentPet().Stats().Speed < r.Players[1].CurrentPet().Stats().Speed {
-				first, second = 1, 0
-			}
+	// 技能优先级高的先行动，优先级相同时速度快的先行动
+	slices.SortStableFunc(actions, func(a, b *battleAction) int {
+		if a.skill.Speed() != b.skill.Speed() {
+			return b.skill.Speed() - a.skill.Speed()
+		}
+		return b.pet.BattleStats().Speed - a.pet.BattleStats().Speed
+	})
+	for _, v := range actions {
+		r.useSkill(v)
+		if r.End {
+			return
 		}
+	}
+}
 
-		attack0 := skills[first].Use(r.Players[first].CurrentPet(), r.Players[second].CurrentPet())
-		for _, v := range attack0 {
-			v.From = r.Players[first].CurrentPet()
-			v.To = r.Players[second].CurrentPet()
-			r.ProcessAttackInfo(v, first, second)
+// useSkill 让在场的宠物释放技能，宠物被替换、阵亡或者无法行动时不再释放
+func (r *BattleRoom) useSkill(action *battleAction) {
+	pet := action.pet
+	if r.ActivePet(action.number, action.slot) != pet || !alive(pet) || !pet.CanAct() {
+		return
+	}
+	targets := r.skillTargets(action)
+	if len(targets) == 0 {
+		return
+	}
+	pet.UseSkill(action.skill)
+	r.SendEvent(&BattleEvent{Type: SkillUsed, Number: action.number, Pet: pet, Skill: action.skill})
+	for _, target := range targets {
+		for _, v := range action.skill.Use(pet, target) {
+			v.From, v.To = pet, target
+			if len(targets) > 1 {
+				v.PhysicalDamage = int(float64(v.PhysicalDamage) * SpreadDamageRate)
+				v.MagicDamage = int(float64(v.MagicDamage) * SpreadDamageRate)
+			}
+			r.ProcessAttackInfo(v)
 			if r.End {
 				return
 			}
 		}
+	}
+}
 
-		attack1 := skills[second].Use(r.Players[second].CurrentPet(), r.Players[first].CurrentPet())
-		for _, v := range attack1 {
-			v.From = r.Players[first].CurrentPet()
-			v.To = r.Players[second].CurrentPet()
-			r.ProcessAttackInfo(v, second, first)
-			if r.End {
-				return
+// skillTargets 根据技能的目标类型选择目标，指定的敌方目标已经阵亡时改为攻击对方第一只存活的宠物，指定的己方目标无效时改为自身
+func (r *BattleRoom) skillTargets(action *battleAction) []*BattlePet {
+	enemies := r.Enemies(action.number)
+	switch action.skill.Target() {
+	case TargetAllEnemies:
+		return enemies
+	case TargetSelf:
+		return []*BattlePet{action.pet}
+	case TargetAlly:
+		if action.target != nil {
+			number := int(action.target.Number)
+			target := r.ActivePet(number, int(action.target.Slot))
+			if alive(target) && r.TeamOf(number) == r.TeamOf(action.number) {
+				return []*BattlePet{target}
 			}
 		}
-	} else {
-		for i, v := range skills {
-			if v != nil {
-				attack := skills[i].Use(r.Players[i].CurrentPet(), r.Players[r.GetTheOtherPlayer(i)].CurrentPet())
-				for _, k := range attack {
-					k.From = r.Players[i].CurrentPet()
-					k.To = r.Players[r.GetTheOtherPlayer(i)].CurrentPet()
-					r.ProcessAttackInfo(k, i, r.GetTheOtherPlayer(i))
-					if r.End {
-						return
-					}
+		return []*BattlePet{action.pet}
+	case TargetAll:
+		res := make([]*BattlePet, 0)
+		for i := range r.Players {
+			for _, v := range r.active[i] {
+				if alive(v) && v != action.pet {
+					res = append(res, v)
 				}
 			}
 		}
+		return res
+	default:
+		if action.target != nil {
+			target := r.ActivePet(int(action.target.Number), int(action.target.Slot))
+			if alive(target) && target != action.pet {
+				return []*BattlePet{target}
+			}
+		}
+		if len(enemies) == 0 {
+			return nil
+		}
+		return enemies[:1]
 	}
 }
 
-func (r *BattleRoom) ProcessAttackInfo(info *AttackInfo, from int, to int) {
-	fromPet := r.Players[from].CurrentPet()
-	toPet := r.Players[to].CurrentPet()
+// ProcessAttackInfo 结算一次攻击，攻击双方为 info.From 和 info.To
+func (r *BattleRoom) ProcessAttackInfo(info *AttackInfo) {
+	fromPet, toPet := info.From, info.To
 	if fromPet == nil || toPet == nil {
 		return
 	}
+	from, to := r.NumberOf(fromPet), r.NumberOf(toPet)
+	if from < 0 || to < 0 {
+		return
+	}
+	fromHP, toHP := fromPet.Stats().HP, toPet.Stats().HP
 
-	// 自身加成
-	info.PhysicalDamage = int(float64(info.PhysicalDamage) * (1 + float64(fromPet.Stats().Strength)*0.01))
-	info.MagicDamage = int(float64(info.MagicDamage) * (1 + float64(fromPet.Stats().Intelligence)*0.01))
-	// TODO 处理buff
+	// 攻击方buff和被动技能
+	for _, v := range fromPet.Buffs {
+		v.Use(info)
+	}
+	for _, v := range fromPet.Passives() {
+		v.Attack(info, fromPet)
+	}
 
-	// 敌方加成
-	info.PhysicalDamage = int(float64(info.PhysicalDamage) * (1 - float64(toPet.Stats().Strength)*0.01))
-	info.MagicDamage = int(float64(info.MagicDamage) * (1 - float64(toPet.Stats().Intelligence)*0.01))
-	// TODO 处理buff
+	// 计算伤害，对己方宠物释放的辅助技能必定命中
+	if r.TeamOf(from) == r.TeamOf(to) && info.PhysicalDamage+info.MagicDamage == 0 {
+		info.Effectiveness, info.Variance = 1, 1
+	} else {
+		CalculateDamage(r.Calculator, info, fromPet, toPet, r.rng)
+	}
+
+	// 防御方buff和被动技能
+	for _, v := range toPet.Buffs {
+		v.Defend(info)
+	}
+	for _, v := range toPet.Passives() {
+		v.Defend(info, toPet)
+	}
 
-	// 应用
+	// 应用，治疗和恢复魔力只有命中时才会生效，自身伤害无论是否命中都会生效
 	toPet.Stats().HP = int(math.Max(0, float64(toPet.Stats().HP-info.PhysicalDamage-info.MagicDamage)))
+	if info.Missed {
+		info.Heal, info.ManaRestore = 0, 0
+	}
+	if info.Heal > 0 && toPet.Stats().HP > 0 {
+		info.Heal = min(info.Heal, toPet.Stats().MaxHP-toPet.Stats().HP)
+		toPet.Stats().HP += info.Heal
+	} else {
+		info.Heal = 0
+	}
+	if info.ManaRestore > 0 && toPet.Stats().HP > 0 {
+		info.ManaRestore = min(info.ManaRestore, toPet.Stats().MaxMana-toPet.Stats().Mana)
+		toPet.Stats().Mana += info.ManaRestore
+	} else {
+		info.ManaRestore = 0
+	}
+	if info.ReflectDamage > 0 {
+		fromPet.Stats().HP = max(0, fromPet.Stats().HP-info.ReflectDamage)
+	}
+	if info.SelfDamage > 0 {
+		fromPet.Stats().HP = max(0, fromPet.Stats().HP-info.SelfDamage)
+	}
 
-	// 发送消息
-	petStats := make([]*packets.PetStatsMessage, 2)
-	for i := 0; i < 2; i++ {
-		s := r.Players[i].CurrentPet().Stats()
-		petStats[i] = &packets.PetStatsMessage{
-			MaxHp:        int64(s.MaxHP),
-			Hp:           int64(s.HP),
-			MaxMana:      int64(s.MaxMana),
-			Mana:         int64(s.Mana),
-			Strength:     int64(s.Strength),
-			Intelligence: int64(s.Intelligence),
-			Speed:        int64(s.Speed),
-			Defense:      int64(s.Defense),
+	// 附加buff，作用于对方的buff只有命中时才会附加
+	for _, v := range info.BuffDamage {
+		owner, pet := to, toPet
+		if v.Self {
+			owner, pet = from, fromPet
+		} else if info.Missed {
+			continue
+		}
+		buff := pet.AddBuff(v.ID, v.Level)
+		if buff == nil {
+			continue
 		}
+		r.SendEvent(&BattleEvent{Type: BuffApplied, Number: owner, Pet: pet, Source: fromPet, Attack: info, Buff: buff})
 	}
+
+	// 发送消息
 	attackStats := packets.AttackStatsMessage{
 		Number:         int64(from),
+		Slot:           int64(r.SlotOf(from, fromPet)),
+		Target:         &packets.BattleTarget{Number: int64(to), Slot: int64(r.SlotOf(to, toPet))},
 		SkillId:        info.Skill,
 		PhysicalDamage: int64(info.PhysicalDamage),
 		MagicDamage:    int64(info.MagicDamage),
-		// TODO 传递buff
-		Buffs:    nil,
-		PetStats: petStats,
+		Effectiveness:  float32(info.Effectiveness),
+		SameElement:    info.SameElement,
+		Missed:         info.Missed,
+		Critical:       info.Critical,
+		Variance:       float32(info.Variance),
+		ReflectDamage:  int64(info.ReflectDamage),
+		Heal:           int64(info.Heal),
+		ManaRestore:    int64(info.ManaRestore),
+		SelfDamage:     int64(info.SelfDamage),
+		Buffs:          r.buffMessages(),
+		PetStats:       r.petStatsMessages(),
+	}
+	r.publish(&packets.BattlePacket_AttackStats{AttackStats: &attackStats})
+
+	if damage := info.PhysicalDamage + info.MagicDamage; damage > 0 {
+		r.SendEvent(&BattleEvent{Type: DamageDealt, Number: to, Pet: toPet, Source: fromPet, Attack: info, Damage: damage})
 	}
-	r.Players[0].ProcessMessage(&packets.BattlePacket_AttackStats{AttackStats: &attackStats})
-	r.Players[1].ProcessMessage(&packets.BattlePacket_AttackStats{AttackStats: &attackStats})
+	if info.ReflectDamage > 0 {
+		r.SendEvent(&BattleEvent{Type: DamageDealt, Number: from, Pet: fromPet, Source: toPet, Attack: info, Damage: info.ReflectDamage})
+	}
+	if info.SelfDamage > 0 {
+		r.SendEvent(&BattleEvent{Type: DamageDealt, Number: from, Pet: fromPet, Source: fromPet, Attack: info, Damage: info.SelfDamage})
+	}
+	// 宠物已经因为之前的攻击阵亡时不再重复发送事件
+	if toPet.Stats().HP <= 0 && toHP > 0 {
+		r.SendEvent(&BattleEvent{Type: PetFainted, Number: to, Pet: toPet, Source: fromPet, Attack: info})
+	}
+	if !r.End && fromPet != toPet && fromPet.Stats().HP <= 0 && fromHP > 0 && r.SlotOf(from, fromPet) >= 0 {
+		r.SendEvent(&BattleEvent{Type: PetFainted, Number: from, Pet: fromPet, Source: toPet, Attack: info})
+	}
+}
+
+// HealPet 为宠物恢复生命值，并同步给双方
+func (r *BattleRoom) HealPet(pet *BattlePet, heal int) {
+	number := r.NumberOf(pet)
+	if number < 0 || heal <= 0 {
+		return
+	}
+	heal = min(heal, pet.Stats().MaxHP-pet.Stats().HP)
+	pet.Stats().HP += heal
+	attackStats := packets.AttackStatsMessage{
+		Number:   int64(number),
+		Slot:     int64(r.SlotOf(number, pet)),
+		Heal:     int64(heal),
+		Buffs:    r.buffMessages(),
+		PetStats: r.petStatsMessages(),
+	}
+	r.publish(&packets.BattlePacket_AttackStats{AttackStats: &attackStats})
+}
 
-	if toPet.Stats().HP <= 0 {
-		r.SendEvent(7, to)
+// NumberOf 返回宠物所属的一方，宠物不在战斗中时返回-1
+func (r *BattleRoom) NumberOf(pet *BattlePet) int {
+	for i, v := range r.Players {
+		for _, k := range v.EquippedPets() {
+			if k != nil && k == pet {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// petStatsMessages 所有在场宠物的属性，下标为 actorIndex
+func (r *BattleRoom) petStatsMessages() []*packets.PetStatsMessage {
+	petStats := make([]*packets.PetStatsMessage, len(r.Players)*r.Format.ActivePets)
+	for i := range r.active {
+		for slot, pet := range r.active[i] {
+			if pet == nil || pet.Pet == nil {
+				continue
+			}
+			s := pet.Stats()
+			petStats[r.actorIndex(i, slot)] = &packets.PetStatsMessage{
+				MaxHp:        int64(s.MaxHP),
+				Hp:           int64(s.HP),
+				MaxMana:      int64(s.MaxMana),
+				Mana:         int64(s.Mana),
+				Strength:     int64(s.Strength),
+				Intelligence: int64(s.Intelligence),
+				Speed:        int64(s.Speed),
+				Defense:      int64(s.Defense),
+			}
+		}
+	}
+	return petStats
+}
+
+// buffMessages 所有在场宠物身上的buff
+func (r *BattleRoom) buffMessages() []*packets.Buff {
+	res := make([]*packets.Buff, 0)
+	for i := range r.active {
+		for slot, pet := range r.active[i] {
+			if pet == nil {
+				continue
+			}
+			for _, v := range pet.Buffs {
+				res = append(res, &packets.Buff{
+					Id:     uint32(v.ID()),
+					Level:  int64(v.Level()),
+					Number: int64(i),
+					Rounds: int64(v.Rounds()),
+					Slot:   int64(slot),
+				})
+			}
+		}
+	}
+	return res
+}
+
+// tickBuffs 回合开始时结算所有在场宠物身上的buff伤害
+func (r *BattleRoom) tickBuffs() {
+	for i := range r.active {
+		for slot, pet := range r.active[i] {
+			if !alive(pet) || len(pet.Buffs) == 0 {
+				continue
+			}
+			damage := 0
+			for _, v := range pet.Buffs {
+				damage += v.Tick()
+			}
+			if damage == 0 {
+				continue
+			}
+			pet.Stats().HP = max(0, pet.Stats().HP-damage)
+			attackStats := packets.AttackStatsMessage{
+				Number:     int64(i),
+				Slot:       int64(slot),
+				BuffDamage: int64(damage),
+				Buffs:      r.buffMessages(),
+				PetStats:   r.petStatsMessages(),
+			}
+			r.publish(&packets.BattlePacket_AttackStats{AttackStats: &attackStats})
+			r.SendEvent(&BattleEvent{Type: DamageDealt, Number: i, Pet: pet, Damage: damage})
+			if pet.Stats().HP <= 0 {
+				r.SendEvent(&BattleEvent{Type: PetFainted, Number: i, Pet: pet})
+				if r.End {
+					return
+				}
+			}
+		}
+	}
+}
+
+// updateBuffs 回合结束时减少buff的剩余回合并移除失效的buff，有变化时同步给客户端
+func (r *BattleRoom) updateBuffs() {
+	changed := false
+	for _, v := range r.Players {
+		for _, k := range v.EquippedPets() {
+			if k != nil && k.Pet != nil && k.updateBuffs() {
+				changed = true
+			}
+		}
+	}
+	if changed {
+		attackStats := packets.AttackStatsMessage{
+			Buffs:    r.buffMessages(),
+			PetStats: r.petStatsMessages(),
+		}
+		r.publish(&packets.BattlePacket_AttackStats{AttackStats: &attackStats})
 	}
 }
 
 type BattlePlayer interface {
 	ProcessMessage(message packets.BattleMsg)
+	// CurrentPet 第一个位置的在场宠物，其余位置由房间管理
 	CurrentPet() *BattlePet
 	SetCurrentPet(pet *BattlePet)
 	EquippedPets() [5]*BattlePet
@@ -408,6 +709,117 @@ type BattlePlayer interface {
 type BattlePet struct {
 	Pet
 	Buffs []Buff
+	// 技能剩余的冷却回合数以及本场战斗中已使用的次数
+	coolDowns map[uint32]int
+	uses      map[uint32]int
+	// 战斗中临时的属性修正，例如被动技能带来的加成
+	modifiers map[string]Stats
+	// participated 是否上场过，上场过的宠物才能获得经验
+	participated bool
+}
+
+// SetModifier 设置一项战斗中的属性修正，相同来源的修正会被覆盖
+func (b *BattlePet) SetModifier(source string, stats Stats) {
+	if b.modifiers == nil {
+		b.modifiers = make(map[string]Stats)
+	}
+	b.modifiers[source] = stats
+}
+
+func (b *BattlePet) RemoveModifier(source string) {
+	delete(b.modifiers, source)
+}
+
+// BattleStats 加上战斗中属性修正后的属性
+func (b *BattlePet) BattleStats() Stats {
+	res := *b.Stats()
+	for _, v := range b.modifiers {
+		res.Strength += v.Strength
+		res.Intelligence += v.Intelligence
+		res.Speed += v.Speed
+		res.Defense += v.Defense
+	}
+	return res
+}
+
+// CheckSkill 检查宠物当前是否可以使用该技能
+func (b *BattlePet) CheckSkill(skill Skill) error {
+	if b.Stats().Mana < skill.Cost() {
+		return errors.New("not enough mana")
+	}
+	if b.coolDowns[skill.ID()] > 0 {
+		return errors.New("the skill is cooling down")
+	}
+	if skill.MaxUses() > 0 && b.uses[skill.ID()] >= skill.MaxUses() {
+		return errors.New("the skill has no uses left")
+	}
+	return nil
+}
+
+// UseSkill 使用技能时扣除魔力值，并记录冷却和使用次数
+func (b *BattlePet) UseSkill(skill Skill) {
+	if b.coolDowns == nil {
+		b.coolDowns = make(map[uint32]int)
+		b.uses = make(map[uint32]int)
+	}
+	b.Stats().Mana = max(0, b.Stats().Mana-skill.Cost())
+	if skill.CoolDown() > 0 {
+		// 回合结束时会减少一次冷却，所以需要包含当前回合
+		b.coolDowns[skill.ID()] = skill.CoolDown() + 1
+	}
+	b.uses[skill.ID()]++
+}
+
+// AddBuff 为宠物添加buff，已有相同buff时叠加层数，返回宠物身上的该buff
+func (b *BattlePet) AddBuff(id int, level int) Buff {
+	for _, v := range b.Buffs {
+		if v.ID() == id {
+			v.Update(level)
+			return v
+		}
+	}
+	buff := BuffManager.NewBuff(id, level)
+	if buff == nil {
+		return nil
+	}
+	buff.SetPet(b)
+	b.Buffs = append(b.Buffs, buff)
+	return buff
+}
+
+// CanAct 宠物身上有阻止行动的buff时无法行动
+func (b *BattlePet) CanAct() bool {
+	for _, v := range b.Buffs {
+		if blocker, ok := v.(ActionBlocker); ok && blocker.BlockAction() {
+			return false
+		}
+	}
+	return true
+}
+
+// updateBuffs 减少buff的剩余回合并移除失效的buff，返回是否有buff被移除
+func (b *BattlePet) updateBuffs() bool {
+	buffs := make([]Buff, 0, len(b.Buffs))
+	for _, v := range b.Buffs {
+		v.RoundEnd()
+		if v.Rounds() > 0 {
+			buffs = append(buffs, v)
+		}
+	}
+	removed := len(buffs) != len(b.Buffs)
+	b.Buffs = buffs
+	return removed
+}
+
+// UpdateCoolDown 回合结束时减少技能冷却
+func (b *BattlePet) UpdateCoolDown() {
+	for id, v := range b.coolDowns {
+		if v <= 1 {
+			delete(b.coolDowns, id)
+		} else {
+			b.coolDowns[id] = v - 1
+		}
+	}
 }
 
 type AttackInfo struct {
@@ -418,23 +830,27 @@ type AttackInfo struct {
 	MagicDamage    int
 	BuffDamage     []*BuffDamage
 	PetStats       [2]Stats
+	// Effectiveness 属性克制倍率，SameElement 是否获得同属性加成
+	Effectiveness float64
+	SameElement   bool
+	// Missed 是否未命中，Critical 是否暴击，Variance 伤害随机浮动倍率
+	Missed   bool
+	Critical bool
+	Variance float64
+	// ReflectDamage 反弹给攻击方的伤害
+	ReflectDamage int
+	// Heal 目标恢复的生命值，ManaRestore 目标恢复的魔力值，结算后为实际恢复的数值
+	Heal        int
+	ManaRestore int
+	// SelfDamage 攻击方对自身造成的伤害，例如自爆技能
+	SelfDamage int
 }
 
 type BuffDamage struct {
 	ID    int
 	Level int
-}
-
-type Buff interface {
-	ID() int
-	Update(updateLevel int)
-	SetPet(pet *BattlePet)
-	Use(attack *AttackInfo)
-	IsPositive() bool
-}
-
-// BattleEndStats 战斗结束后的统计数据
-type BattleEndStats struct {
+	// Self 为true时buff作用于攻击方自身
+	Self bool
 }
 
 type Command struct {
@@ -442,27 +858,18 @@ type Command struct {
 	Number int
 }
 
-func (r *BattleRoom) ReplacePlayerAuto(number int) {
-	r.Players[number] = &AutoBattlePlayer{CommandChan: r.CommandChan, NextRoundChan: r.NextRoundChan}
-	if r.CurrentStage.Load() == 1 {
-		r.CommandChan <- &Command{
-			Msg: &packets.RoundCommandMessage{
-				Command: &packets.RoundCommandMessage_Runaway{},
-			},
-			Number: number,
-		}
-	} else if r.CurrentStage.Load() == 2 {
-		r.NextRoundChan <- number
-	}
-}
-
+// AutoBattlePlayer 玩家掉线后接管的一方，保留原来的队伍并自动逃跑
 type AutoBattlePlayer struct {
-	Number        int
-	CommandChan   chan *Command
-	NextRoundChan chan int
+	Number      int
+	room        *BattleRoom
+	currentPet  *BattlePet
+	equippedPet [5]*BattlePet
 }
 
 func (a *AutoBattlePlayer) ProcessMessage(message packets.BattleMsg) {
+	if a.room == nil {
+		return
+	}
 	switch message.(type) {
 	case *packets.BattlePacket_StartNextRound:
 		// 自动输入逃跑指令
@@ -472,27 +879,27 @@ func (a *AutoBattlePlayer) ProcessMessage(message packets.BattleMsg) {
 			},
 			Number: a.Number,
 		}
-		a.CommandChan <- cmd
+		a.room.PushCommand(cmd)
 	case *packets.BattlePacket_RoundEnd:
-		a.NextRoundChan <- a.Number
+		a.room.PushReady(a.Number)
 	}
 	// 其它消息不处理
 }
 
 func (a *AutoBattlePlayer) CurrentPet() *BattlePet {
-	return nil
+	return a.currentPet
 }
 
 func (a *AutoBattlePlayer) SetCurrentPet(pet *BattlePet) {
-	// 不做任何操作
+	a.currentPet = pet
 }
 
 func (a *AutoBattlePlayer) EquippedPets() [5]*BattlePet {
-	return [5]*BattlePet{&BattlePet{Pet: nil}, &BattlePet{Pet: nil}, &BattlePet{Pet: nil}, &BattlePet{Pet: nil}, &BattlePet{Pet: nil}}
+	return a.equippedPet
 }
 
 func (a *AutoBattlePlayer) SetBattleRoom(room *BattleRoom) {
-
+	a.room = room
 }
 
 func (a *AutoBattlePlayer) UserName() string {
@@ -531,5 +938,25 @@ func newPetMessage(pet Pet) *packets.PetMessage {
 	}
 	res.EquippedSkills = equippedSkills
 	res.PetStats = &stats
+	for _, v := range pet.Passives() {
+		res.Passives = append(res.Passives, v.ID())
+	}
+	res.Talent = NewPetTalentMessage(pet.Talent())
 	return &res
 }
+
+// NewPetTalentMessage 宠物天赋的消息，战斗和背包中的宠物共用，pkg/utils 依赖 objects，因此放在这里
+func NewPetTalentMessage(talent *Talent) *packets.PetTalentMessage {
+	if talent == nil {
+		return nil
+	}
+	return &packets.PetTalentMessage{
+		Nature:       talent.Nature,
+		Hp:           int64(talent.HP),
+		Mana:         int64(talent.Mana),
+		Strength:     int64(talent.Strength),
+		Intelligence: int64(talent.Intelligence),
+		Speed:        int64(talent.Speed),
+		Defense:      int64(talent.Defense),
+	}
+}
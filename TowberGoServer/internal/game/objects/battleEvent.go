@@ -0,0 +1,194 @@
+package objects
+
+import "TowberGoServer/pkg/packets"
+
+type BattleEventType int
+
+const (
+	// BattleStarted 进入战斗
+	BattleStarted BattleEventType = iota + 1
+	// RoundStarted 新回合开始
+	RoundStarted
+	// RoundEnded 回合结束
+	RoundEnded
+	// DamageDealt 宠物受到伤害
+	DamageDealt
+	// BuffApplied 宠物获得buff
+	BuffApplied
+	// PetFainted 宠物死亡
+	PetFainted
+	// PetSwitched 玩家更换了当前宠物
+	PetSwitched
+	// SkillUsed 宠物释放技能
+	SkillUsed
+	// BattleEnded 战斗结束
+	BattleEnded
+	// MessageSent 引擎需要发送的消息，只返回给驱动，由驱动发送，不会通知订阅者和宠物
+	MessageSent
+)
+
+// broadcastNumber 发送给所有玩家以及观战者的消息使用的 Number
+const broadcastNumber = -1
+
+// BattleEvent 战斗事件
+// Number 为事件所属的一方，Pet 为事件所属的宠物，例如受到伤害、获得buff、死亡、换上场以及释放技能的宠物
+// Source 为引发事件的另一只宠物，例如造成伤害的宠物、被换下场的宠物
+// MessageSent 事件的 Message 为需要发送的消息，Number 为接收的一方，broadcastNumber 时发送给所有玩家以及观战者
+type BattleEvent struct {
+	Type    BattleEventType
+	Number  int
+	Pet     *BattlePet
+	Source  *BattlePet
+	Attack  *AttackInfo
+	Skill   Skill
+	Buff    Buff
+	Damage  int
+	Message packets.BattleMsg
+}
+
+type BattleEventHandler func(event *BattleEvent)
+
+type battleSubscription struct {
+	id      int
+	handler BattleEventHandler
+}
+
+// Subscribe 订阅指定类型的战斗事件，返回的编号用于取消订阅
+func (r *BattleRoom) Subscribe(eventType BattleEventType, handler BattleEventHandler) int {
+	r.eventLock.Lock()
+	defer r.eventLock.Unlock()
+	if r.handlers == nil {
+		r.handlers = make(map[BattleEventType][]*battleSubscription)
+	}
+	r.subscriptionID++
+	r.handlers[eventType] = append(r.handlers[eventType], &battleSubscription{id: r.subscriptionID, handler: handler})
+	return r.subscriptionID
+}
+
+// Unsubscribe 取消订阅
+func (r *BattleRoom) Unsubscribe(id int) {
+	r.eventLock.Lock()
+	defer r.eventLock.Unlock()
+	for eventType, list := range r.handlers {
+		for i, v := range list {
+			if v.id == id {
+				r.handlers[eventType] = append(list[:i:i], list[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// SendEvent 发送事件，先通知所有订阅者，再通知双方所有的宠物以及在场宠物的被动技能
+func (r *BattleRoom) SendEvent(event *BattleEvent) {
+	r.eventLock.Lock()
+	handlers := make([]*battleSubscription, len(r.handlers[event.Type]))
+	copy(handlers, r.handlers[event.Type])
+	r.eventLock.Unlock()
+	r.emitted = append(r.emitted, event)
+	for _, v := range handlers {
+		v.handler(event)
+	}
+
+	for i, v := range r.Players {
+		self := event.Number == i
+		for _, k := range v.EquippedPets() {
+			if k == nil || k.Pet == nil {
+				continue
+			}
+			k.GetEvent(event, self, r)
+		}
+		// 只有在场的宠物会触发被动技能
+		for _, pet := range r.active[i] {
+			if pet == nil || pet.Pet == nil {
+				continue
+			}
+			for _, k := range pet.Passives() {
+				k.GetEvent(event, pet, r)
+			}
+		}
+	}
+}
+
+// publish 引擎向所有玩家以及观战者发送公开的消息，在这一步结束后由驱动发送
+func (r *BattleRoom) publish(message packets.BattleMsg) {
+	r.tell(broadcastNumber, message)
+}
+
+// tell 引擎向一方发送消息，在这一步结束后由驱动发送
+func (r *BattleRoom) tell(number int, message packets.BattleMsg) {
+	r.emitted = append(r.emitted, &BattleEvent{Type: MessageSent, Number: number, Message: message})
+}
+
+// deliver 发送引擎一步中产生的消息，只由驱动调用
+func (r *BattleRoom) deliver(events []*BattleEvent) []*BattleEvent {
+	for _, v := range events {
+		if v.Type != MessageSent {
+			continue
+		}
+		if v.Number == broadcastNumber {
+			r.Broadcast(v.Message)
+		} else if v.Number >= 0 && v.Number < len(r.Players) {
+			r.Players[v.Number].ProcessMessage(v.Message)
+		}
+	}
+	return events
+}
+
+// registerHandlers 注册房间自身需要处理的事件
+func (r *BattleRoom) registerHandlers() {
+	r.Subscribe(RoundStarted, func(event *BattleEvent) {
+		// 回合开始时结算buff伤害
+		r.tickBuffs()
+	})
+	r.Subscribe(RoundEnded, func(event *BattleEvent) {
+		// 回合结束后减少所有宠物的技能冷却和buff回合
+		for _, v := range r.Players {
+			for _, k := range v.EquippedPets() {
+				if k != nil && k.Pet != nil {
+					k.UpdateCoolDown()
+				}
+			}
+		}
+		r.updateBuffs()
+	})
+	r.Subscribe(PetFainted, r.onPetFainted)
+	r.Subscribe(BattleStarted, r.markParticipated)
+	r.Subscribe(PetSwitched, r.markParticipated)
+	r.Subscribe(BattleEnded, r.updateLeaderboard)
+}
+
+// onPetFainted 宠物死亡后检查队伍是否全部阵亡，否则在当前行动结算完成后要求玩家更换该位置的宠物，没有替补时位置保持空缺
+func (r *BattleRoom) onPetFainted(event *BattleEvent) {
+	target := event.Number
+	if !r.teamAlive(r.TeamOf(target)) {
+		r.Defeat(target)
+		return
+	}
+	slot := r.SlotOf(target, event.Pet)
+	if slot < 0 || r.reserve(target) < 0 {
+		return
+	}
+	r.switches = append(r.switches, pendingSwitch{number: target, slot: slot})
+}
+
+// switchPet 更换一方指定位置的在场宠物并发送事件，第一个位置同时作为玩家的当前宠物
+func (r *BattleRoom) switchPet(number int, slot int, pet *BattlePet) {
+	previous := r.active[number][slot]
+	r.active[number][slot] = pet
+	if slot == 0 {
+		r.Players[number].SetCurrentPet(pet)
+	}
+	position := int64(-1)
+	for i, v := range r.Players[number].EquippedPets() {
+		if v != nil && v == pet {
+			position = int64(i)
+		}
+	}
+	r.publish(&packets.BattlePacket_PetSwitched{PetSwitched: &packets.PetSwitchedMessage{
+		Number:      int64(number),
+		Slot:        int64(slot),
+		PetPosition: position,
+	}})
+	r.SendEvent(&BattleEvent{Type: PetSwitched, Number: number, Pet: pet, Source: previous})
+}
@@ -1 +1,100 @@
 package objects
+
+// MaxBuffLevel buff能够叠加的最大层数
+const MaxBuffLevel = 5
+
+type Buff interface {
+	ID() int
+	// Level 当前叠加的层数
+	Level() int
+	// Update 再次获得相同的buff时叠加层数并刷新持续回合
+	Update(updateLevel int)
+	SetPet(pet *BattlePet)
+	// Use 宠物作为攻击方时修改攻击信息，在伤害计算之前调用
+	Use(attack *AttackInfo)
+	// Defend 宠物作为防御方时修改攻击信息，在伤害计算之后调用
+	Defend(attack *AttackInfo)
+	// Tick 回合开始时调用，返回buff对宠物造成的伤害
+	Tick() int
+	// RoundEnd 回合结束时调用，减少剩余回合数
+	RoundEnd()
+	// Rounds 剩余的回合数，为0时buff被移除
+	Rounds() int
+	IsPositive() bool
+	// Clone 创建一个指定层数的新buff
+	Clone(level int) Buff
+}
+
+// ActionBlocker 实现该接口的buff会阻止宠物行动，例如眩晕
+type ActionBlocker interface {
+	BlockAction() bool
+}
+
+// BaseBuff buff的通用实现，具体的buff嵌入后只需要实现自己关心的方法
+type BaseBuff struct {
+	level    int
+	rounds   int
+	duration int
+	pet      *BattlePet
+}
+
+// Initialize 初始化层数和持续回合
+func (b *BaseBuff) Initialize(level int, duration int) {
+	b.level = min(level, MaxBuffLevel)
+	b.duration = duration
+	b.rounds = duration
+}
+
+func (b *BaseBuff) Level() int {
+	return b.level
+}
+
+func (b *BaseBuff) Update(updateLevel int) {
+	b.level = min(b.level+updateLevel, MaxBuffLevel)
+	b.rounds = b.duration
+}
+
+func (b *BaseBuff) SetPet(pet *BattlePet) {
+	b.pet = pet
+}
+
+func (b *BaseBuff) Pet() *BattlePet {
+	return b.pet
+}
+
+func (b *BaseBuff) Use(attack *AttackInfo) {}
+
+func (b *BaseBuff) Defend(attack *AttackInfo) {}
+
+func (b *BaseBuff) Tick() int {
+	return 0
+}
+
+func (b *BaseBuff) RoundEnd() {
+	if b.rounds > 0 {
+		b.rounds--
+	}
+}
+
+func (b *BaseBuff) Rounds() int {
+	return b.rounds
+}
+
+// Expire 让buff在本回合结束后失效
+func (b *BaseBuff) Expire() {
+	b.rounds = 0
+}
+
+var BuffManager *BuffManagerStruct
+
+type BuffManagerStruct struct {
+	BuffList map[int]Buff
+}
+
+func (b *BuffManagerStruct) NewBuff(id int, level int) Buff {
+	template := b.BuffList[id]
+	if template == nil {
+		return nil
+	}
+	return template.Clone(level)
+}
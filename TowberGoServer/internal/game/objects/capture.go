@@ -0,0 +1,53 @@
+package objects
+
+import "TowberGoServer/pkg/packets"
+
+// CaptureChance 计算捕捉成功的概率，对方剩余血量越少、道具越强、种族越常见越容易捕捉
+func CaptureChance(target *BattlePet, item CaptureItem) float64 {
+	hp := float64(target.Stats().HP) / float64(max(target.Stats().MaxHP, 1))
+	return min(1, item.Strength()*target.CaptureRate()*(1-hp*2/3))
+}
+
+// capture 消耗捕捉道具尝试捕捉对方当前的宠物，只有单人对战可以捕捉，捕捉成功后保留宠物的等级、属性、天赋和技能交给玩家并结束战斗，返回是否捕捉成功
+func (r *BattleRoom) capture(number int, itemID uint32) bool {
+	player := r.Players[number].GetPlayer()
+	target := r.ActivePet(r.GetTheOtherPlayer(number), 0)
+	item, _ := PetItemManager.PetItemList[itemID].(CaptureItem)
+	if item == nil || target == nil || target.Pet == nil {
+		return false
+	}
+	// 回放时不修改玩家的数据
+	if r.replay == nil {
+		if player == nil {
+			return false
+		}
+		if err := PetItemManager.DeleteItem(player, itemID, 1); err != nil {
+			deny := &packets.BattlePacket_DenyCommand{DenyCommand: &packets.DenyCommandMessage{Reason: err.Error()}}
+			r.tell(number, deny)
+			return false
+		}
+	}
+
+	chance := CaptureChance(target, item)
+	success := r.rng.Float64() < chance
+	// 宠物保存失败时归还道具，按照捕捉失败处理，战斗继续
+	if success && r.replay == nil {
+		if _, _, err := PetManager.CreatePet(player, target.PetID(), target.Pet); err != nil {
+			PetItemManager.CompensateItem(player, itemID, 1)
+			r.tell(number, &packets.BattlePacket_DenyCommand{DenyCommand: &packets.DenyCommandMessage{Reason: err.Error()}})
+			success = false
+		}
+	}
+	msg := &packets.BattlePacket_CaptureResult{CaptureResult: &packets.CaptureResultMessage{
+		Number:  int64(number),
+		ItemId:  itemID,
+		Success: success,
+		Chance:  float32(chance),
+	}}
+	r.publish(msg)
+	if !success {
+		return false
+	}
+	r.EndBattle(r.TeamOf(number))
+	return true
+}
@@ -0,0 +1,116 @@
+package objects
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// DamageCalculator 伤害计算器，战斗中所有和伤害有关的公式都放在这里，平衡性调整时只需要替换计算器
+// 可以通过嵌入DefaultDamageCalculator只重写其中的某一步
+type DamageCalculator interface {
+	// Hit 根据技能命中率和防御方闪避判断攻击是否命中
+	Hit(info *AttackInfo, from *BattlePet, to *BattlePet, rng *rand.Rand) bool
+	// Attack 计算攻击方的属性加成以及属性克制
+	Attack(info *AttackInfo, from *BattlePet, to *BattlePet)
+	// Critical 判断是否暴击并返回暴击倍率
+	Critical(info *AttackInfo, from *BattlePet, to *BattlePet, rng *rand.Rand) (bool, float64)
+	// Mitigate 计算防御方的减伤
+	Mitigate(info *AttackInfo, from *BattlePet, to *BattlePet)
+	// Variance 伤害的随机浮动倍率
+	Variance(info *AttackInfo, rng *rand.Rand) float64
+}
+
+// CalculateDamage 按照 命中->攻击加成->暴击->防御减伤->随机浮动 的顺序计算攻击信息的最终伤害
+func CalculateDamage(c DamageCalculator, info *AttackInfo, from *BattlePet, to *BattlePet, rng *rand.Rand) {
+	info.Effectiveness = 1
+	info.Variance = 1
+	if !c.Hit(info, from, to, rng) {
+		info.Missed = true
+		info.PhysicalDamage, info.MagicDamage = 0, 0
+		return
+	}
+	c.Attack(info, from, to)
+
+	critical, rate := c.Critical(info, from, to, rng)
+	if critical {
+		info.Critical = true
+		info.PhysicalDamage = int(float64(info.PhysicalDamage) * rate)
+		info.MagicDamage = int(float64(info.MagicDamage) * rate)
+	}
+
+	c.Mitigate(info, from, to)
+
+	info.Variance = c.Variance(info, rng)
+	info.PhysicalDamage = int(math.Round(float64(info.PhysicalDamage) * info.Variance))
+	info.MagicDamage = int(math.Round(float64(info.MagicDamage) * info.Variance))
+}
+
+type DefaultDamageCalculator struct {
+	// MaxEvasion 速度带来的最大闪避率
+	MaxEvasion float64
+	// CriticalRate 基础暴击率，CriticalMultiplier 暴击伤害倍率
+	CriticalRate       float64
+	CriticalMultiplier float64
+	// DefenseFactor 防御减伤系数，减伤比例为 defense / (defense + DefenseFactor)
+	DefenseFactor float64
+	// MinVariance 随机浮动的下限，上限为1
+	MinVariance float64
+}
+
+var DefaultCalculator = &DefaultDamageCalculator{
+	MaxEvasion:         0.2,
+	CriticalRate:       0.0625,
+	CriticalMultiplier: 1.5,
+	DefenseFactor:      100,
+	MinVariance:        0.85,
+}
+
+func (d *DefaultDamageCalculator) Hit(info *AttackInfo, from *BattlePet, to *BattlePet, rng *rand.Rand) bool {
+	accuracy := 1.0
+	if skill := SkillManager.SkillList[info.Skill]; skill != nil {
+		accuracy = float64(skill.Accuracy()) / 100
+	}
+	// 防御方速度高于攻击方时获得闪避
+	evasion := 0.0
+	fromSpeed, toSpeed := float64(from.BattleStats().Speed), float64(to.BattleStats().Speed)
+	if toSpeed > fromSpeed {
+		evasion = math.Min(d.MaxEvasion, (toSpeed-fromSpeed)/(toSpeed+fromSpeed))
+	}
+	return rng.Float64() < accuracy*(1-evasion)
+}
+
+func (d *DefaultDamageCalculator) Attack(info *AttackInfo, from *BattlePet, to *BattlePet) {
+	// 自身加成
+	stats := from.BattleStats()
+	info.PhysicalDamage = int(float64(info.PhysicalDamage) * (1 + float64(stats.Strength)*0.01))
+	info.MagicDamage = int(float64(info.MagicDamage) * (1 + float64(stats.Intelligence)*0.01))
+
+	// 属性克制
+	if skill := SkillManager.SkillList[info.Skill]; skill != nil {
+		rate := Effectiveness(skill.Element(), to.Elements())
+		info.Effectiveness = rate
+		if HasElement(from.Elements(), skill.Element()) {
+			info.SameElement = true
+			rate *= SameElementBonus
+		}
+		info.PhysicalDamage = int(float64(info.PhysicalDamage) * rate)
+		info.MagicDamage = int(float64(info.MagicDamage) * rate)
+	}
+}
+
+func (d *DefaultDamageCalculator) Critical(info *AttackInfo, from *BattlePet, to *BattlePet, rng *rand.Rand) (bool, float64) {
+	return rng.Float64() < d.CriticalRate, d.CriticalMultiplier
+}
+
+func (d *DefaultDamageCalculator) Mitigate(info *AttackInfo, from *BattlePet, to *BattlePet) {
+	// 物理伤害由防御减免，魔法伤害由防御和智力的平均值减免
+	stats := to.BattleStats()
+	physical := float64(stats.Defense)
+	magic := float64(stats.Defense+stats.Intelligence) / 2
+	info.PhysicalDamage = int(float64(info.PhysicalDamage) * (1 - physical/(physical+d.DefenseFactor)))
+	info.MagicDamage = int(float64(info.MagicDamage) * (1 - magic/(magic+d.DefenseFactor)))
+}
+
+func (d *DefaultDamageCalculator) Variance(info *AttackInfo, rng *rand.Rand) float64 {
+	return d.MinVariance + rng.Float64()*(1-d.MinVariance)
+}
@@ -0,0 +1,308 @@
+package objects
+
+import (
+	"TowberGoServer/pkg/packets"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DisconnectPolicy 玩家在战斗中掉线后的处理方式
+type DisconnectPolicy uint32
+
+const (
+	// DisconnectForfeit 掉线直接认输
+	DisconnectForfeit DisconnectPolicy = iota
+	// DisconnectAI 由AI使用掉线玩家的队伍继续战斗
+	DisconnectAI
+	// DisconnectWait 暂停战斗等待玩家重连，超时后认输
+	DisconnectWait
+)
+
+// BattleConfig 战斗房间的配置，排位赛和活动战斗可以使用不同的配置
+type BattleConfig struct {
+	Disconnect       DisconnectPolicy
+	ReconnectTimeout time.Duration
+	// AIDifficulty 掉线托管时AI的难度
+	AIDifficulty AIDifficulty
+	// AllowCapture 是否允许捕捉对方的宠物，只有野生宠物战斗允许
+	AllowCapture bool
+	// MaxSpectators 观战人数上限
+	MaxSpectators int
+	// Timer 回合计时以及超时后的默认行动
+	Timer BattleTimer
+}
+
+var DefaultBattleConfig = BattleConfig{
+	Disconnect:       DisconnectForfeit,
+	ReconnectTimeout: 30 * time.Second,
+	AIDifficulty:     AINormal,
+	MaxSpectators:    8,
+	Timer:            DefaultBattleTimer,
+}
+
+// ReplacePlayerAuto 玩家掉线后交给战斗协程替换该玩家，战斗已经结束时直接返回
+func (r *BattleRoom) ReplacePlayerAuto(number int) {
+	select {
+	case r.disconnects <- number:
+	case <-r.done:
+	}
+}
+
+// replacePlayer 在战斗协程中根据房间配置替换掉线的玩家，补上当前阶段的操作，并通知其他玩家
+func (r *BattleRoom) replacePlayer(number int) {
+	if number < 0 || number >= len(r.Players) || r.stage == StageEnded {
+		return
+	}
+	origin := r.Players[number]
+	policy := r.Config.Disconnect
+	if policy == DisconnectWait && origin.GetPlayer() == nil {
+		policy = DisconnectForfeit
+	}
+
+	timeout := time.Duration(0)
+	switch policy {
+	case DisconnectAI:
+		ai := NewTakeoverAIBattlePlayer(number, r.Config.AIDifficulty, origin)
+		ai.SetBattleRoom(r)
+		r.Players[number] = ai
+	case DisconnectWait:
+		timeout = r.Config.ReconnectTimeout
+		waiting := &ReconnectingBattlePlayer{
+			Number:      number,
+			UID:         origin.GetPlayer().UID,
+			Deadline:    r.Clock.Now().Add(timeout),
+			name:        origin.UserName(),
+			player:      origin.GetPlayer(),
+			currentPet:  origin.CurrentPet(),
+			equippedPet: origin.EquippedPets(),
+			reconnected: make(chan struct{}, 1),
+		}
+		waiting.SetBattleRoom(r)
+		r.Players[number] = waiting
+		BattleManager.addReconnect(waiting)
+	default:
+		r.Players[number] = &AutoBattlePlayer{
+			Number:      number,
+			room:        r,
+			currentPet:  origin.CurrentPet(),
+			equippedPet: origin.EquippedPets(),
+		}
+	}
+
+	msg := &packets.BattlePacket_PlayerDisconnected{PlayerDisconnected: &packets.PlayerDisconnectedMessage{
+		Number:  int64(number),
+		Policy:  uint32(policy),
+		Timeout: int64(timeout.Seconds()),
+	}}
+	r.sendOthers(number, msg)
+
+	if policy == DisconnectForfeit {
+		r.record(ReplayInputForfeit, number)
+		r.deliver(r.Forfeit(number))
+		return
+	}
+	r.catchUp(number, 0)
+}
+
+// catchUp 向接替的一方补发当前阶段还没有完成的请求，remaining 为当前阶段剩余的时间
+func (r *BattleRoom) catchUp(number int, remaining time.Duration) {
+	switch r.stage {
+	case StageCommand:
+		if r.waitingFor(r.commands, number) {
+			r.Players[number].ProcessMessage(&packets.BattlePacket_StartNextRound{StartNextRound: &packets.StartNextRoundMessage{
+				Timeout: int64(remaining.Seconds()),
+			}})
+		}
+	case StageSwitch:
+		for _, v := range r.switches {
+			if v.number == number && v.requested {
+				r.Players[number].ProcessMessage(&packets.BattlePacket_ChangePetRequest{ChangePetRequest: &packets.ChangePetRequestMessage{
+					Slot:    int64(v.slot),
+					Timeout: int64(remaining.Seconds()),
+				}})
+			}
+		}
+	case StageNextRound:
+		if !r.ready[number] {
+			r.Players[number].ProcessMessage(&packets.BattlePacket_RoundEnd{RoundEnd: &packets.RoundEndMessage{}})
+		}
+	}
+}
+
+// connected 一方是否仍由原来的玩家操作，掉线后被替换的一方返回false
+func (r *BattleRoom) connected(number int) bool {
+	switch r.Players[number].(type) {
+	case *AIBattlePlayer, *ReconnectingBattlePlayer, *AutoBattlePlayer:
+		return false
+	}
+	return true
+}
+
+// reconnecting 是否有掉线的玩家正在等待重连
+func (r *BattleRoom) reconnecting() bool {
+	return slices.ContainsFunc(r.Players, func(v BattlePlayer) bool {
+		_, ok := v.(*ReconnectingBattlePlayer)
+		return ok
+	})
+}
+
+// waitReconnect 在回合开始前以及等待输入时等待掉线的玩家重连，超时则判负
+// remaining 为当前阶段剩余的时间，重连后补发给玩家
+func (r *BattleRoom) waitReconnect(remaining time.Duration) {
+	for i, v := range r.Players {
+		waiting, ok := v.(*ReconnectingBattlePlayer)
+		if !ok {
+			continue
+		}
+		deadline := r.Clock.After(waiting.Deadline.Sub(r.Clock.Now()))
+		for !waiting.resume(r) {
+			select {
+			case <-waiting.reconnected:
+			case number := <-r.disconnects:
+				// 等待期间其他玩家掉线，认输时战斗直接结束
+				r.replacePlayer(number)
+				if r.stage == StageEnded {
+					BattleManager.removeReconnect(waiting)
+					return
+				}
+			case join := <-r.joins:
+				r.addSpectator(join)
+			case <-deadline:
+				BattleManager.removeReconnect(waiting)
+				r.record(ReplayInputForfeit, i)
+				r.deliver(r.Forfeit(i))
+				return
+			}
+		}
+		BattleManager.removeReconnect(waiting)
+		// 重新同步每一方的信息，重连的玩家还需要战斗的快照
+		for k := range r.Players {
+			r.SyncPlayerInformation(k)
+		}
+		r.Players[i].ProcessMessage(&packets.BattlePacket_BattleSnapshot{BattleSnapshot: r.snapshot()})
+		msg := &packets.BattlePacket_PlayerReconnected{PlayerReconnected: &packets.PlayerReconnectedMessage{Number: int64(i)}}
+		r.sendOthers(i, msg)
+		r.catchUp(i, remaining)
+	}
+}
+
+// ReconnectingBattlePlayer 等待重连的一方，保留掉线玩家的队伍
+type ReconnectingBattlePlayer struct {
+	Number   int
+	UID      uint32
+	Deadline time.Time
+
+	name        string
+	player      *Player
+	room        *BattleRoom
+	currentPet  *BattlePet
+	equippedPet [5]*BattlePet
+
+	lock        sync.Mutex
+	pending     BattlePlayer
+	ended       bool
+	reconnected chan struct{}
+}
+
+// Reconnect 玩家重新登录后接管战斗，战斗已经结束时返回false
+func (w *ReconnectingBattlePlayer) Reconnect(player BattlePlayer) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.ended {
+		return false
+	}
+	w.pending = player
+	select {
+	case w.reconnected <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// Cancel 重连的玩家在接管战斗前再次掉线，返回是否成功取消
+func (w *ReconnectingBattlePlayer) Cancel(player BattlePlayer) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.pending != player {
+		return false
+	}
+	w.pending = nil
+	return true
+}
+
+// resume 在战斗协程中将重连的玩家放回房间
+func (w *ReconnectingBattlePlayer) resume(r *BattleRoom) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.pending == nil {
+		return false
+	}
+	w.pending.SetBattleRoom(r)
+	r.Players[w.Number] = w.pending
+	w.pending = nil
+	return true
+}
+
+func (w *ReconnectingBattlePlayer) ProcessMessage(message packets.BattleMsg) {
+	switch message.(type) {
+	case *packets.BattlePacket_RoundEnd:
+		w.room.PushReady(w.Number)
+	case *packets.BattlePacket_BattleEnd:
+		// 已经重连但还没有回到房间的玩家也需要结束战斗
+		w.lock.Lock()
+		w.ended = true
+		pending := w.pending
+		w.pending = nil
+		w.lock.Unlock()
+		BattleManager.removeReconnect(w)
+		if pending != nil {
+			pending.ProcessMessage(message)
+		}
+	}
+}
+
+func (w *ReconnectingBattlePlayer) CurrentPet() *BattlePet {
+	return w.currentPet
+}
+
+func (w *ReconnectingBattlePlayer) SetCurrentPet(pet *BattlePet) {
+	w.currentPet = pet
+}
+
+func (w *ReconnectingBattlePlayer) EquippedPets() [5]*BattlePet {
+	return w.equippedPet
+}
+
+func (w *ReconnectingBattlePlayer) SetBattleRoom(room *BattleRoom) {
+	w.room = room
+}
+
+func (w *ReconnectingBattlePlayer) UserName() string {
+	return w.name
+}
+
+func (w *ReconnectingBattlePlayer) GetPlayer() *Player {
+	return w.player
+}
+
+// GetReconnect 获得玩家掉线前所在的等待重连的战斗
+func (b *BattleManagerStruct) GetReconnect(uid uint32) *ReconnectingBattlePlayer {
+	b.roomLock.Lock()
+	defer b.roomLock.Unlock()
+	return b.reconnects[uid]
+}
+
+func (b *BattleManagerStruct) addReconnect(w *ReconnectingBattlePlayer) {
+	b.roomLock.Lock()
+	defer b.roomLock.Unlock()
+	b.reconnects[w.UID] = w
+}
+
+func (b *BattleManagerStruct) removeReconnect(w *ReconnectingBattlePlayer) {
+	b.roomLock.Lock()
+	defer b.roomLock.Unlock()
+	if b.reconnects[w.UID] == w {
+		delete(b.reconnects, w.UID)
+	}
+}
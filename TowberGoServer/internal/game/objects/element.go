@@ -0,0 +1,88 @@
+package objects
+
+// Element 宠物和技能的属性
+type Element int
+
+const (
+	ElementNormal Element = iota
+	ElementFire
+	ElementWater
+	ElementGrass
+	ElementElectric
+	ElementEarth
+	ElementDark
+	ElementLight
+)
+
+const (
+	SuperEffective = 2.0
+	Resisted       = 0.5
+	Immune         = 0.0
+	// SameElementBonus 技能属性与宠物属性相同时的伤害加成
+	SameElementBonus = 1.5
+)
+
+// EffectivenessTable 属性克制表，攻击属性 -> 防御属性 -> 伤害倍率，未配置的组合倍率为1
+var EffectivenessTable = map[Element]map[Element]float64{
+	ElementNormal: {
+		ElementEarth: Resisted,
+	},
+	ElementFire: {
+		ElementGrass: SuperEffective,
+		ElementFire:  Resisted,
+		ElementWater: Resisted,
+		ElementEarth: Resisted,
+	},
+	ElementWater: {
+		ElementFire:  SuperEffective,
+		ElementEarth: SuperEffective,
+		ElementWater: Resisted,
+		ElementGrass: Resisted,
+	},
+	ElementGrass: {
+		ElementWater: SuperEffective,
+		ElementEarth: SuperEffective,
+		ElementFire:  Resisted,
+		ElementGrass: Resisted,
+	},
+	ElementElectric: {
+		ElementWater:    SuperEffective,
+		ElementGrass:    Resisted,
+		ElementElectric: Resisted,
+		ElementEarth:    Immune,
+	},
+	ElementEarth: {
+		ElementFire:     SuperEffective,
+		ElementElectric: SuperEffective,
+		ElementGrass:    Resisted,
+	},
+	ElementDark: {
+		ElementLight: SuperEffective,
+		ElementDark:  Resisted,
+	},
+	ElementLight: {
+		ElementDark:  SuperEffective,
+		ElementLight: Resisted,
+	},
+}
+
+// Effectiveness 计算攻击属性对防御方所有属性的总伤害倍率
+func Effectiveness(attack Element, defend []Element) float64 {
+	res := 1.0
+	for _, v := range defend {
+		if rate, ok := EffectivenessTable[attack][v]; ok {
+			res *= rate
+		}
+	}
+	return res
+}
+
+// HasElement 判断属性列表中是否包含指定属性
+func HasElement(elements []Element, element Element) bool {
+	for _, v := range elements {
+		if v == element {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package objects
+
+import (
+	"TowberGoServer/internal/containers"
+	"math/rand/v2"
+)
+
+// EncounterEntry 野生宠物遭遇表中的一项，Weight 为出现的权重
+type EncounterEntry struct {
+	PetID    uint32
+	MinLevel int
+	MaxLevel int
+	Weight   float32
+}
+
+// EncounterZone 区域中的野生宠物出没范围，玩家在范围内每次移动都有 Rate 的概率遭遇野生宠物
+type EncounterZone struct {
+	Min     containers.Vector2
+	Max     containers.Vector2
+	Rate    float32
+	Entries []EncounterEntry
+	// Looter 战胜野生宠物后的战利品
+	Looter LootTable
+}
+
+func (z *EncounterZone) Contains(pos containers.Vector2) bool {
+	return pos.X >= z.Min.X && pos.X <= z.Max.X && pos.Y >= z.Min.Y && pos.Y <= z.Max.Y
+}
+
+// Roll 根据遭遇概率和权重抽取一只野生宠物，未遭遇时返回nil
+func (z *EncounterZone) Roll() (*EncounterEntry, int) {
+	if len(z.Entries) == 0 || rand.Float32() >= z.Rate {
+		return nil, 0
+	}
+	var total float32
+	for _, v := range z.Entries {
+		total += v.Weight
+	}
+	r := rand.Float32() * total
+	var sum float32
+	for i := range z.Entries {
+		sum += z.Entries[i].Weight
+		if r <= sum {
+			entry := &z.Entries[i]
+			level := entry.MinLevel
+			if entry.MaxLevel > entry.MinLevel {
+				level += rand.IntN(entry.MaxLevel - entry.MinLevel + 1)
+			}
+			return entry, level
+		}
+	}
+	return nil, 0
+}
+
+// RollEncounter 检查玩家所在位置是否触发野生宠物遭遇
+func RollEncounter(area Area, pos containers.Vector2) (*EncounterZone, *EncounterEntry, int) {
+	if area == nil {
+		return nil, nil, 0
+	}
+	for _, zone := range area.GetEncounterZones() {
+		if !zone.Contains(pos) {
+			continue
+		}
+		if entry, level := zone.Roll(); entry != nil {
+			return zone, entry, level
+		}
+		return nil, nil, 0
+	}
+	return nil, nil, 0
+}
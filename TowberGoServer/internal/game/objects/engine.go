@@ -0,0 +1,426 @@
+package objects
+
+import (
+	"TowberGoServer/pkg/packets"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// BattleStage 战斗引擎当前等待的输入
+type BattleStage int
+
+const (
+	// StageIdle 战斗还没有开始
+	StageIdle BattleStage = iota
+	// StageRoundStart 等待开始新的回合，驱动在此时处理掉线重连
+	StageRoundStart
+	// StageCommand 等待所有存活的在场宠物的指令
+	StageCommand
+	// StageSwitch 等待玩家为阵亡宠物的位置选择替补
+	StageSwitch
+	// StageNextRound 回合已经结束，等待所有玩家确认进入下一回合
+	StageNextRound
+	// StageEnded 战斗已经结束
+	StageEnded
+)
+
+// Clock 战斗计时使用的时钟，模拟战斗时可以替换
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SystemClock 默认使用的系统时钟
+var SystemClock Clock = systemClock{}
+
+// WithClock 创建房间时设置战斗使用的时钟
+func WithClock(clock Clock) func(room *BattleRoom) {
+	return func(room *BattleRoom) {
+		room.Clock = clock
+	}
+}
+
+// WithSeed 创建房间时设置战斗的随机数种子，相同的种子和指令会得到相同的战斗过程
+func WithSeed(seed uint64) func(room *BattleRoom) {
+	return func(room *BattleRoom) {
+		room.Seed = seed
+		room.rng = rand.New(rand.NewPCG(seed, seed))
+		room.inputRng = rand.New(rand.NewPCG(seed, ^seed))
+	}
+}
+
+// pendingSwitch 等待更换宠物的位置，requested 为是否已经通知玩家
+type pendingSwitch struct {
+	number    int
+	slot      int
+	requested bool
+}
+
+// 战斗引擎只负责战斗规则，不读取通道也不计时：驱动在玩家发送输入或者等待超时后调用对应的方法，
+// 每个方法返回这一步产生的所有事件，需要发送的消息也作为 MessageSent 事件返回，由驱动发送给每一方
+
+// Stage 返回战斗引擎当前等待的输入
+func (r *BattleRoom) Stage() BattleStage {
+	return r.stage
+}
+
+func (r *BattleRoom) setStage(stage BattleStage) {
+	r.stage = stage
+	r.phase++
+}
+
+// step 执行引擎的一步，返回期间产生的事件
+func (r *BattleRoom) step(f func()) []*BattleEvent {
+	r.emitted = nil
+	f()
+	events := r.emitted
+	r.emitted = nil
+	return events
+}
+
+// Begin 开始战斗，初始化在场宠物并同步赛制和每一方的信息，一方队伍没有可以战斗的宠物时直接判负
+func (r *BattleRoom) Begin() []*BattleEvent {
+	return r.step(func() {
+		if r.stage != StageIdle {
+			return
+		}
+		r.ready = make([]bool, len(r.Players))
+		r.timeouts = make([]int, len(r.Players))
+		for _, v := range r.Players {
+			v.SetBattleRoom(r)
+		}
+		r.initActivePets()
+		r.registerHandlers()
+
+		r.publish(&packets.BattlePacket_BattleFormat{BattleFormat: newBattleFormatMessage(r.Format)})
+		for i := range r.Players {
+			r.publish(&packets.BattlePacket_SyncBattleInformation{SyncBattleInformation: r.syncMessage(i)})
+		}
+		r.startRecord()
+
+		for team := range 2 {
+			if !r.teamAlive(team) {
+				r.EndBattle(1 - team)
+			}
+		}
+		r.SendEvent(&BattleEvent{Type: BattleStarted})
+		if r.End {
+			r.finish()
+			return
+		}
+		r.setStage(StageRoundStart)
+	})
+}
+
+// StartRound 开始新的回合，结算回合开始时的buff后等待指令，有宠物阵亡时先等待更换
+func (r *BattleRoom) StartRound() []*BattleEvent {
+	return r.step(func() {
+		if r.stage != StageRoundStart {
+			return
+		}
+		r.SendEvent(&BattleEvent{Type: RoundStarted})
+		if r.End {
+			r.finish()
+			return
+		}
+		r.waitSwitches(StageCommand)
+	})
+}
+
+// Submit 提交一条指令，等待指令时所有指令到齐后结算回合，等待更换宠物时立即更换
+func (r *BattleRoom) Submit(cmd *Command) ([]*BattleEvent, error) {
+	var err error
+	events := r.step(func() {
+		switch r.stage {
+		case StageCommand:
+			err = r.submitCommand(cmd)
+		case StageSwitch:
+			err = r.submitSwitch(cmd)
+		default:
+			err = errors.New("not waiting for commands")
+		}
+	})
+	return events, err
+}
+
+// Timeout 当前阶段等待超时，等待指令时为没有指令的宠物执行默认行动后结算回合，等待更换宠物时自动选择替补
+func (r *BattleRoom) Timeout() []*BattleEvent {
+	return r.step(func() {
+		switch r.stage {
+		case StageCommand:
+			r.fillTimeouts(r.commands)
+			r.resolve()
+		case StageSwitch:
+			for _, v := range slices.Clone(r.switches) {
+				pos := r.reserve(v.number)
+				if pos < 0 {
+					continue
+				}
+				r.record(ReplayInputSwitch, v.number, &Command{
+					Msg: &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_ChangePet{
+						ChangePet: &packets.ChangePet{PetPosition: int64(pos)}}, Slot: int64(v.slot)},
+					Number: v.number,
+				})
+				r.switchPet(v.number, v.slot, r.Players[v.number].EquippedPets()[pos])
+			}
+			r.switches = nil
+			r.waitSwitches(r.switchNext)
+		}
+	})
+}
+
+// Ready 一方确认进入下一回合，所有玩家确认后开始新的回合或者结束战斗
+func (r *BattleRoom) Ready(number int) []*BattleEvent {
+	return r.step(func() {
+		if r.stage != StageNextRound || number < 0 || number >= len(r.ready) {
+			return
+		}
+		r.ready[number] = true
+		if slices.Contains(r.ready, false) {
+			return
+		}
+		if r.End {
+			r.finish()
+			return
+		}
+		r.round++
+		r.SendEvent(&BattleEvent{Type: RoundEnded})
+		r.setStage(StageRoundStart)
+	})
+}
+
+// Forfeit 一方认输，战斗立即结束
+func (r *BattleRoom) Forfeit(number int) []*BattleEvent {
+	return r.step(func() {
+		if r.stage == StageIdle || r.stage == StageEnded || number < 0 || number >= len(r.Players) {
+			return
+		}
+		r.Defeat(number)
+		r.finish()
+	})
+}
+
+// Summary 战斗结果，Winner 和 Loser 为双方队伍中的第一名玩家
+func (r *BattleRoom) Summary() *BattleSummary {
+	summary := &BattleSummary{}
+	for i, v := range r.Players {
+		if r.TeamOf(i) == r.winner {
+			summary.Winners = append(summary.Winners, v.GetPlayer())
+		} else {
+			summary.Losers = append(summary.Losers, v.GetPlayer())
+		}
+	}
+	summary.Winner, summary.Loser = summary.Winners[0], summary.Losers[0]
+	return summary
+}
+
+// startCommand 通知所有玩家发送本回合的指令
+func (r *BattleRoom) startCommand() {
+	clear(r.ready)
+	r.commands = make([]*Command, len(r.Players)*r.Format.ActivePets)
+	r.setStage(StageCommand)
+	r.publish(&packets.BattlePacket_StartNextRound{StartNextRound: &packets.StartNextRoundMessage{
+		Timeout: int64(r.Config.Timer.CommandTimeout.Seconds()),
+	}})
+}
+
+func (r *BattleRoom) submitCommand(cmd *Command) error {
+	if err := r.isValid(cmd); err != nil {
+		return err
+	}
+	index := r.actorIndex(cmd.Number, int(cmd.Msg.GetSlot()))
+	if r.commands[index] != nil {
+		return errors.New("command error")
+	}
+	r.commands[index] = cmd
+	if r.commandsReady(r.commands) {
+		for i := range r.Players {
+			r.timeouts[i] = 0
+		}
+		r.resolve()
+	}
+	return nil
+}
+
+// resolve 结算本回合所有的指令，之后更换阵亡的宠物并结束回合
+func (r *BattleRoom) resolve() {
+	r.record(ReplayInputCommands, 0, r.commands...)
+	r.ProcessCommand(r.commands)
+	r.commands = nil
+	r.waitSwitches(StageNextRound)
+}
+
+// endRound 通知所有玩家当前回合已经结束
+func (r *BattleRoom) endRound() {
+	r.setStage(StageNextRound)
+	r.publish(&packets.BattlePacket_RoundEnd{RoundEnd: &packets.RoundEndMessage{}})
+}
+
+// waitSwitches 有需要更换宠物的位置时通知玩家并等待更换，否则进入next阶段
+// 已经没有替补的位置会被移除，战斗已经结束时不再等待
+func (r *BattleRoom) waitSwitches(next BattleStage) {
+	switches := r.switches[:0]
+	for _, v := range r.switches {
+		if !r.End && !alive(r.ActivePet(v.number, v.slot)) && r.reserve(v.number) >= 0 {
+			switches = append(switches, v)
+		}
+	}
+	r.switches = switches
+	if len(r.switches) == 0 {
+		switch {
+		case next == StageCommand && r.End:
+			r.finish()
+		case next == StageCommand:
+			r.startCommand()
+		default:
+			r.endRound()
+		}
+		return
+	}
+	r.switchNext = next
+	if r.stage != StageSwitch {
+		r.setStage(StageSwitch)
+	}
+	if r.replay != nil {
+		return
+	}
+	for i, v := range r.switches {
+		if v.requested {
+			continue
+		}
+		r.switches[i].requested = true
+		r.tell(v.number, &packets.BattlePacket_ChangePetRequest{ChangePetRequest: &packets.ChangePetRequestMessage{
+			Slot:    int64(v.slot),
+			Timeout: int64(r.Config.Timer.SwitchTimeout.Seconds()),
+		}})
+	}
+}
+
+// submitSwitch 更换等待替补的位置，指令中的位置不需要更换时更换该玩家第一个等待替补的位置
+func (r *BattleRoom) submitSwitch(cmd *Command) error {
+	changeCmd, ok := cmd.Msg.Command.(*packets.RoundCommandMessage_ChangePet)
+	if !ok {
+		return errors.New("waiting for pet change")
+	}
+	index := slices.IndexFunc(r.switches, func(v pendingSwitch) bool {
+		return v.number == cmd.Number && v.slot == int(cmd.Msg.GetSlot())
+	})
+	if index < 0 {
+		index = slices.IndexFunc(r.switches, func(v pendingSwitch) bool { return v.number == cmd.Number })
+	}
+	if index < 0 {
+		return errors.New("no pet to change")
+	}
+	pos := changeCmd.ChangePet.PetPosition
+	if pos < 0 || pos >= 5 {
+		return errors.New("pet error")
+	}
+	pet := r.Players[cmd.Number].EquippedPets()[pos]
+	if !alive(pet) || r.SlotOf(cmd.Number, pet) >= 0 {
+		return errors.New("pet error")
+	}
+	target := r.switches[index]
+	r.switches = slices.Delete(r.switches, index, index+1)
+	cmd.Msg.Slot = int64(target.slot)
+	r.record(ReplayInputSwitch, target.number, cmd)
+	r.switchPet(target.number, target.slot, pet)
+	r.waitSwitches(r.switchNext)
+	return nil
+}
+
+// finish 结束战斗，保存回放并发送结算以及战斗结束消息，结算消息需要在战斗结束消息之前发送
+func (r *BattleRoom) finish() {
+	r.setStage(StageEnded)
+	r.saveRecord()
+	r.SendEvent(&BattleEvent{Type: BattleEnded, Number: r.winner})
+	for i := range r.Players {
+		r.tell(i, &packets.BattlePacket_BattleEndStats{BattleEndStats: r.settle(i)})
+	}
+	r.publish(&packets.BattlePacket_BattleEnd{BattleEnd: &packets.BattleEndMessage{Winner: int64(r.winner)}})
+}
+
+// Round 返回当前的回合数，从0开始
+func (r *BattleRoom) Round() int {
+	return r.round
+}
+
+// Winner 返回获胜的队伍，战斗结束后有效
+func (r *BattleRoom) Winner() int {
+	return r.winner
+}
+
+// PushCommand 由战斗中的一方在战斗协程中发送指令，同步驱动的房间放入队列，否则在新的协程中发送到通道，避免阻塞房间
+func (r *BattleRoom) PushCommand(cmd *Command) {
+	if r.sync {
+		r.inbox = append(r.inbox, cmd)
+		return
+	}
+	go r.SendCommand(cmd)
+}
+
+// PushReady 由战斗中的一方在战斗协程中确认进入下一回合，同步驱动的房间会直接让所有玩家确认
+func (r *BattleRoom) PushReady(number int) {
+	if r.sync {
+		return
+	}
+	go r.SendReady(number)
+}
+
+// SendCommand 在其他协程中发送指令并等待房间接收，战斗已经结束时直接返回
+func (r *BattleRoom) SendCommand(cmd *Command) {
+	select {
+	case r.CommandChan <- cmd:
+	case <-r.done:
+	}
+}
+
+// SendReady 在其他协程中确认进入下一回合，战斗已经结束时直接返回
+func (r *BattleRoom) SendReady(number int) {
+	select {
+	case r.NextRoundChan <- number:
+	case <-r.done:
+	}
+}
+
+// RunSync 在当前协程中驱动战斗直到结束，不使用通道也不计时，只适用于所有玩家都会自动发送指令的房间，例如模拟战斗
+// 超过maxRounds回合仍未结束时停止并返回false，maxRounds为0时不限制
+func (r *BattleRoom) RunSync(maxRounds int) bool {
+	r.sync = true
+	r.deliver(r.Begin())
+	for r.stage != StageEnded {
+		if maxRounds > 0 && r.round >= maxRounds {
+			return false
+		}
+		switch r.stage {
+		case StageRoundStart:
+			r.deliver(r.StartRound())
+		case StageCommand, StageSwitch:
+			// 所有玩家都已经发送过指令，剩下的位置按照超时处理
+			if len(r.inbox) == 0 {
+				r.deliver(r.Timeout())
+				continue
+			}
+			cmd := r.inbox[0]
+			r.inbox = r.inbox[1:]
+			events, _ := r.Submit(cmd)
+			r.deliver(events)
+		case StageNextRound:
+			for i := range r.Players {
+				r.deliver(r.Ready(i))
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,553 @@
+package objects_test
+
+import (
+	"TowberGoServer/internal/game/objects"
+	"TowberGoServer/internal/list"
+	"TowberGoServer/pkg/packets"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+const testSeed = 42
+
+func TestMain(m *testing.M) {
+	// 只初始化战斗需要的管理器，不连接数据库
+	objects.PetManager = objects.NewPetManager(nil, list.PetList)
+	objects.SkillManager = &objects.SkillManagerStruct{SkillList: list.SkillsList}
+	objects.BuffManager = &objects.BuffManagerStruct{BuffList: list.BuffList}
+	objects.PetItemManager = &objects.PetItemManagerStruct{PetItemList: list.PetItemList}
+	os.Exit(m.Run())
+}
+
+// fakeClock 固定时间的时钟，测试直接调用 Timeout，After 返回的通道永远不会收到
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	return make(chan time.Time)
+}
+
+// testPlayer 指令由测试直接提交给引擎，发送的消息从引擎返回的事件中检查
+type testPlayer struct {
+	name     string
+	current  *objects.BattlePet
+	equipped [5]*objects.BattlePet
+}
+
+func newTestPlayer(name string, pets ...objects.Pet) *testPlayer {
+	p := &testPlayer{name: name}
+	for i, v := range pets {
+		p.equipped[i] = &objects.BattlePet{Pet: v}
+	}
+	p.current = p.equipped[0]
+	return p
+}
+
+func (p *testPlayer) ProcessMessage(message packets.BattleMsg) {}
+
+func (p *testPlayer) CurrentPet() *objects.BattlePet {
+	return p.current
+}
+
+func (p *testPlayer) SetCurrentPet(pet *objects.BattlePet) {
+	p.current = pet
+}
+
+func (p *testPlayer) EquippedPets() [5]*objects.BattlePet {
+	return p.equipped
+}
+
+func (p *testPlayer) SetBattleRoom(room *objects.BattleRoom) {}
+
+func (p *testPlayer) UserName() string {
+	return p.name
+}
+
+func (p *testPlayer) GetPlayer() *objects.Player {
+	return nil
+}
+
+// sent 引擎是否在这一步向 number 发送了满足条件的消息，包括公开的消息
+func sent(events []*objects.BattleEvent, number int, match func(message packets.BattleMsg) bool) bool {
+	return slices.ContainsFunc(events, func(v *objects.BattleEvent) bool {
+		return v.Type == objects.MessageSent && (v.Number == number || v.Number < 0) && match(v.Message)
+	})
+}
+
+// testStats 默认的测试属性，速度相同时技能必定命中
+func testStats() objects.Stats {
+	return objects.Stats{MaxHP: 1000, HP: 1000, MaxMana: 100, Mana: 50, Strength: 10, Intelligence: 10, Speed: 50, Defense: 10}
+}
+
+func newTestPet(stats objects.Stats, skills ...uint32) objects.Pet {
+	return list.PetList[1].Initialize(0, skills, &stats, &objects.Talent{}, nil)
+}
+
+var skipTimer = objects.BattleTimer{CommandTimeout: time.Second, SwitchTimeout: time.Second, TimeoutAction: objects.TimeoutSkip}
+
+// newTestRoom 创建两名玩家的单打房间并进入第一回合的指令阶段
+func newTestRoom(t *testing.T, timer objects.BattleTimer, players ...*testPlayer) *objects.BattleRoom {
+	t.Helper()
+	battlePlayers := make([]objects.BattlePlayer, len(players))
+	for i, v := range players {
+		battlePlayers[i] = v
+	}
+	room, err := objects.NewBattleRoom(battlePlayers, objects.WithSeed(testSeed), objects.WithClock(&fakeClock{}), objects.WithTimer(timer))
+	if err != nil {
+		t.Fatalf("create room: %v", err)
+	}
+	room.Begin()
+	room.StartRound()
+	if room.Stage() != objects.StageCommand {
+		t.Fatalf("stage = %v, want StageCommand", room.Stage())
+	}
+	return room
+}
+
+func attack(number int, pos int) *objects.Command {
+	return &objects.Command{
+		Msg: &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_Attack{Attack: &packets.Attack{
+			SkillPos: int64(pos),
+			Target:   &packets.BattleTarget{Number: int64(1 - number)},
+		}}},
+		Number: number,
+	}
+}
+
+func changePet(number int, pos int) *objects.Command {
+	return &objects.Command{
+		Msg: &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_ChangePet{
+			ChangePet: &packets.ChangePet{PetPosition: int64(pos)}}},
+		Number: number,
+	}
+}
+
+func submit(t *testing.T, room *objects.BattleRoom, cmd *objects.Command) {
+	t.Helper()
+	if _, err := room.Submit(cmd); err != nil {
+		t.Fatalf("submit command of %d: %v", cmd.Number, err)
+	}
+}
+
+// skillsUsed 返回事件中依次释放技能的一方
+func skillsUsed(events []*objects.BattleEvent) []int {
+	res := make([]int, 0)
+	for _, v := range events {
+		if v.Type == objects.SkillUsed {
+			res = append(res, v.Number)
+		}
+	}
+	return res
+}
+
+func hasBuff(pet *objects.BattlePet, id int) bool {
+	return slices.ContainsFunc(pet.Buffs, func(v objects.Buff) bool { return v.ID() == id })
+}
+
+func TestSkillInteractions(t *testing.T) {
+	tests := []struct {
+		name    string
+		skill   uint32
+		prepare func(stats *objects.Stats)
+		check   func(t *testing.T, self, enemy *objects.BattlePet)
+	}{
+		{
+			name:  "bite damages the enemy",
+			skill: 1,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if enemy.Stats().HP >= 1000 {
+					t.Errorf("enemy hp = %d, want damage", enemy.Stats().HP)
+				}
+			},
+		},
+		{
+			name:  "triple strike damages the enemy",
+			skill: 2,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if enemy.Stats().HP >= 1000 {
+					t.Errorf("enemy hp = %d, want damage", enemy.Stats().HP)
+				}
+			},
+		},
+		{
+			name:  "poison fang damages and poisons the enemy",
+			skill: 3,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if enemy.Stats().HP >= 1000 {
+					t.Errorf("enemy hp = %d, want damage", enemy.Stats().HP)
+				}
+				if !hasBuff(enemy, 1) {
+					t.Errorf("enemy buffs = %v, want poison", enemy.Buffs)
+				}
+			},
+		},
+		{
+			name:  "roar buffs itself without damage",
+			skill: 4,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if !hasBuff(self, 4) {
+					t.Errorf("self buffs = %v, want attack up", self.Buffs)
+				}
+				if hasBuff(enemy, 4) || enemy.Stats().HP != 1000 {
+					t.Errorf("enemy hp = %d buffs = %v, want untouched", enemy.Stats().HP, enemy.Buffs)
+				}
+			},
+		},
+		{
+			name:    "recover heals 40% of max hp and costs mana",
+			skill:   5,
+			prepare: func(stats *objects.Stats) { stats.HP = 100 },
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if self.Stats().HP != 500 {
+					t.Errorf("self hp = %d, want 500", self.Stats().HP)
+				}
+				if self.Stats().Mana != 35 {
+					t.Errorf("self mana = %d, want 35", self.Stats().Mana)
+				}
+			},
+		},
+		{
+			name:    "recover does not heal above max hp",
+			skill:   5,
+			prepare: func(stats *objects.Stats) { stats.HP = 900 },
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if self.Stats().HP != 1000 {
+					t.Errorf("self hp = %d, want 1000", self.Stats().HP)
+				}
+			},
+		},
+		{
+			name:    "inspire restores mana",
+			skill:   6,
+			prepare: func(stats *objects.Stats) { stats.Mana = 20 },
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if self.Stats().Mana != 50 {
+					t.Errorf("self mana = %d, want 50", self.Stats().Mana)
+				}
+			},
+		},
+		{
+			name:  "self destruct damages the enemy and faints itself",
+			skill: 7,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if self.Stats().HP != 0 {
+					t.Errorf("self hp = %d, want 0", self.Stats().HP)
+				}
+				if enemy.Stats().HP >= 1000 {
+					t.Errorf("enemy hp = %d, want damage", enemy.Stats().HP)
+				}
+			},
+		},
+		{
+			name:  "ember damages and burns the enemy",
+			skill: 9,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if enemy.Stats().HP >= 1000 {
+					t.Errorf("enemy hp = %d, want damage", enemy.Stats().HP)
+				}
+				if !hasBuff(enemy, 2) {
+					t.Errorf("enemy buffs = %v, want burn", enemy.Buffs)
+				}
+			},
+		},
+		{
+			name:  "slam damages and stuns the enemy",
+			skill: 10,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if enemy.Stats().HP >= 1000 {
+					t.Errorf("enemy hp = %d, want damage", enemy.Stats().HP)
+				}
+				if !hasBuff(enemy, 3) {
+					t.Errorf("enemy buffs = %v, want stun", enemy.Buffs)
+				}
+			},
+		},
+		{
+			name:  "growl lowers the enemy attack without damage",
+			skill: 11,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if !hasBuff(enemy, 5) || enemy.Stats().HP != 1000 {
+					t.Errorf("enemy hp = %d buffs = %v, want attack down only", enemy.Stats().HP, enemy.Buffs)
+				}
+			},
+		},
+		{
+			name:  "harden shields itself",
+			skill: 12,
+			check: func(t *testing.T, self, enemy *objects.BattlePet) {
+				if !hasBuff(self, 6) {
+					t.Errorf("self buffs = %v, want shield", self.Buffs)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := testStats()
+			if tt.prepare != nil {
+				tt.prepare(&stats)
+			}
+			p0 := newTestPlayer("p0", newTestPet(stats, tt.skill))
+			p1 := newTestPlayer("p1", newTestPet(testStats(), 1))
+			room := newTestRoom(t, skipTimer, p0, p1)
+			submit(t, room, attack(0, 0))
+			// 对方超时不行动，只结算测试的技能
+			room.Timeout()
+			tt.check(t, room.ActivePet(0, 0), room.ActivePet(1, 0))
+		})
+	}
+}
+
+func TestPassives(t *testing.T) {
+	newThornback := func(stats objects.Stats) objects.Pet {
+		return list.PetList[2].Initialize(0, []uint32{1}, &stats, &objects.Talent{}, nil)
+	}
+
+	t.Run("thorns reflects physical damage", func(t *testing.T) {
+		p0 := newTestPlayer("p0", newTestPet(testStats(), 1))
+		p1 := newTestPlayer("p1", newThornback(testStats()))
+		room := newTestRoom(t, skipTimer, p0, p1)
+		submit(t, room, attack(0, 0))
+		room.Timeout()
+		if hp := room.ActivePet(0, 0).Stats().HP; hp >= 1000 {
+			t.Errorf("attacker hp = %d, want reflected damage", hp)
+		}
+	})
+
+	t.Run("regeneration heals at the end of the round", func(t *testing.T) {
+		stats := testStats()
+		stats.HP = 500
+		p0 := newTestPlayer("p0", newTestPet(testStats(), 1))
+		p1 := newTestPlayer("p1", newThornback(stats))
+		room := newTestRoom(t, skipTimer, p0, p1)
+		// 双方都不行动，确认进入下一回合时回合结束，恢复最大生命值的5%
+		room.Timeout()
+		room.Ready(0)
+		room.Ready(1)
+		if hp := room.ActivePet(1, 0).Stats().HP; hp != 550 {
+			t.Errorf("hp = %d, want 550", hp)
+		}
+	})
+}
+
+func TestTurnOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		speeds [2]int
+		want   []int
+	}{
+		{name: "faster pet acts first", speeds: [2]int{80, 20}, want: []int{0, 1}},
+		{name: "slower pet acts last", speeds: [2]int{20, 80}, want: []int{1, 0}},
+		{name: "equal speed keeps player order", speeds: [2]int{50, 50}, want: []int{0, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			players := make([]*testPlayer, 2)
+			for i := range players {
+				stats := testStats()
+				stats.Speed = tt.speeds[i]
+				players[i] = newTestPlayer("p", newTestPet(stats, 1))
+			}
+			room := newTestRoom(t, skipTimer, players...)
+			submit(t, room, attack(1, 0))
+			events, err := room.Submit(attack(0, 0))
+			if err != nil {
+				t.Fatalf("submit: %v", err)
+			}
+			if got := skillsUsed(events); !slices.Equal(got, tt.want) {
+				t.Errorf("order = %v, want %v", got, tt.want)
+			}
+			if room.Stage() != objects.StageNextRound {
+				t.Errorf("stage = %v, want StageNextRound", room.Stage())
+			}
+		})
+	}
+}
+
+func TestForcedSwitch(t *testing.T) {
+	setup := func(t *testing.T) (*objects.BattleRoom, *testPlayer) {
+		p0 := newTestPlayer("p0", newTestPet(testStats(), 7), newTestPet(testStats(), 1))
+		p1 := newTestPlayer("p1", newTestPet(testStats(), 1))
+		room := newTestRoom(t, skipTimer, p0, p1)
+		submit(t, room, attack(0, 0))
+		events := room.Timeout()
+		if room.Stage() != objects.StageSwitch {
+			t.Fatalf("stage = %v, want StageSwitch", room.Stage())
+		}
+		requested := sent(events, 0, func(message packets.BattleMsg) bool {
+			_, ok := message.(*packets.BattlePacket_ChangePetRequest)
+			return ok
+		})
+		if !requested {
+			t.Fatal("fainted side did not receive a change pet request")
+		}
+		return room, p0
+	}
+
+	t.Run("switch to a living reserve", func(t *testing.T) {
+		room, p0 := setup(t)
+		if _, err := room.Submit(changePet(0, 0)); err == nil {
+			t.Error("switching to the fainted pet was accepted")
+		}
+		submit(t, room, changePet(0, 1))
+		if room.Stage() != objects.StageNextRound {
+			t.Errorf("stage = %v, want StageNextRound", room.Stage())
+		}
+		if room.ActivePet(0, 0) != p0.EquippedPets()[1] {
+			t.Error("reserve pet is not active")
+		}
+
+		// 下一回合不能在回合中换上已经阵亡的宠物
+		room.Ready(0)
+		room.Ready(1)
+		room.StartRound()
+		if _, err := room.Submit(changePet(0, 0)); err == nil {
+			t.Error("switching to the fainted pet mid-round was accepted")
+		}
+	})
+
+	t.Run("timeout picks the reserve", func(t *testing.T) {
+		room, p0 := setup(t)
+		room.Timeout()
+		if room.Stage() != objects.StageNextRound {
+			t.Errorf("stage = %v, want StageNextRound", room.Stage())
+		}
+		if room.ActivePet(0, 0) != p0.EquippedPets()[1] {
+			t.Error("reserve pet is not active")
+		}
+	})
+}
+
+func TestTimeoutActions(t *testing.T) {
+	tests := []struct {
+		name       string
+		action     objects.TimeoutAction
+		wantSkills int
+	}{
+		{name: "skip", action: objects.TimeoutSkip, wantSkills: 0},
+		{name: "basic attack", action: objects.TimeoutBasicAttack, wantSkills: 2},
+		{name: "random skill", action: objects.TimeoutRandomSkill, wantSkills: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timer := skipTimer
+			timer.TimeoutAction = tt.action
+			p0 := newTestPlayer("p0", newTestPet(testStats(), 1, 4))
+			p1 := newTestPlayer("p1", newTestPet(testStats(), 1, 4))
+			room := newTestRoom(t, timer, p0, p1)
+			events := room.Timeout()
+			if got := len(skillsUsed(events)); got != tt.wantSkills {
+				t.Errorf("skills used = %d, want %d", got, tt.wantSkills)
+			}
+			if tt.action == objects.TimeoutBasicAttack {
+				for _, v := range events {
+					if v.Type == objects.SkillUsed && v.Skill.ID() != 1 {
+						t.Errorf("skill = %d, want the first skill", v.Skill.ID())
+					}
+				}
+			}
+			if room.Stage() != objects.StageNextRound {
+				t.Errorf("stage = %v, want StageNextRound", room.Stage())
+			}
+		})
+	}
+
+	t.Run("max timeouts runs away", func(t *testing.T) {
+		timer := skipTimer
+		timer.MaxTimeouts = 1
+		p0 := newTestPlayer("p0", newTestPet(testStats(), 1))
+		p1 := newTestPlayer("p1", newTestPet(testStats(), 1))
+		room := newTestRoom(t, timer, p0, p1)
+		submit(t, room, attack(0, 0))
+		room.Timeout()
+		room.Ready(0)
+		room.Ready(1)
+		if room.Stage() != objects.StageEnded || room.Winner() != 0 {
+			t.Errorf("stage = %v winner = %d, want StageEnded and winner 0", room.Stage(), room.Winner())
+		}
+	})
+}
+
+func TestForfeit(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+		before func(room *objects.BattleRoom)
+	}{
+		{name: "while waiting for commands", number: 0},
+		{name: "while waiting for next round", number: 1, before: func(room *objects.BattleRoom) { room.Timeout() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p0 := newTestPlayer("p0", newTestPet(testStats(), 1))
+			p1 := newTestPlayer("p1", newTestPet(testStats(), 1))
+			room := newTestRoom(t, skipTimer, p0, p1)
+			if tt.before != nil {
+				tt.before(room)
+			}
+			events := room.Forfeit(tt.number)
+			if room.Stage() != objects.StageEnded {
+				t.Fatalf("stage = %v, want StageEnded", room.Stage())
+			}
+			if want := 1 - tt.number; room.Winner() != want {
+				t.Errorf("winner = %d, want %d", room.Winner(), want)
+			}
+			if !slices.ContainsFunc(events, func(v *objects.BattleEvent) bool { return v.Type == objects.BattleEnded }) {
+				t.Error("no BattleEnded event")
+			}
+			for i, p := range []*testPlayer{p0, p1} {
+				ended := sent(events, i, func(message packets.BattleMsg) bool {
+					_, ok := message.(*packets.BattlePacket_BattleEnd)
+					return ok
+				})
+				if !ended {
+					t.Errorf("%s was not sent BattleEnd", p.name)
+				}
+			}
+			if _, err := room.Submit(attack(0, 0)); err == nil {
+				t.Error("command accepted after the battle ended")
+			}
+		})
+	}
+}
+
+// TestSeedReproducible 相同的种子得到相同的战斗过程，包括超时的随机技能以及AI的选择
+func TestSeedReproducible(t *testing.T) {
+	type result struct {
+		winner, round int
+		hp            []int
+	}
+	run := func() result {
+		players := []objects.BattlePlayer{
+			objects.NewAIBattlePlayer(0, objects.AINormal, "p0", newTestPet(testStats(), 1, 2, 3, 4), newTestPet(testStats(), 1, 7)),
+			objects.NewAIBattlePlayer(1, objects.AIEasy, "p1", newTestPet(testStats(), 1, 2, 5, 6), newTestPet(testStats(), 3, 4)),
+		}
+		timer := skipTimer
+		timer.TimeoutAction = objects.TimeoutRandomSkill
+		room, err := objects.NewBattleRoom(players, objects.WithSeed(testSeed), objects.WithClock(&fakeClock{}), objects.WithTimer(timer))
+		if err != nil {
+			t.Fatalf("create room: %v", err)
+		}
+		room.RunSync(100)
+		res := result{winner: room.Winner(), round: room.Round()}
+		for _, v := range players {
+			for _, pet := range v.EquippedPets() {
+				if pet != nil {
+					res.hp = append(res.hp, pet.Stats().HP)
+				}
+			}
+		}
+		return res
+	}
+	first, second := run(), run()
+	if first.winner != second.winner || first.round != second.round || !slices.Equal(first.hp, second.hp) {
+		t.Errorf("same seed gave different battles: %+v and %+v", first, second)
+	}
+}
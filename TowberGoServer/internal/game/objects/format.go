@@ -0,0 +1,185 @@
+package objects
+
+import (
+	"TowberGoServer/pkg/packets"
+	"errors"
+)
+
+// SpreadDamageRate 技能同时攻击多个目标时每个目标受到的伤害比例
+const SpreadDamageRate = 0.75
+
+// BattleFormat 战斗的赛制，Teams 为每一方所属的队伍（0或1），ActivePets 为每一方同时在场的宠物数量
+type BattleFormat struct {
+	Teams      []int
+	ActivePets int
+}
+
+var (
+	// FormatSingles 单打，双方各一只在场宠物
+	FormatSingles = BattleFormat{Teams: []int{0, 1}, ActivePets: 1}
+	// FormatDoubles 双打，双方各两只在场宠物
+	FormatDoubles = BattleFormat{Teams: []int{0, 1}, ActivePets: 2}
+	// FormatTeam 2v2，四名玩家两两组队，每人一只在场宠物
+	FormatTeam = BattleFormat{Teams: []int{0, 0, 1, 1}, ActivePets: 1}
+)
+
+// check 检查赛制是否适用于players名玩家，必须分为两支队伍
+func (f BattleFormat) check(players int) error {
+	if len(f.Teams) != players || f.ActivePets < 1 || f.ActivePets > 5 {
+		return errors.New("invalid battle format")
+	}
+	teams := [2]bool{}
+	for _, v := range f.Teams {
+		if v < 0 || v > 1 {
+			return errors.New("invalid battle format")
+		}
+		teams[v] = true
+	}
+	if !teams[0] || !teams[1] {
+		return errors.New("invalid battle format")
+	}
+	return nil
+}
+
+// WithFormat 创建房间时设置战斗的赛制
+func WithFormat(format BattleFormat) func(room *BattleRoom) {
+	return func(room *BattleRoom) {
+		room.Format = format
+	}
+}
+
+func newBattleFormatMessage(format BattleFormat) *packets.BattleFormatMessage {
+	res := &packets.BattleFormatMessage{ActivePets: int64(format.ActivePets)}
+	for _, v := range format.Teams {
+		res.Teams = append(res.Teams, int64(v))
+	}
+	return res
+}
+
+func newBattleFormat(message *packets.BattleFormatMessage) BattleFormat {
+	if message == nil || len(message.Teams) == 0 {
+		return FormatSingles
+	}
+	res := BattleFormat{ActivePets: max(int(message.ActivePets), 1)}
+	for _, v := range message.Teams {
+		res.Teams = append(res.Teams, int(v))
+	}
+	return res
+}
+
+// TeamOf 返回一方所属的队伍
+func (r *BattleRoom) TeamOf(number int) int {
+	return r.Format.Teams[number]
+}
+
+// Defeat 一方认输或者全部宠物阵亡，所在的队伍输掉战斗
+func (r *BattleRoom) Defeat(number int) {
+	r.EndBattle(1 - r.TeamOf(number))
+}
+
+// Opponents 返回与number不同队伍的所有一方
+func (r *BattleRoom) Opponents(number int) []int {
+	res := make([]int, 0, len(r.Players))
+	for i := range r.Players {
+		if r.TeamOf(i) != r.TeamOf(number) {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// ActivePets 返回一方所有位置上的在场宠物，阵亡且没有替补的宠物仍然留在原来的位置
+func (r *BattleRoom) ActivePets(number int) []*BattlePet {
+	return r.active[number]
+}
+
+// ActivePet 返回指定位置的在场宠物，位置不存在时返回nil
+func (r *BattleRoom) ActivePet(number int, slot int) *BattlePet {
+	if number < 0 || number >= len(r.active) || slot < 0 || slot >= len(r.active[number]) {
+		return nil
+	}
+	return r.active[number][slot]
+}
+
+// SlotOf 返回宠物在场上的位置，不在场时返回-1
+func (r *BattleRoom) SlotOf(number int, pet *BattlePet) int {
+	if pet == nil || number < 0 || number >= len(r.active) {
+		return -1
+	}
+	for i, v := range r.active[number] {
+		if v == pet {
+			return i
+		}
+	}
+	return -1
+}
+
+// Enemies 返回number一方所有存活的在场对手宠物
+func (r *BattleRoom) Enemies(number int) []*BattlePet {
+	res := make([]*BattlePet, 0)
+	for _, i := range r.Opponents(number) {
+		for _, v := range r.active[i] {
+			if alive(v) {
+				res = append(res, v)
+			}
+		}
+	}
+	return res
+}
+
+// teamAlive 队伍中是否还有存活的宠物
+func (r *BattleRoom) teamAlive(team int) bool {
+	for i, v := range r.Players {
+		if r.TeamOf(i) != team {
+			continue
+		}
+		for _, k := range v.EquippedPets() {
+			if alive(k) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reserve 返回一方可以替换上场的宠物位置，没有时返回-1
+func (r *BattleRoom) reserve(number int) int {
+	for i, v := range r.Players[number].EquippedPets() {
+		if alive(v) && r.SlotOf(number, v) < 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// initActivePets 战斗开始时第一个位置为玩家当前的宠物，当前宠物已经阵亡时以及其余位置依次选择存活的宠物
+func (r *BattleRoom) initActivePets() {
+	r.active = make([][]*BattlePet, len(r.Players))
+	for i, v := range r.Players {
+		r.active[i] = make([]*BattlePet, r.Format.ActivePets)
+		if alive(v.CurrentPet()) {
+			r.active[i][0] = v.CurrentPet()
+		}
+		for slot := range r.active[i] {
+			if r.active[i][slot] != nil {
+				continue
+			}
+			if pos := r.reserve(i); pos >= 0 {
+				r.active[i][slot] = v.EquippedPets()[pos]
+			}
+		}
+		if r.active[i][0] == nil {
+			r.active[i][0] = v.CurrentPet()
+		}
+		v.SetCurrentPet(r.active[i][0])
+	}
+}
+
+// actorIndex 在场宠物在所有位置中的下标，用于指令以及属性消息
+func (r *BattleRoom) actorIndex(number int, slot int) int {
+	return number*r.Format.ActivePets + slot
+}
+
+func alive(pet *BattlePet) bool {
+	return pet != nil && pet.Pet != nil && pet.Stats().HP > 0
+}
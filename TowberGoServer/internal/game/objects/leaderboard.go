@@ -0,0 +1,194 @@
+package objects
+
+import (
+	"TowberGoServer/internal/db"
+	"TowberGoServer/pkg/packets"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type BoardType uint32
+
+const (
+	// BoardPetLevel 最高宠物等级
+	BoardPetLevel BoardType = iota + 1
+	// BoardPetsCollected 获得的宠物总数
+	BoardPetsCollected
+	// BoardBattlesWon 对战胜利的场数
+	BoardBattlesWon
+	// BoardEventScore 活动积分
+	BoardEventScore
+)
+
+type BoardWindow uint32
+
+const (
+	WindowDaily BoardWindow = iota
+	WindowWeekly
+	WindowAllTime
+)
+
+var boardWindows = []BoardWindow{WindowDaily, WindowWeekly, WindowAllTime}
+
+const (
+	// maxBoardPageSize 排行榜每页的最大数量
+	maxBoardPageSize = 100
+	// boardNamesKey 记录排行榜中玩家名字的hash
+	boardNamesKey = "leaderboard:names"
+)
+
+var LeaderboardManager *LeaderboardManagerStruct
+
+// LeaderboardManagerStruct 基于redis有序集合的通用排行榜，每种排行榜都有每日、每周和总榜
+type LeaderboardManagerStruct struct{}
+
+func boardKey(board BoardType, window BoardWindow) string {
+	return fmt.Sprintf("leaderboard:%d:%d", board, window)
+}
+
+func validBoard(board BoardType, window BoardWindow) bool {
+	return board >= BoardPetLevel && board <= BoardEventScore && window <= WindowAllTime
+}
+
+// Incr 增加玩家在排行榜中的分数，同时更新所有时间范围
+func (l *LeaderboardManagerStruct) Incr(player *Player, board BoardType, delta float64) {
+	if player == nil {
+		return
+	}
+	ctx := context.Background()
+	pipe := db.Rdb.TxPipeline()
+	pipe.HSet(ctx, boardNamesKey, fmt.Sprint(player.UID), player.UserName)
+	for _, window := range boardWindows {
+		pipe.ZIncrBy(ctx, boardKey(board, window), delta, fmt.Sprint(player.UID))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		fmt.Println("update leaderboard error:", err)
+	}
+}
+
+// SetMax 分数高于玩家当前的分数时更新，用于记录最高值的排行榜
+func (l *LeaderboardManagerStruct) SetMax(player *Player, board BoardType, score float64) {
+	if player == nil {
+		return
+	}
+	ctx := context.Background()
+	pipe := db.Rdb.TxPipeline()
+	pipe.HSet(ctx, boardNamesKey, fmt.Sprint(player.UID), player.UserName)
+	for _, window := range boardWindows {
+		pipe.ZAddGT(ctx, boardKey(board, window), redis.Z{Score: score, Member: fmt.Sprint(player.UID)})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		fmt.Println("update leaderboard error:", err)
+	}
+}
+
+// AddEventScore 增加玩家的活动积分
+func (l *LeaderboardManagerStruct) AddEventScore(player *Player, score int) {
+	l.Incr(player, BoardEventScore, float64(score))
+}
+
+// Page 获得排行榜的一页，page从0开始
+func (l *LeaderboardManagerStruct) Page(board BoardType, window BoardWindow, page int, size int) ([]*packets.LeaderboardEntryMessage, error) {
+	if !validBoard(board, window) {
+		return nil, errors.New("no such leaderboard")
+	}
+	if size <= 0 || size > maxBoardPageSize {
+		size = maxBoardPageSize
+	}
+	page = max(page, 0)
+	ctx := context.Background()
+	start := int64(page * size)
+	result, err := db.Rdb.ZRevRangeWithScores(ctx, boardKey(board, window), start, start+int64(size)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	uids := make([]string, 0, len(result))
+	for _, v := range result {
+		uids = append(uids, v.Member.(string))
+	}
+	names := l.userNames(uids)
+	entries := make([]*packets.LeaderboardEntryMessage, 0, len(result))
+	for i, v := range result {
+		uid, _ := strconv.ParseUint(uids[i], 10, 32)
+		entries = append(entries, &packets.LeaderboardEntryMessage{
+			Rank:     start + int64(i) + 1,
+			Uid:      uint32(uid),
+			UserName: names[i],
+			Score:    int64(v.Score),
+		})
+	}
+	return entries, nil
+}
+
+// Rank 获得玩家在排行榜中的名次，不在排行榜中时名次为0
+func (l *LeaderboardManagerStruct) Rank(player *Player, board BoardType, window BoardWindow) (*packets.LeaderboardEntryMessage, error) {
+	if !validBoard(board, window) {
+		return nil, errors.New("no such leaderboard")
+	}
+	entry := &packets.LeaderboardEntryMessage{Uid: player.UID, UserName: player.UserName}
+	ctx := context.Background()
+	result, err := db.Rdb.ZRevRankWithScore(ctx, boardKey(board, window), fmt.Sprint(player.UID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return entry, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	entry.Rank = result.Rank + 1
+	entry.Score = int64(result.Score)
+	return entry, nil
+}
+
+func (l *LeaderboardManagerStruct) userNames(uids []string) []string {
+	if len(uids) == 0 {
+		return nil
+	}
+	names := make([]string, len(uids))
+	result, err := db.Rdb.HMGet(context.Background(), boardNamesKey, uids...).Result()
+	if err != nil {
+		return names
+	}
+	for i, v := range result {
+		if name, ok := v.(string); ok {
+			names[i] = name
+		}
+	}
+	return names
+}
+
+// Reset 清空到期的排行榜，每日0点由定时任务调用，每周一同时清空周榜
+func (l *LeaderboardManagerStruct) Reset(now time.Time) {
+	ctx := context.Background()
+	keys := make([]string, 0)
+	for board := BoardPetLevel; board <= BoardEventScore; board++ {
+		keys = append(keys, boardKey(board, WindowDaily))
+		if now.Weekday() == time.Monday {
+			keys = append(keys, boardKey(board, WindowWeekly))
+		}
+	}
+	if err := db.Rdb.Del(ctx, keys...).Err(); err != nil {
+		fmt.Println("reset leaderboard error:", err)
+	}
+}
+
+// updateLeaderboard 战斗结束后为获胜队伍的玩家增加胜场，回放和有AI参与的战斗不计入
+func (r *BattleRoom) updateLeaderboard(event *BattleEvent) {
+	if r.replay != nil || LeaderboardManager == nil || event.Number < 0 || event.Number > 1 {
+		return
+	}
+	for _, v := range r.Players {
+		if v.GetPlayer() == nil {
+			return
+		}
+	}
+	for i, v := range r.Players {
+		if r.TeamOf(i) == event.Number {
+			LeaderboardManager.Incr(v.GetPlayer(), BoardBattlesWon, 1)
+		}
+	}
+}
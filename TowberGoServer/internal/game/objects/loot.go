@@ -3,9 +3,10 @@ package objects
 import "math/rand/v2"
 
 type LootTable interface {
-	getTableSize() int
-	getTable() []*Loot
-	getCount() int
+	GetTableSize() int
+	GetTable() []*Loot
+	// GetCount 单次最多获得的物品数量
+	GetCount() int
 }
 
 type Loot struct {
@@ -14,13 +15,21 @@ type Loot struct {
 	Weight float32
 }
 
+// LootResult 实际获得的战利品
+type LootResult struct {
+	ID    uint32
+	Type  uint32
+	Count int
+}
+
 var LootManager *LootManagerStruct
 
 type LootManagerStruct struct{}
 
-func (l *LootManagerStruct) GetLoot(player *Player, lootTable LootTable, value float32, certainCount ...int) error {
+// GetLoot 从战利品表中随机抽取物品交给玩家，返回成功添加的物品
+func (l *LootManagerStruct) GetLoot(player *Player, lootTable LootTable, value float32, certainCount ...int) ([]*LootResult, error) {
 	// 随机生成1-5个物品
-	count := rand.IntN(lootTable.getCount()) + 1
+	count := rand.IntN(lootTable.GetCount()) + 1
 	if len(certainCount) > 0 {
 		count = certainCount[0]
 	}
@@ -28,9 +37,9 @@ func (l *LootManagerStruct) GetLoot(player *Player, lootTable LootTable, value f
 	petItemList := make(map[uint32]int)
 	for n := 0; n < count; n++ {
 		// 调整权重
-		adjusted := make([]float32, lootTable.getTableSize())
+		adjusted := make([]float32, lootTable.GetTableSize())
 		var total float32
-		for idx, loot := range lootTable.getTable() {
+		for idx, loot := range lootTable.GetTable() {
 			adjusted[idx] = loot.Weight * (1 + value*float32(idx))
 			total += adjusted[idx]
 		}
@@ -41,12 +50,12 @@ func (l *LootManagerStruct) GetLoot(player *Player, lootTable LootTable, value f
 		for idx, w := range adjusted {
 			sum += w
 			if r <= sum {
-				if lootTable.getTable()[idx].Type == 1 {
-					petItemList[lootTable.getTable()[idx].ID] += 1
+				if lootTable.GetTable()[idx].Type == 1 {
+					petItemList[lootTable.GetTable()[idx].ID] += 1
 					break
 				} else {
 					// 给玩家添加 lootTable[idx]
-					itemList[lootTable.getTable()[idx].ID] += 1
+					itemList[lootTable.GetTable()[idx].ID] += 1
 					break
 				}
 
@@ -54,15 +63,20 @@ func (l *LootManagerStruct) GetLoot(player *Player, lootTable LootTable, value f
 		}
 	}
 	var err error
+	res := make([]*LootResult, 0)
 	for i, v := range itemList {
 		if e := ItemManager.AddItem(player, i, v); e != nil {
 			err = e
+		} else {
+			res = append(res, &LootResult{ID: i, Type: 2, Count: v})
 		}
 	}
 	for i, v := range petItemList {
-		if e := ItemManager.AddItem(player, i, v); e != nil {
+		if e := PetItemManager.AddItem(player, i, v); e != nil {
 			err = e
+		} else {
+			res = append(res, &LootResult{ID: i, Type: 1, Count: v})
 		}
 	}
-	return err
+	return res, err
 }
@@ -0,0 +1,22 @@
+package objects
+
+// Passive 宠物种族的被动技能，只有宠物在场时才会触发
+type Passive interface {
+	ID() uint32
+	Name() string
+	// GetEvent 宠物在场时收到战斗事件
+	GetEvent(event *BattleEvent, owner *BattlePet, battleRoom *BattleRoom)
+	// Attack 宠物作为攻击方时修改攻击信息，在伤害计算之前调用
+	Attack(attack *AttackInfo, owner *BattlePet)
+	// Defend 宠物作为防御方时修改攻击信息，在伤害计算之后调用
+	Defend(attack *AttackInfo, owner *BattlePet)
+}
+
+// BasePassive 被动技能的通用实现，具体的被动技能嵌入后只需要实现自己关心的方法
+type BasePassive struct{}
+
+func (b *BasePassive) GetEvent(event *BattleEvent, owner *BattlePet, battleRoom *BattleRoom) {}
+
+func (b *BasePassive) Attack(attack *AttackInfo, owner *BattlePet) {}
+
+func (b *BasePassive) Defend(attack *AttackInfo, owner *BattlePet) {}
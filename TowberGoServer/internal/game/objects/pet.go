@@ -18,21 +18,33 @@ type Pet interface {
 	// SetID 设置宠物的编号
 	SetID(id uint64)
 	Name() string
-	SkillList() map[int]Skill
+	// Elements 种族的属性，最多两个
+	Elements() []Element
+	// Passives 种族的被动技能
+	Passives() []Passive
+	// CaptureRate 种族的捕捉系数，范围0-1，越稀有的种族越低
+	CaptureRate() float64
+	// SkillList 种族技能表，按照解锁等级排列
+	SkillList() []SkillUnlock
 	Exp() int
 	SetExp(exp int)
 	Level() int
 	UnlockedSkillList() []Skill
+	// LearnedSkills 通过技能书学会的技能
+	LearnedSkills() []Skill
+	AddLearnedSkill(skill Skill)
 	EquippedSkills() [4]Skill
 	SetSkill(pos int, skill Skill)
-	Initialize(exp int, equippedSkills []uint32, stats *Stats, owner *Player) Pet
+	Initialize(exp int, equippedSkills []uint32, stats *Stats, talent *Talent, owner *Player) Pet
 	Stats() *Stats
 	BaseStats() Stats
+	// Talent 宠物的个体天赋
+	Talent() *Talent
 	// LevelUp 宠物提升一级时调用
 	LevelUp()
 	Owner() *Player
 	// GetEvent 战斗时获得事件触发
-	GetEvent(event int, self bool, battleRoom *BattleRoom)
+	GetEvent(event *BattleEvent, self bool, battleRoom *BattleRoom)
 }
 
 type Stats struct {
@@ -98,6 +110,8 @@ func (p *PetManagerStruct) SavePet(player *Player, pet Pet) {
 		"id":           pet.ID(),
 		"max_hp":       s.MaxHP,
 		"hp":           s.HP,
+		"max_mana":     s.MaxMana,
+		"mana":         s.Mana,
 		"strength":     s.Strength,
 		"intelligence": s.Intelligence,
 		"speed":        s.Speed,
@@ -106,6 +120,11 @@ func (p *PetManagerStruct) SavePet(player *Player, pet Pet) {
 	player.Client.Db().Model(&db.PetStats{}).Where("id = ?", pet.ID()).Updates(stats)
 
 	// 保存技能配置信息
+	p.savePetSkills(pet)
+}
+
+// savePetSkills 保存宠物的技能配置信息
+func (p *PetManagerStruct) savePetSkills(pet Pet) {
 	equippedSkills := map[string]interface{}{
 		"slot1": 0,
 		"slot2": 0,
@@ -128,53 +147,38 @@ func (p *PetManagerStruct) SavePet(player *Player, pet Pet) {
 	p.db.Model(&db.PetSkills{}).Where("id = ?", pet.ID()).Updates(equippedSkills)
 }
 
-// CreatePet 向玩家添加一个新宠物，并返回是否放入到背包中
-func (p *PetManagerStruct) CreatePet(player *Player, petID uint32) (Pet, bool) {
-	base := p.petList[petID].BaseStats()
-	pet := p.petList[petID].Initialize(0, nil, &base, player)
-	data := &db.Pets{
-		PetID: petID,
-		Owner: player.UID,
-		Exp:   0,
-	}
-	equipped := false
-	p.db.Create(data)
-	pet.SetID(data.ID)
-
-	// 创建宠物状态
-	s := pet.Stats()
-	stats := &db.PetStats{
-		ID:           pet.ID(),
-		MaxHP:        s.MaxHP,
-		HP:           s.HP,
-		MaxMana:      s.MaxMana,
-		Mana:         s.Mana,
-		Strength:     s.Strength,
-		Intelligence: s.Intelligence,
-		Speed:        s.Speed,
-		Defense:      s.Defense,
-	}
-	p.db.Create(stats)
-
-	// 创建宠物技能
-	t := pet.EquippedSkills()
-	skills := &db.PetSkills{
-		ID: pet.ID(),
-	}
-	if t[0] != nil {
-		skills.Slot1 = uint32(t[0].ID())
-	}
-	if t[1] != nil {
-		skills.Slot2 = uint32(t[1].ID())
-	}
-	if t[2] != nil {
-		skills.Slot3 = uint32(t[2].ID())
+// CreatePet 向玩家添加一个新宠物，并返回是否放入到背包中，背包已满时宠物会放入仓库
+// from 不为空时保留 from 的经验、属性、天赋以及装备和学会的技能，例如捕捉到的野生宠物，否则生成一只新的宠物
+func (p *PetManagerStruct) CreatePet(player *Player, petID uint32, from Pet) (Pet, bool, error) {
+	template := p.petList[petID]
+	if template == nil {
+		return nil, false, errors.New("no such pet")
+	}
+	var pet Pet
+	if from == nil {
+		base := template.BaseStats()
+		talent := RollTalent()
+		talent.Apply(&base)
+		pet = template.Initialize(0, nil, &base, talent, player)
+	} else {
+		equippedSkills := make([]uint32, 4)
+		for i, v := range from.EquippedSkills() {
+			if v != nil {
+				equippedSkills[i] = v.ID()
+			}
+		}
+		stats := *from.Stats()
+		talent := *from.Talent()
+		pet = template.Initialize(from.Exp(), equippedSkills, &stats, &talent, player)
+		for _, v := range from.LearnedSkills() {
+			pet.AddLearnedSkill(v)
+		}
 	}
-	if t[3] != nil {
-		skills.Slot4 = uint32(t[3].ID())
+	if err := p.savePet(player, pet); err != nil {
+		return nil, false, err
 	}
-	p.db.Create(skills)
 
+	equipped := false
 	for i, v := range player.EquippedPets {
 		if v == nil {
 			p.EquipPet(player, pet, i)
@@ -190,7 +194,135 @@ func (p *PetManagerStruct) CreatePet(player *Player, petID uint32) (Pet, bool) {
 	}}
 	player.Client.SocketSend(&packet)
 
-	return pet, equipped
+	if LeaderboardManager != nil {
+		LeaderboardManager.Incr(player, BoardPetsCollected, 1)
+		LeaderboardManager.SetMax(player, BoardPetLevel, float64(pet.Level()))
+	}
+	return pet, equipped, nil
+}
+
+// savePet 保存一只新宠物的数据、属性、天赋以及技能，任意一项保存失败时全部回滚
+func (p *PetManagerStruct) savePet(player *Player, pet Pet) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		data := &db.Pets{
+			PetID: pet.PetID(),
+			Owner: player.UID,
+			Exp:   pet.Exp(),
+		}
+		if err := tx.Create(data).Error; err != nil {
+			return err
+		}
+		pet.SetID(data.ID)
+
+		// 创建宠物状态
+		s, talent := pet.Stats(), pet.Talent()
+		stats := &db.PetStats{
+			ID:           pet.ID(),
+			MaxHP:        s.MaxHP,
+			HP:           s.HP,
+			MaxMana:      s.MaxMana,
+			Mana:         s.Mana,
+			Strength:     s.Strength,
+			Intelligence: s.Intelligence,
+			Speed:        s.Speed,
+			Defense:      s.Defense,
+			Nature:       talent.Nature,
+			TalentHP:     talent.HP,
+			TalentMana:   talent.Mana,
+			TalentStr:    talent.Strength,
+			TalentInt:    talent.Intelligence,
+			TalentSpeed:  talent.Speed,
+			TalentDef:    talent.Defense,
+		}
+		if err := tx.Create(stats).Error; err != nil {
+			return err
+		}
+
+		// 创建宠物技能
+		t := pet.EquippedSkills()
+		skills := &db.PetSkills{
+			ID: pet.ID(),
+		}
+		if t[0] != nil {
+			skills.Slot1 = uint32(t[0].ID())
+		}
+		if t[1] != nil {
+			skills.Slot2 = uint32(t[1].ID())
+		}
+		if t[2] != nil {
+			skills.Slot3 = uint32(t[2].ID())
+		}
+		if t[3] != nil {
+			skills.Slot4 = uint32(t[3].ID())
+		}
+		if err := tx.Create(skills).Error; err != nil {
+			return err
+		}
+		for _, v := range pet.LearnedSkills() {
+			if err := tx.Create(&db.PetLearnedSkills{Pet: pet.ID(), SkillID: v.ID()}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CreateWildPet 生成一只指定等级的野生宠物，野生宠物没有主人也不会保存到数据库，装备最后解锁的技能
+func (p *PetManagerStruct) CreateWildPet(petID uint32, level int) Pet {
+	template := p.petList[petID]
+	if template == nil {
+		return nil
+	}
+	base := template.BaseStats()
+	talent := RollTalent()
+	talent.Apply(&base)
+	pet := template.Initialize(0, nil, &base, talent, nil)
+	level = min(max(level, 1), len(LevelList))
+	for pet.Level() < level {
+		pet.LevelUp()
+	}
+	if level > 1 {
+		pet.SetExp(LevelList[level-2])
+	}
+	unlocked := pet.UnlockedSkillList()
+	if len(unlocked) > 4 {
+		unlocked = unlocked[len(unlocked)-4:]
+	}
+	for i, v := range unlocked {
+		pet.SetSkill(i, v)
+	}
+	return pet
+}
+
+// NewPetFromMessage 根据宠物消息还原宠物，用于战斗回放，还原的宠物没有主人
+func (p *PetManagerStruct) NewPetFromMessage(msg *packets.PetMessage) Pet {
+	if msg == nil || p.petList[msg.PetId] == nil {
+		return nil
+	}
+	s := msg.PetStats
+	stats := &Stats{
+		MaxHP:        int(s.GetMaxHp()),
+		HP:           int(s.GetHp()),
+		MaxMana:      int(s.GetMaxMana()),
+		Mana:         int(s.GetMana()),
+		Strength:     int(s.GetStrength()),
+		Intelligence: int(s.GetIntelligence()),
+		Speed:        int(s.GetSpeed()),
+		Defense:      int(s.GetDefense()),
+	}
+	t := msg.Talent
+	talent := &Talent{
+		Nature:       t.GetNature(),
+		HP:           int(t.GetHp()),
+		Mana:         int(t.GetMana()),
+		Strength:     int(t.GetStrength()),
+		Intelligence: int(t.GetIntelligence()),
+		Speed:        int(t.GetSpeed()),
+		Defense:      int(t.GetDefense()),
+	}
+	pet := p.petList[msg.PetId].Initialize(int(msg.Exp), msg.EquippedSkills, stats, talent, nil)
+	pet.SetID(msg.Id)
+	return pet
 }
 
 // GetPetBag 获得宠物背包中的所有宠物
@@ -230,11 +362,28 @@ func (p *PetManagerStruct) GetPetBag(player *Player) [5]Pet {
 func (p *PetManagerStruct) GetPet(player *Player, id uint64) Pet {
 	skills := p.GetPetSkill(id)
 	stats := p.GetPetStats(id)
+	talent := p.GetPetTalent(id)
 	//fmt.Println("get player pet stats:", stats)
 	pet := db.Pets{}
 	p.db.Where("id = ?", id).First(&pet)
-	res := p.petList[pet.PetID].Initialize(pet.Exp, skills, stats, player)
+	res := p.petList[pet.PetID].Initialize(pet.Exp, skills, stats, talent, player)
 	res.SetID(id)
+	for _, v := range p.GetPetLearnedSkills(id) {
+		if skill := SkillManager.SkillList[v]; skill != nil {
+			res.AddLearnedSkill(skill)
+		}
+	}
+	return res
+}
+
+// GetPetLearnedSkills 获得宠物通过技能书学会的技能
+func (p *PetManagerStruct) GetPetLearnedSkills(id uint64) []uint32 {
+	learned := make([]db.PetLearnedSkills, 0)
+	p.db.Where("pet = ?", id).Find(&learned)
+	res := make([]uint32, len(learned))
+	for i := range learned {
+		res[i] = learned[i].SkillID
+	}
 	return res
 }
 
@@ -272,6 +421,21 @@ func (p *PetManagerStruct) GetPetStats(id uint64) *Stats {
 	}
 }
 
+// GetPetTalent 获得宠物的个体天赋
+func (p *PetManagerStruct) GetPetTalent(id uint64) *Talent {
+	stats := db.PetStats{}
+	p.db.Where("id = ?", id).First(&stats)
+	return &Talent{
+		Nature:       stats.Nature,
+		HP:           stats.TalentHP,
+		Mana:         stats.TalentMana,
+		Strength:     stats.TalentStr,
+		Intelligence: stats.TalentInt,
+		Speed:        stats.TalentSpeed,
+		Defense:      stats.TalentDef,
+	}
+}
+
 func (p *PetManagerStruct) SavePetGoroutine(hub *internal.Hub) {
 	for {
 		hub.BroadCast(&packets.Packet{Msg: &packets.Packet_SavePet{SavePet: &packets.SavePetMessage{}}})
@@ -323,27 +487,45 @@ func (p *PetManagerStruct) AddExp(pet Pet, exp int) bool {
 	if pet.Exp() == MaxExp {
 		return false
 	}
-	if pet.Exp()+exp >= MaxExp {
-		pet.SetExp(MaxExp)
-		return true
-	} else {
-		pet.SetExp(pet.Exp() + exp)
-	}
-	for pet.Exp() >= LevelList[pet.Level()-1] {
+	pet.SetExp(min(pet.Exp()+exp, MaxExp))
+	level := pet.Level()
+	for pet.Level() <= len(LevelList) && pet.Exp() >= LevelList[pet.Level()-1] {
 		pet.LevelUp()
 	}
+	if pet.Level() > level && pet.Owner() != nil && LeaderboardManager != nil {
+		LeaderboardManager.SetMax(pet.Owner(), BoardPetLevel, float64(pet.Level()))
+	}
 	return true
 }
 
+// CanLearn 判断宠物是否能够装备该技能，技能需要在种族技能表中已解锁或者已通过技能书学会
+func (p *PetManagerStruct) CanLearn(pet Pet, skill uint32) bool {
+	for _, v := range pet.UnlockedSkillList() {
+		if v.ID() == skill {
+			return true
+		}
+	}
+	for _, v := range pet.LearnedSkills() {
+		if v.ID() == skill {
+			return true
+		}
+	}
+	return false
+}
+
+// LearnSkill 将宠物已掌握的技能装备到指定位置，并立即保存
 func (p *PetManagerStruct) LearnSkill(pet Pet, skill uint32, position int) error {
 	s := SkillManager.SkillList[skill]
 	if s == nil {
 		return errors.New("no such skill")
 	}
+	if !p.CanLearn(pet, skill) {
+		return errors.New("the skill has not been unlocked")
+	}
 	equippedSkills := pet.EquippedSkills()
 	// 检查是否已装备该技能
 	for _, v := range equippedSkills {
-		if v == s {
+		if v != nil && v.ID() == skill {
 			return errors.New("the skill has equipped")
 		}
 	}
@@ -352,5 +534,49 @@ func (p *PetManagerStruct) LearnSkill(pet Pet, skill uint32, position int) error
 		return errors.New("error position")
 	}
 	pet.SetSkill(position, s)
+	p.savePetSkills(pet)
+	return nil
+}
+
+// ForgetSkill 卸下宠物指定位置的技能，并立即保存，宠物至少需要保留一个技能
+func (p *PetManagerStruct) ForgetSkill(pet Pet, position int) error {
+	equippedSkills := pet.EquippedSkills()
+	if position < 0 || position >= len(equippedSkills) || equippedSkills[position] == nil {
+		return errors.New("error position")
+	}
+	count := 0
+	for _, v := range equippedSkills {
+		if v != nil {
+			count++
+		}
+	}
+	if count <= 1 {
+		return errors.New("the pet must keep at least one skill")
+	}
+	pet.SetSkill(position, nil)
+	p.savePetSkills(pet)
+	return nil
+}
+
+// TeachSkill 通过技能书让宠物学会种族技能表之外的技能
+func (p *PetManagerStruct) TeachSkill(pet Pet, skill uint32) error {
+	s := SkillManager.SkillList[skill]
+	if s == nil {
+		return errors.New("no such skill")
+	}
+	for _, v := range pet.SkillList() {
+		if v.Skill.ID() == skill {
+			return errors.New("the skill is in the pet's skill list")
+		}
+	}
+	for _, v := range pet.LearnedSkills() {
+		if v.ID() == skill {
+			return errors.New("the skill has learned")
+		}
+	}
+	if err := p.db.Create(&db.PetLearnedSkills{Pet: pet.ID(), SkillID: skill}).Error; err != nil {
+		return err
+	}
+	pet.AddLearnedSkill(s)
 	return nil
 }
@@ -47,6 +47,13 @@ type PetItem interface {
 	Clone(count int) PetItem
 }
 
+// CaptureItem 捕捉道具，只能在允许捕捉的战斗中使用
+type CaptureItem interface {
+	PetItem
+	// Strength 道具的捕捉倍率
+	Strength() float64
+}
+
 type BasePetItem struct {
 	Count int
 	ID    uint32
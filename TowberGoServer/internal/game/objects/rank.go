@@ -0,0 +1,319 @@
+package objects
+
+import (
+	"TowberGoServer/internal/db"
+	"TowberGoServer/pkg/packets"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// InitialRating 新赛季玩家的初始积分
+	InitialRating = 1000
+	// ratingK Elo的K值，决定每场战斗积分变化的幅度
+	ratingK = 32
+	// seasonDuration 每个赛季持续的时间
+	seasonDuration = 30 * 24 * time.Hour
+	// maxRankCount 单次排行榜查询的最大数量
+	maxRankCount = 100
+)
+
+// RankTier 赛季结束时根据积分发放奖励的段位
+type RankTier struct {
+	Name      string
+	MinRating int
+	Items     []MailItem
+}
+
+// RankTiers 从高到低排列的段位奖励，达不到最低段位的玩家没有奖励
+var RankTiers = []RankTier{
+	{Name: "Master", MinRating: 1600, Items: []MailItem{{ID: 4, Count: 10, Type: 1}, {ID: 1, Count: 10, Type: 1}}},
+	{Name: "Diamond", MinRating: 1400, Items: []MailItem{{ID: 4, Count: 5, Type: 1}, {ID: 1, Count: 5, Type: 1}}},
+	{Name: "Gold", MinRating: 1200, Items: []MailItem{{ID: 3, Count: 10, Type: 1}, {ID: 1, Count: 3, Type: 1}}},
+	{Name: "Silver", MinRating: 1050, Items: []MailItem{{ID: 3, Count: 5, Type: 1}}},
+}
+
+// GetRankTier 获得积分对应的段位，没有段位时返回nil
+func GetRankTier(rating int) *RankTier {
+	for i := range RankTiers {
+		if rating >= RankTiers[i].MinRating {
+			return &RankTiers[i]
+		}
+	}
+	return nil
+}
+
+// EloChange 计算胜者增加的积分，败者减少同样的积分
+func EloChange(winner int, loser int) int {
+	expected := 1 / (1 + math.Pow(10, float64(loser-winner)/400))
+	return max(int(math.Round(ratingK*(1-expected))), 1)
+}
+
+var RankManager *RankManagerStruct
+
+// RankManagerStruct 管理排位积分、战斗记录以及赛季
+type RankManagerStruct struct {
+	db     *gorm.DB
+	season *db.Seasons
+	lock   sync.Mutex
+}
+
+func NewRankManager(db *gorm.DB) *RankManagerStruct {
+	return &RankManagerStruct{db: db}
+}
+
+// Season 获得当前赛季，没有赛季时开启第一个赛季
+func (m *RankManagerStruct) Season() *db.Seasons {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.season != nil {
+		return m.season
+	}
+	season := &db.Seasons{}
+	if err := m.db.Where("rewarded = ?", false).Order("id desc").First(season).Error; err != nil {
+		now := time.Now()
+		season = &db.Seasons{StartTime: now, EndTime: now.Add(seasonDuration)}
+		m.db.Create(season)
+	}
+	m.season = season
+	return season
+}
+
+// Rating 获得玩家当前赛季的积分
+func (m *RankManagerStruct) Rating(player *Player) int {
+	rating, _ := m.getRating(m.db, player.UID, m.Season().ID)
+	return rating.Rating
+}
+
+// getRating 获得玩家在指定赛季的积分记录，没有记录时返回初始积分
+func (m *RankManagerStruct) getRating(tx *gorm.DB, uid uint32, season uint32) (*db.PlayerRatings, bool) {
+	rating := &db.PlayerRatings{}
+	if err := tx.Where("uid = ? AND season = ?", uid, season).First(rating).Error; err != nil {
+		return &db.PlayerRatings{UID: uid, Season: season, Rating: InitialRating}, false
+	}
+	return rating, true
+}
+
+// RecordBattle 记录一场排位战斗的结果并更新双方的积分，返回积分变化
+func (m *RankManagerStruct) RecordBattle(winner *Player, loser *Player) (int, error) {
+	if winner == nil || loser == nil || winner.UID == loser.UID {
+		return 0, errors.New("invalid ranked battle")
+	}
+	season := m.Season().ID
+	var change int
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		w, _ := m.getRating(tx, winner.UID, season)
+		l, _ := m.getRating(tx, loser.UID, season)
+		change = EloChange(w.Rating, l.Rating)
+		w.Rating += change
+		w.Wins++
+		l.Rating = max(l.Rating-change, 0)
+		l.Losses++
+		if err := tx.Save(w).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(l).Error; err != nil {
+			return err
+		}
+		return tx.Create(&db.BattleRecords{
+			Season:       season,
+			Winner:       winner.UID,
+			Loser:        loser.UID,
+			WinnerRating: w.Rating,
+			LoserRating:  l.Rating,
+			RatingChange: change,
+		}).Error
+	})
+	return change, err
+}
+
+// History 获得玩家最近的排位战斗记录
+func (m *RankManagerStruct) History(player *Player, count int) []*packets.BattleRecordMessage {
+	if count <= 0 || count > maxRankCount {
+		count = maxRankCount
+	}
+	var records []db.BattleRecords
+	m.db.Where("winner = ? OR loser = ?", player.UID, player.UID).Order("id desc").Limit(count).Find(&records)
+	uids := make([]uint32, 0, len(records))
+	for _, v := range records {
+		uids = append(uids, v.Winner, v.Loser)
+	}
+	names := m.userNames(uids)
+	result := make([]*packets.BattleRecordMessage, 0, len(records))
+	for _, v := range records {
+		msg := &packets.BattleRecordMessage{
+			Win:          v.Winner == player.UID,
+			RatingChange: int64(v.RatingChange),
+			Time:         v.CreatedAt.Unix(),
+		}
+		if msg.Win {
+			msg.Opponent = names[v.Loser]
+		} else {
+			msg.Opponent = names[v.Winner]
+			msg.RatingChange = -msg.RatingChange
+		}
+		result = append(result, msg)
+	}
+	return result
+}
+
+// Leaderboard 获得当前赛季积分最高的count名玩家
+func (m *RankManagerStruct) Leaderboard(count int) []*packets.RankEntryMessage {
+	if count <= 0 || count > maxRankCount {
+		count = maxRankCount
+	}
+	var ratings []db.PlayerRatings
+	m.db.Where("season = ?", m.Season().ID).Order("rating desc, uid").Limit(count).Find(&ratings)
+	return m.entries(ratings, 1)
+}
+
+// AroundMe 获得玩家自己的排名以及前后各count名玩家
+func (m *RankManagerStruct) AroundMe(player *Player, count int) ([]*packets.RankEntryMessage, *packets.RankEntryMessage) {
+	if count <= 0 || count > maxRankCount/2 {
+		count = maxRankCount / 2
+	}
+	season := m.Season().ID
+	self, ok := m.getRating(m.db, player.UID, season)
+	// 没有打过排位的玩家不在排行榜中，只返回自己的积分
+	if !ok {
+		return nil, m.entries([]db.PlayerRatings{*self}, 0)[0]
+	}
+	var above int64
+	m.db.Model(&db.PlayerRatings{}).
+		Where("season = ? AND (rating > ? OR (rating = ? AND uid < ?))", season, self.Rating, self.Rating, player.UID).
+		Count(&above)
+	start := max(int(above)-count, 0)
+	var ratings []db.PlayerRatings
+	m.db.Where("season = ?", season).Order("rating desc, uid").Offset(start).Limit(2*count + 1).Find(&ratings)
+	entries := m.entries(ratings, int64(start)+1)
+	var selfEntry *packets.RankEntryMessage
+	for _, v := range entries {
+		if v.Uid == player.UID {
+			selfEntry = v
+		}
+	}
+	return entries, selfEntry
+}
+
+func (m *RankManagerStruct) entries(ratings []db.PlayerRatings, rank int64) []*packets.RankEntryMessage {
+	uids := make([]uint32, 0, len(ratings))
+	for _, v := range ratings {
+		uids = append(uids, v.UID)
+	}
+	names := m.userNames(uids)
+	result := make([]*packets.RankEntryMessage, 0, len(ratings))
+	for i, v := range ratings {
+		entry := &packets.RankEntryMessage{
+			Uid:      v.UID,
+			UserName: names[v.UID],
+			Rating:   int64(v.Rating),
+			Wins:     int64(v.Wins),
+			Losses:   int64(v.Losses),
+		}
+		if rank > 0 {
+			entry.Rank = rank + int64(i)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func (m *RankManagerStruct) userNames(uids []uint32) map[uint32]string {
+	names := make(map[uint32]string, len(uids))
+	if len(uids) == 0 {
+		return names
+	}
+	var users []db.UserInfo
+	m.db.Select("id", "user_name").Where("id IN ?", uids).Find(&users)
+	for _, v := range users {
+		names[v.ID] = v.UserName
+	}
+	return names
+}
+
+// CheckSeason 当前赛季到期时结束赛季，由每日定时任务调用
+func (m *RankManagerStruct) CheckSeason() {
+	if time.Now().Before(m.Season().EndTime) {
+		return
+	}
+	if err := m.EndSeason(); err != nil {
+		fmt.Println("end season error:", err)
+	}
+}
+
+// EndSeason 结束当前赛季，按照段位发放奖励邮件，开启新赛季并将积分向初始积分回归一半
+func (m *RankManagerStruct) EndSeason() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	old := m.season
+	if old == nil {
+		return errors.New("no season")
+	}
+	var ratings []db.PlayerRatings
+	if err := m.db.Where("season = ?", old.ID).Find(&ratings).Error; err != nil {
+		return err
+	}
+	now := time.Now()
+	season := &db.Seasons{StartTime: now, EndTime: now.Add(seasonDuration)}
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(old).Update("rewarded", true).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(season).Error; err != nil {
+			return err
+		}
+		reset := make([]db.PlayerRatings, 0, len(ratings))
+		for _, v := range ratings {
+			reset = append(reset, db.PlayerRatings{
+				UID:    v.UID,
+				Season: season.ID,
+				Rating: (v.Rating + InitialRating) / 2,
+			})
+		}
+		if len(reset) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(reset, 100).Error
+	})
+	if err != nil {
+		return err
+	}
+	m.season = season
+
+	for _, v := range ratings {
+		tier := GetRankTier(v.Rating)
+		if tier == nil {
+			continue
+		}
+		MailManager.SendMail(v.UID, &Mail{
+			Title:   fmt.Sprintf("Season %d Rewards", old.ID),
+			Content: fmt.Sprintf("You reached %s with %d rating in season %d.", tier.Name, v.Rating, old.ID),
+			Sender:  "System",
+			Items:   tier.Items,
+		})
+	}
+	return nil
+}
+
+// Ranked 将战斗房间设置为排位战斗，战斗结束后更新双方积分，逃跑和掉线判负都算作失败，只有两名玩家的战斗计入排位
+func (m *RankManagerStruct) Ranked(room *BattleRoom) {
+	if len(room.Players) != 2 {
+		return
+	}
+	// 掉线后玩家会被替换，需要在创建房间时记录双方玩家，两人战斗时队伍编号即为玩家编号
+	players := [2]*Player{room.Players[0].GetPlayer(), room.Players[1].GetPlayer()}
+	room.Subscribe(BattleEnded, func(event *BattleEvent) {
+		if room.replay != nil || event.Number < 0 || event.Number > 1 {
+			return
+		}
+		winner, loser := players[event.Number], players[room.GetTheOtherPlayer(event.Number)]
+		if _, err := m.RecordBattle(winner, loser); err != nil {
+			fmt.Println("record ranked battle error:", err)
+		}
+	})
+}
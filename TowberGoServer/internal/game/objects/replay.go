@@ -0,0 +1,282 @@
+package objects
+
+import (
+	"TowberGoServer/internal/db"
+	"TowberGoServer/pkg/packets"
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/protobuf/proto"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	ReplayInputCommands uint32 = iota + 1
+	ReplayInputSwitch
+	ReplayInputForfeit
+)
+
+const (
+	// replayExpire 回放的保存时间
+	replayExpire = 7 * 24 * time.Hour
+	// maxPlayerReplays 每个玩家最多保留的回放数量
+	maxPlayerReplays = 20
+)
+
+var ReplayManager *ReplayManagerStruct
+
+// ReplayManagerStruct 管理战斗回放，回放以protobuf格式保存在redis中
+type ReplayManagerStruct struct{}
+
+// Save 保存回放并加入参与玩家的回放列表，返回回放编号
+func (m *ReplayManagerStruct) Save(replay *packets.BattleReplay) (uint64, error) {
+	ctx := context.Background()
+	id, err := db.Rdb.Incr(ctx, "replay:id").Result()
+	if err != nil {
+		return 0, err
+	}
+	replay.Id = uint64(id)
+	data, err := proto.Marshal(replay)
+	if err != nil {
+		return 0, err
+	}
+	pipe := db.Rdb.TxPipeline()
+	pipe.Set(ctx, fmt.Sprintf("replay:%d", id), data, replayExpire)
+	for _, uid := range replay.Uids {
+		key := fmt.Sprintf("player:%d:replays", uid)
+		pipe.LPush(ctx, key, id)
+		pipe.LTrim(ctx, key, 0, maxPlayerReplays-1)
+	}
+	_, err = pipe.Exec(ctx)
+	return replay.Id, err
+}
+
+// Get 获得回放，只有参与战斗的玩家可以获取
+func (m *ReplayManagerStruct) Get(player *Player, id uint64) (*packets.BattleReplay, error) {
+	data, err := db.Rdb.Get(context.Background(), fmt.Sprintf("replay:%d", id)).Bytes()
+	if err != nil {
+		return nil, errors.New("no such replay")
+	}
+	replay := &packets.BattleReplay{}
+	if err := proto.Unmarshal(data, replay); err != nil {
+		return nil, err
+	}
+	if !slices.Contains(replay.Uids, player.UID) {
+		return nil, errors.New("no such replay")
+	}
+	return replay, nil
+}
+
+// List 获得玩家最近的回放
+func (m *ReplayManagerStruct) List(player *Player) []*packets.ReplayInfo {
+	ids, _ := db.Rdb.LRange(context.Background(), fmt.Sprintf("player:%d:replays", player.UID), 0, -1).Result()
+	res := make([]*packets.ReplayInfo, 0)
+	for _, v := range ids {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			continue
+		}
+		replay, err := m.Get(player, id)
+		if err != nil {
+			continue
+		}
+		names := make([]string, 0)
+		for _, team := range replay.Teams {
+			names = append(names, team.PlayerName)
+		}
+		res = append(res, &packets.ReplayInfo{Id: id, PlayerNames: names, Winner: replay.Winner, Time: replay.Time})
+	}
+	return res
+}
+
+// Play 在服务器上重新播放战斗，返回number一方在战斗中收到的消息
+func (m *ReplayManagerStruct) Play(replay *packets.BattleReplay, number int) []*packets.BattlePacket {
+	stream := make([]*packets.BattlePacket, 0)
+	output := func(message packets.BattleMsg) {
+		stream = append(stream, &packets.BattlePacket{Msg: message})
+	}
+	format := newBattleFormat(replay.Format)
+	players := make([]BattlePlayer, len(format.Teams))
+	for i := range players {
+		var team *packets.SyncBattleInformationMessage
+		if i < len(replay.Teams) {
+			team = replay.Teams[i]
+		}
+		player := newReplayBattlePlayer(team)
+		if i == number {
+			player.output = output
+		}
+		players[i] = player
+	}
+	room, err := NewBattleRoom(players, WithFormat(format), WithSeed(replay.Seed))
+	if err != nil {
+		return stream
+	}
+	room.replay = &replaySource{replay: replay}
+	// 回放不需要等待输入，按照记录的顺序直接驱动战斗引擎
+	room.deliver(room.Begin())
+	for room.Stage() != StageEnded {
+		// 掉线认输可能发生在任何阶段
+		room.deliver(room.replayForfeit())
+		switch room.Stage() {
+		case StageRoundStart:
+			room.deliver(room.StartRound())
+		case StageCommand:
+			room.deliver(room.step(room.replayCommands))
+		case StageSwitch:
+			room.deliver(room.step(room.replaySwitch))
+		case StageNextRound:
+			for i := range room.Players {
+				room.deliver(room.Ready(i))
+			}
+		}
+	}
+	return stream
+}
+
+// replaySource 回放时代替玩家向房间提供输入
+type replaySource struct {
+	replay *packets.BattleReplay
+	next   int
+}
+
+// pop 取出下一条指定类型的输入，类型不符或者输入已经用完时返回nil
+func (s *replaySource) pop(inputType uint32) *packets.ReplayInput {
+	if s.next >= len(s.replay.Inputs) || s.replay.Inputs[s.next].Type != inputType {
+		return nil
+	}
+	s.next++
+	return s.replay.Inputs[s.next-1]
+}
+
+// startRecord 记录战斗开始时双方的队伍
+func (r *BattleRoom) startRecord() {
+	if r.replay != nil || ReplayManager == nil {
+		return
+	}
+	r.recorder = &packets.BattleReplay{Seed: r.Seed, Time: r.Clock.Now().Unix(), Format: newBattleFormatMessage(r.Format)}
+	for i, v := range r.Players {
+		r.recorder.Teams = append(r.recorder.Teams, r.syncMessage(i))
+		if v.GetPlayer() != nil {
+			r.recorder.Uids = append(r.recorder.Uids, v.GetPlayer().UID)
+		}
+	}
+}
+
+func (r *BattleRoom) record(inputType uint32, number int, commands ...*Command) {
+	if r.recorder == nil {
+		return
+	}
+	input := &packets.ReplayInput{Type: inputType, Number: int64(number)}
+	for _, v := range commands {
+		if v != nil {
+			input.Commands = append(input.Commands, &packets.ReplayCommand{Number: int64(v.Number), Command: v.Msg})
+		}
+	}
+	r.recorder.Inputs = append(r.recorder.Inputs, input)
+}
+
+// saveRecord 战斗结束后保存回放
+func (r *BattleRoom) saveRecord() {
+	if r.recorder == nil || len(r.recorder.Uids) == 0 {
+		return
+	}
+	r.recorder.Winner = int64(r.winner)
+	if _, err := ReplayManager.Save(r.recorder); err != nil {
+		fmt.Println("save replay failed:", err)
+	}
+}
+
+// replayCommands 回放时读取回合指令并结算，指令已经用完时按照记录的胜者结束战斗
+func (r *BattleRoom) replayCommands() {
+	input := r.replay.pop(ReplayInputCommands)
+	if input == nil {
+		r.EndBattle(int(r.replay.replay.Winner))
+		r.resolve()
+		return
+	}
+	for _, v := range input.Commands {
+		number, slot := int(v.Number), int(v.Command.GetSlot())
+		if number >= 0 && number < len(r.Players) && slot >= 0 && slot < r.Format.ActivePets {
+			r.commands[r.actorIndex(number, slot)] = &Command{Msg: v.Command, Number: number}
+		}
+	}
+	r.resolve()
+}
+
+// replaySwitch 回放时读取宠物死亡后的更换，没有记录或者记录无效时该方认输
+func (r *BattleRoom) replaySwitch() {
+	input := r.replay.pop(ReplayInputSwitch)
+	if input != nil && len(input.Commands) > 0 {
+		cmd := input.Commands[0]
+		if r.submitSwitch(&Command{Msg: cmd.Command, Number: int(cmd.Number)}) == nil {
+			return
+		}
+	}
+	r.Defeat(r.switches[0].number)
+	r.waitSwitches(r.switchNext)
+}
+
+// replayForfeit 回放时检查掉线超时认输
+func (r *BattleRoom) replayForfeit() []*BattleEvent {
+	if input := r.replay.pop(ReplayInputForfeit); input != nil {
+		return r.Forfeit(int(input.Number))
+	}
+	return nil
+}
+
+// ReplayBattlePlayer 回放中的一方，只负责输出消息
+type ReplayBattlePlayer struct {
+	name        string
+	currentPet  *BattlePet
+	equippedPet [5]*BattlePet
+	output      func(message packets.BattleMsg)
+}
+
+func newReplayBattlePlayer(team *packets.SyncBattleInformationMessage) *ReplayBattlePlayer {
+	res := &ReplayBattlePlayer{}
+	if team == nil {
+		return res
+	}
+	res.name = team.PlayerName
+	for i, v := range team.PetMessages {
+		if i >= len(res.equippedPet) {
+			break
+		}
+		if pet := PetManager.NewPetFromMessage(v); pet != nil {
+			res.equippedPet[i] = &BattlePet{Pet: pet}
+		}
+	}
+	res.currentPet = res.equippedPet[0]
+	return res
+}
+
+func (p *ReplayBattlePlayer) ProcessMessage(message packets.BattleMsg) {
+	if p.output != nil {
+		p.output(message)
+	}
+}
+
+func (p *ReplayBattlePlayer) CurrentPet() *BattlePet {
+	return p.currentPet
+}
+
+func (p *ReplayBattlePlayer) SetCurrentPet(pet *BattlePet) {
+	p.currentPet = pet
+}
+
+func (p *ReplayBattlePlayer) EquippedPets() [5]*BattlePet {
+	return p.equippedPet
+}
+
+func (p *ReplayBattlePlayer) SetBattleRoom(room *BattleRoom) {}
+
+func (p *ReplayBattlePlayer) UserName() string {
+	return p.name
+}
+
+func (p *ReplayBattlePlayer) GetPlayer() *Player {
+	return nil
+}
@@ -0,0 +1,102 @@
+package objects
+
+import "TowberGoServer/pkg/packets"
+
+// LoseExpRate 战败方获得经验的比例
+const LoseExpRate = 0.3
+
+// BattleExp 击败指定等级的对手获得的经验总量，由所有参战过的宠物平分，包括已经阵亡的宠物
+func BattleExp(opponentLevel int, win bool) int {
+	exp := 5 + opponentLevel*5
+	if !win {
+		exp = int(float64(exp) * LoseExpRate)
+	}
+	return exp
+}
+
+// markParticipated 记录上场过的宠物
+func (r *BattleRoom) markParticipated(event *BattleEvent) {
+	if event.Type == PetSwitched {
+		if event.Pet != nil {
+			event.Pet.participated = true
+		}
+		return
+	}
+	for i := range r.Players {
+		for _, pet := range r.active[i] {
+			if pet != nil {
+				pet.participated = true
+			}
+		}
+	}
+}
+
+// opponentLevel 对方队伍上场过的宠物的平均等级
+func (r *BattleRoom) opponentLevel(number int) int {
+	total, count := 0, 0
+	for _, i := range r.Opponents(number) {
+		for _, v := range r.Players[i].EquippedPets() {
+			if v != nil && v.Pet != nil && v.participated {
+				total += v.Level()
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 1
+	}
+	return total / count
+}
+
+// settle 结算一方的经验和战利品，并生成结算消息
+func (r *BattleRoom) settle(number int) *packets.BattleEndStats {
+	player := r.Players[number].GetPlayer()
+	win := r.TeamOf(number) == r.winner
+	stats := &packets.BattleEndStats{Number: int64(number), Win: win}
+	// 掉线后由AI托管或者等待重连的一方不获得经验和战利品，也不会再向已经断开的客户端发送消息
+	if player == nil || !r.connected(number) {
+		return stats
+	}
+
+	// 经验由参战过的宠物平分
+	pets := make([]*BattlePet, 0)
+	for _, v := range r.Players[number].EquippedPets() {
+		if v != nil && v.Pet != nil && v.participated {
+			pets = append(pets, v)
+		}
+	}
+	if len(pets) > 0 {
+		exp := max(1, BattleExp(r.opponentLevel(number), win)/len(pets))
+		for _, v := range pets {
+			level, before := v.Level(), v.Exp()
+			if PetManager.AddExp(v.Pet, exp) {
+				PetManager.SavePet(player, v.Pet)
+			}
+			stats.Pets = append(stats.Pets, &packets.PetEndStats{
+				Id:       v.ID(),
+				PetId:    v.PetID(),
+				Exp:      int64(v.Exp() - before),
+				Level:    int64(v.Level()),
+				LevelUps: int64(v.Level() - level),
+			})
+		}
+	}
+
+	// 胜利方获得战利品
+	if win && r.Looter != nil {
+		loots, err := LootManager.GetLoot(player, r.Looter, 0.5)
+		if err != nil {
+			player.Client.SocketSend(&packets.Packet_DenyResponse{
+				DenyResponse: &packets.DenyResponseMessage{Reason: err.Error()}},
+			)
+		}
+		for _, v := range loots {
+			if v.Type == 1 {
+				stats.PetItems = append(stats.PetItems, &packets.PetItemMessage{Id: v.ID, Count: int64(v.Count)})
+			} else {
+				stats.Items = append(stats.Items, &packets.ItemMessage{Id: v.ID, Count: int64(v.Count)})
+			}
+		}
+	}
+	return stats
+}
@@ -1,11 +1,45 @@
 package objects
 
+// SkillTarget 技能的目标类型
+type SkillTarget int
+
+const (
+	// TargetEnemy 单个目标，默认为对方的宠物，双打时可以指定任意一只在场的宠物
+	TargetEnemy SkillTarget = iota
+	// TargetAllEnemies 对方所有在场的宠物，多个目标时伤害降低
+	TargetAllEnemies
+	// TargetSelf 释放技能的宠物自身
+	TargetSelf
+	// TargetAlly 单个己方宠物，默认为自身，双打时可以指定队友的宠物
+	TargetAlly
+	// TargetAll 除自身以外所有在场的宠物，包括队友
+	TargetAll
+)
+
 type Skill interface {
 	Name() string
 	ID() uint32
-	Use(self *BattlePet, enemy *BattlePet) []*AttackInfo
+	// Element 技能的属性
+	Element() Element
+	// Use 对一个目标释放技能，多个目标时对每个目标分别调用
+	Use(self *BattlePet, target *BattlePet) []*AttackInfo
 	Speed() int
+	// Accuracy 技能的命中率，取值0-100
+	Accuracy() int
+	// Cost 使用技能消耗的魔力值
 	Cost() int
+	// CoolDown 使用技能后需要等待的回合数
+	CoolDown() int
+	// MaxUses 每场战斗中的使用次数上限，为0时不限制
+	MaxUses() int
+	// Target 技能的目标类型
+	Target() SkillTarget
+}
+
+// SkillUnlock 种族技能表中的一项，宠物达到Level后解锁该技能
+type SkillUnlock struct {
+	Level int
+	Skill Skill
 }
 
 var SkillManager *SkillManagerStruct
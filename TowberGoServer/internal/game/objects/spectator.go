@@ -0,0 +1,117 @@
+package objects
+
+import (
+	"TowberGoServer/pkg/packets"
+	"errors"
+)
+
+// Spectator 观战者，只能接收战斗中公开的消息，不能发送指令
+type Spectator interface {
+	SpectatorID() uint32
+	ProcessMessage(message packets.BattleMsg)
+}
+
+// spectatorJoin 等待战斗协程处理的观战请求
+type spectatorJoin struct {
+	spectator Spectator
+	onJoin    func()
+	result    chan error
+}
+
+// AddSpectator 加入观战，由战斗协程在两步之间处理，加入成功后先调用onJoin再发送当前战斗的快照
+// onJoin 在战斗协程中调用，调用期间发起请求的协程一直等待结果
+func (r *BattleRoom) AddSpectator(spectator Spectator, onJoin func()) error {
+	join := &spectatorJoin{spectator: spectator, onJoin: onJoin, result: make(chan error, 1)}
+	select {
+	case r.joins <- join:
+		return <-join.result
+	case <-r.done:
+		return errors.New("the battle has ended")
+	}
+}
+
+// addSpectator 在战斗协程中加入观战者并发送快照
+func (r *BattleRoom) addSpectator(join *spectatorJoin) {
+	if err := r.registerSpectator(join.spectator); err != nil {
+		join.result <- err
+		return
+	}
+	if join.onJoin != nil {
+		join.onJoin()
+	}
+	join.spectator.ProcessMessage(&packets.BattlePacket_BattleSnapshot{BattleSnapshot: r.snapshot()})
+	join.result <- nil
+}
+
+func (r *BattleRoom) registerSpectator(spectator Spectator) error {
+	r.spectatorLock.Lock()
+	defer r.spectatorLock.Unlock()
+	if r.stage == StageEnded {
+		return errors.New("the battle has ended")
+	}
+	if r.spectators == nil {
+		r.spectators = make(map[uint32]Spectator)
+	}
+	if _, ok := r.spectators[spectator.SpectatorID()]; ok {
+		return errors.New("already spectating")
+	}
+	if len(r.spectators) >= r.Config.MaxSpectators {
+		return errors.New("too many spectators")
+	}
+	r.spectators[spectator.SpectatorID()] = spectator
+	return nil
+}
+
+func (r *BattleRoom) RemoveSpectator(id uint32) {
+	r.spectatorLock.Lock()
+	defer r.spectatorLock.Unlock()
+	delete(r.spectators, id)
+}
+
+// Broadcast 向所有玩家以及观战者发送公开的消息
+func (r *BattleRoom) Broadcast(message packets.BattleMsg) {
+	for _, v := range r.Players {
+		v.ProcessMessage(message)
+	}
+	r.sendSpectators(message)
+}
+
+// sendOthers 向除了number以外的所有玩家以及观战者发送消息
+func (r *BattleRoom) sendOthers(number int, message packets.BattleMsg) {
+	for i, v := range r.Players {
+		if i != number {
+			v.ProcessMessage(message)
+		}
+	}
+	r.sendSpectators(message)
+}
+
+func (r *BattleRoom) sendSpectators(message packets.BattleMsg) {
+	r.spectatorLock.Lock()
+	defer r.spectatorLock.Unlock()
+	for _, v := range r.spectators {
+		v.ProcessMessage(message)
+	}
+}
+
+// snapshot 当前战斗的快照，包括赛制、每一方的队伍、在场宠物以及buff
+func (r *BattleRoom) snapshot() *packets.BattleSnapshotMessage {
+	res := &packets.BattleSnapshotMessage{
+		Buffs:  r.buffMessages(),
+		Round:  int64(r.round),
+		Format: newBattleFormatMessage(r.Format),
+	}
+	for i, v := range r.Players {
+		res.Teams = append(res.Teams, r.syncMessage(i))
+		for _, active := range r.active[i] {
+			current := int64(-1)
+			for k, pet := range v.EquippedPets() {
+				if pet != nil && pet == active {
+					current = int64(k)
+				}
+			}
+			res.CurrentPets = append(res.CurrentPets, current)
+		}
+	}
+	return res
+}
@@ -0,0 +1,122 @@
+package objects
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// MaxTalentValue 单项个体值的上限
+const MaxTalentValue = 31
+
+type StatType int
+
+const (
+	StatNone StatType = iota
+	StatHP
+	StatMana
+	StatStrength
+	StatIntelligence
+	StatSpeed
+	StatDefense
+)
+
+// Nature 宠物性格，提升一项属性的成长并降低另一项属性的成长，Up和Down都为StatNone时为中性性格
+type Nature struct {
+	ID   uint32
+	Name string
+	Up   StatType
+	Down StatType
+}
+
+var NatureList = []Nature{
+	{ID: 0, Name: "Hardy"},
+	{ID: 1, Name: "Brave", Up: StatStrength, Down: StatSpeed},
+	{ID: 2, Name: "Adamant", Up: StatStrength, Down: StatIntelligence},
+	{ID: 3, Name: "Bold", Up: StatDefense, Down: StatStrength},
+	{ID: 4, Name: "Relaxed", Up: StatDefense, Down: StatSpeed},
+	{ID: 5, Name: "Modest", Up: StatIntelligence, Down: StatStrength},
+	{ID: 6, Name: "Quiet", Up: StatIntelligence, Down: StatSpeed},
+	{ID: 7, Name: "Timid", Up: StatSpeed, Down: StatStrength},
+	{ID: 8, Name: "Jolly", Up: StatSpeed, Down: StatIntelligence},
+	{ID: 9, Name: "Sturdy", Up: StatHP, Down: StatMana},
+	{ID: 10, Name: "Wise", Up: StatMana, Down: StatHP},
+}
+
+// Talent 宠物的个体天赋，在创建宠物时随机生成，之后不再改变
+type Talent struct {
+	Nature       uint32
+	HP           int
+	Mana         int
+	Strength     int
+	Intelligence int
+	Speed        int
+	Defense      int
+}
+
+// RollTalent 随机生成一份个体天赋
+func RollTalent() *Talent {
+	return &Talent{
+		Nature:       uint32(rand.IntN(len(NatureList))),
+		HP:           rand.IntN(MaxTalentValue + 1),
+		Mana:         rand.IntN(MaxTalentValue + 1),
+		Strength:     rand.IntN(MaxTalentValue + 1),
+		Intelligence: rand.IntN(MaxTalentValue + 1),
+		Speed:        rand.IntN(MaxTalentValue + 1),
+		Defense:      rand.IntN(MaxTalentValue + 1),
+	}
+}
+
+func (t *Talent) GetNature() Nature {
+	if int(t.Nature) >= len(NatureList) {
+		return NatureList[0]
+	}
+	return NatureList[t.Nature]
+}
+
+// Apply 在创建宠物时将天赋作用于初始属性，个体值最多提升15%的属性
+func (t *Talent) Apply(stats *Stats) {
+	t.forEach(stats, func(stat StatType, value *int, iv int) {
+		*value = t.adjust(*value, stat, 1+float64(iv)/200)
+	})
+	stats.HP = stats.MaxHP
+	stats.Mana = stats.MaxMana
+}
+
+// Grow 宠物升级时根据天赋计算每项属性的成长值，个体值最多提升50%的成长
+func (t *Talent) Grow(stats *Stats, growth Stats) {
+	g := t.values(&growth)
+	t.forEach(stats, func(stat StatType, value *int, iv int) {
+		*value += t.adjust(g[stat], stat, 1+float64(iv)/float64(2*MaxTalentValue))
+	})
+}
+
+// adjust 根据个体值倍率以及性格修正计算属性
+func (t *Talent) adjust(value int, stat StatType, rate float64) int {
+	nature := t.GetNature()
+	if nature.Up == stat {
+		rate *= 1.1
+	} else if nature.Down == stat {
+		rate *= 0.9
+	}
+	return int(math.Round(float64(value) * rate))
+}
+
+func (t *Talent) values(stats *Stats) map[StatType]int {
+	return map[StatType]int{
+		StatHP:           stats.MaxHP,
+		StatMana:         stats.MaxMana,
+		StatStrength:     stats.Strength,
+		StatIntelligence: stats.Intelligence,
+		StatSpeed:        stats.Speed,
+		StatDefense:      stats.Defense,
+	}
+}
+
+func (t *Talent) forEach(stats *Stats, callback func(stat StatType, value *int, iv int)) {
+	callback(StatHP, &stats.MaxHP, t.HP)
+	callback(StatMana, &stats.MaxMana, t.Mana)
+	callback(StatStrength, &stats.Strength, t.Strength)
+	callback(StatIntelligence, &stats.Intelligence, t.Intelligence)
+	callback(StatSpeed, &stats.Speed, t.Speed)
+	callback(StatDefense, &stats.Defense, t.Defense)
+}
@@ -0,0 +1,134 @@
+package objects
+
+import (
+	"TowberGoServer/pkg/packets"
+	"time"
+)
+
+// TimeoutAction 等待指令超时后宠物的默认行动
+type TimeoutAction uint32
+
+const (
+	// TimeoutSkip 超时的宠物本回合不行动
+	TimeoutSkip TimeoutAction = iota
+	// TimeoutRandomSkill 随机释放一个可以使用的技能
+	TimeoutRandomSkill
+	// TimeoutBasicAttack 释放第一个可以使用的技能
+	TimeoutBasicAttack
+)
+
+// BattleTimer 战斗的计时配置，不同的战斗模式使用不同的计时
+type BattleTimer struct {
+	// CommandTimeout 每回合等待指令的时间
+	CommandTimeout time.Duration
+	// SwitchTimeout 宠物阵亡后等待玩家更换宠物的时间，超时后自动选择
+	SwitchTimeout time.Duration
+	// TimeoutAction 等待指令超时后的默认行动
+	TimeoutAction TimeoutAction
+	// MaxTimeouts 连续超时达到该回合数的一方直接认输，为0时不限制
+	MaxTimeouts int
+}
+
+var (
+	// DefaultBattleTimer 默认计时，超时的宠物不行动
+	DefaultBattleTimer = BattleTimer{
+		CommandTimeout: 15 * time.Second,
+		SwitchTimeout:  10 * time.Second,
+		TimeoutAction:  TimeoutSkip,
+	}
+	// WildBattleTimer 野外战斗，给玩家更多的思考时间，超时后自动攻击
+	WildBattleTimer = BattleTimer{
+		CommandTimeout: 60 * time.Second,
+		SwitchTimeout:  30 * time.Second,
+		TimeoutAction:  TimeoutBasicAttack,
+	}
+	// FriendlyBattleTimer 好友之间的对战，超时后自动攻击，连续超时5回合判负
+	FriendlyBattleTimer = BattleTimer{
+		CommandTimeout: 30 * time.Second,
+		SwitchTimeout:  15 * time.Second,
+		TimeoutAction:  TimeoutBasicAttack,
+		MaxTimeouts:    5,
+	}
+	// RankedBattleTimer 排位战斗，超时后随机释放技能，连续超时3回合判负
+	RankedBattleTimer = BattleTimer{
+		CommandTimeout: 15 * time.Second,
+		SwitchTimeout:  10 * time.Second,
+		TimeoutAction:  TimeoutRandomSkill,
+		MaxTimeouts:    3,
+	}
+)
+
+// WithTimer 创建房间时设置战斗的计时
+func WithTimer(timer BattleTimer) func(room *BattleRoom) {
+	return func(room *BattleRoom) {
+		room.Config.Timer = timer
+	}
+}
+
+// waitingFor 一方是否还有存活的在场宠物没有收到指令，逃跑的一方不再需要其它位置的指令
+func (r *BattleRoom) waitingFor(commands []*Command, number int) bool {
+	for slot := range r.Format.ActivePets {
+		if cmd := commands[r.actorIndex(number, slot)]; cmd != nil {
+			if _, ok := cmd.Msg.Command.(*packets.RoundCommandMessage_Runaway); ok {
+				return false
+			}
+		}
+	}
+	for slot, pet := range r.active[number] {
+		if alive(pet) && commands[r.actorIndex(number, slot)] == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// fillTimeouts 等待指令超时后为没有收到指令的宠物选择默认行动，连续超时次数达到上限的一方逃跑认输
+// 默认行动会和玩家的指令一起记录到回放中，因此随机选择技能时使用 inputRng，回放时战斗的随机数不受影响
+func (r *BattleRoom) fillTimeouts(commands []*Command) {
+	for i := range r.Players {
+		if !r.waitingFor(commands, i) {
+			r.timeouts[i] = 0
+			continue
+		}
+		r.timeouts[i]++
+		if r.Config.Timer.MaxTimeouts > 0 && r.timeouts[i] >= r.Config.Timer.MaxTimeouts {
+			commands[r.actorIndex(i, 0)] = &Command{
+				Msg:    &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_Runaway{}},
+				Number: i,
+			}
+			continue
+		}
+		for slot, pet := range r.active[i] {
+			index := r.actorIndex(i, slot)
+			if alive(pet) && commands[index] == nil {
+				commands[index] = r.timeoutCommand(i, slot, pet)
+			}
+		}
+	}
+}
+
+// timeoutCommand 根据房间配置生成超时宠物的默认指令，不行动或者没有可以使用的技能时返回nil
+func (r *BattleRoom) timeoutCommand(number int, slot int, pet *BattlePet) *Command {
+	usable := make([]int, 0)
+	for i, v := range pet.EquippedSkills() {
+		if v != nil && pet.CheckSkill(v) == nil {
+			usable = append(usable, i)
+		}
+	}
+	if len(usable) == 0 {
+		return nil
+	}
+	pos := usable[0]
+	switch r.Config.Timer.TimeoutAction {
+	case TimeoutRandomSkill:
+		pos = usable[r.inputRng.IntN(len(usable))]
+	case TimeoutBasicAttack:
+	default:
+		return nil
+	}
+	return &Command{
+		Msg: &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_Attack{
+			Attack: &packets.Attack{SkillPos: int64(pos)}}, Slot: int64(slot)},
+		Number: number,
+	}
+}
@@ -0,0 +1,267 @@
+package objects
+
+import (
+	"TowberGoServer/internal/db"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// WagerEscrowed 双方的押注已经扣除，等待战斗结束
+	WagerEscrowed uint8 = iota + 1
+	// WagerPaid 押注已经发放给胜者
+	WagerPaid
+	// WagerRefunded 押注已经退还给双方
+	WagerRefunded
+)
+
+// maxStakeItems 每场押注对战最多押上的物品种类
+const maxStakeItems = 5
+
+var WagerManager *WagerManagerStruct
+
+// WagerManagerStruct 管理押注对战，押注的物品在接受邀请时从双方背包中扣除，战斗结束后通过邮件发放给胜者
+// 物品的每一次流动都会记录到数据库中
+type WagerManagerStruct struct {
+	db *gorm.DB
+}
+
+func NewWagerManager(db *gorm.DB) *WagerManagerStruct {
+	return &WagerManagerStruct{db: db}
+}
+
+// Wager 一场押注对战，双方押上相同的物品，Players[0] 为发起邀请的一方
+type Wager struct {
+	ID      uint64
+	Players [2]*Player
+	Stake   []MailItem
+	end     chan *BattleSummary
+}
+
+// CheckStake 检查押注的物品是否存在以及玩家是否拥有足够的数量
+func (m *WagerManagerStruct) CheckStake(player *Player, stake []MailItem) error {
+	if len(stake) > maxStakeItems {
+		return errors.New("too many stake items")
+	}
+	seen := make(map[MailItem]bool)
+	for _, v := range stake {
+		if v.Count == 0 {
+			return errors.New("invalid stake")
+		}
+		key := MailItem{ID: v.ID, Type: v.Type}
+		if seen[key] {
+			return errors.New("duplicate stake item")
+		}
+		seen[key] = true
+		owned, ok := ownedCount(player, v)
+		if !ok {
+			return errors.New("no such item")
+		}
+		if owned < int(v.Count) {
+			return errors.New("not enough items to stake")
+		}
+	}
+	return nil
+}
+
+// Escrow 从双方背包中扣除押注的物品，任意一方扣除失败时归还已经扣除的物品
+func (m *WagerManagerStruct) Escrow(players [2]*Player, stake []MailItem) (*Wager, error) {
+	record := &db.Wagers{Inviter: players[0].UID, Target: players[1].UID, Status: WagerEscrowed}
+	if err := m.db.Create(record).Error; err != nil {
+		return nil, err
+	}
+	wager := &Wager{ID: record.ID, Players: players, Stake: stake, end: make(chan *BattleSummary, 1)}
+	type escrowed struct {
+		player *Player
+		item   MailItem
+	}
+	taken := make([]escrowed, 0, len(stake)*2)
+	for _, player := range players {
+		for _, item := range stake {
+			if err := deleteStake(player, item); err != nil {
+				for _, v := range taken {
+					compensateStake(v.player, v.item)
+					m.log(wager.ID, v.player.UID, "rollback", v.item)
+				}
+				m.claim(wager.ID, WagerRefunded, 0)
+				return nil, fmt.Errorf("%s does not have enough items to stake", player.UserName)
+			}
+			taken = append(taken, escrowed{player: player, item: item})
+			m.log(wager.ID, player.UID, "escrow", item)
+		}
+	}
+	go m.wait(wager)
+	return wager, nil
+}
+
+// Wagered 将战斗房间设置为押注对战，战斗结束后通过 EndChan 结算押注
+func (m *WagerManagerStruct) Wagered(wager *Wager) func(room *BattleRoom) {
+	return func(room *BattleRoom) {
+		room.EndChan = append(room.EndChan, wager.end)
+	}
+}
+
+// Abort 战斗没有开始时退还双方的押注
+func (m *WagerManagerStruct) Abort(wager *Wager) {
+	close(wager.end)
+}
+
+func (m *WagerManagerStruct) wait(wager *Wager) {
+	summary, ok := <-wager.end
+	if !ok {
+		m.Refund(wager, "The battle was aborted.")
+		return
+	}
+	m.Settle(wager, summary)
+}
+
+// Settle 战斗结束后将双方的押注发放给胜者，无法确定胜者时退还
+func (m *WagerManagerStruct) Settle(wager *Wager, summary *BattleSummary) {
+	winner := -1
+	for i, v := range wager.Players {
+		// 掉线被替换的一方没有玩家，需要根据另一方判断胜负
+		if summary.Winner != nil && summary.Winner.UID == v.UID {
+			winner = i
+		} else if summary.Loser != nil && summary.Loser.UID == v.UID {
+			winner = 1 - i
+		}
+	}
+	if winner < 0 {
+		m.Refund(wager, "The battle ended without a winner.")
+		return
+	}
+	player := wager.Players[winner]
+	if !m.claim(wager.ID, WagerPaid, player.UID) {
+		return
+	}
+	pot := make([]MailItem, 0, len(wager.Stake))
+	for _, v := range wager.Stake {
+		v.Count *= 2
+		pot = append(pot, v)
+		m.log(wager.ID, player.UID, "payout", v)
+	}
+	MailManager.SendMail(player.UID, &Mail{
+		Title:   "Wager Won",
+		Content: fmt.Sprintf("You won the wager against %s.", wager.Players[1-winner].UserName),
+		Sender:  "System",
+		Items:   pot,
+	})
+}
+
+// Refund 通过邮件将押注退还给双方
+func (m *WagerManagerStruct) Refund(wager *Wager, reason string) {
+	if !m.claim(wager.ID, WagerRefunded, 0) {
+		return
+	}
+	for _, player := range wager.Players {
+		m.refund(wager.ID, player.UID, wager.Stake, reason)
+	}
+}
+
+// RefundPending 退还服务器重启前没有结算的押注，根据押注记录还原每一方的物品，服务器启动时调用
+func (m *WagerManagerStruct) RefundPending() {
+	var wagers []db.Wagers
+	if err := m.db.Where("status = ?", WagerEscrowed).Find(&wagers).Error; err != nil {
+		fmt.Println("load pending wagers error:", err)
+		return
+	}
+	for _, wager := range wagers {
+		var logs []db.WagerLogs
+		if err := m.db.Where("wager = ? AND action = ?", wager.ID, "escrow").Find(&logs).Error; err != nil {
+			fmt.Println("load wager logs error:", err)
+			continue
+		}
+		if !m.claim(wager.ID, WagerRefunded, 0) {
+			continue
+		}
+		stakes := make(map[uint32][]MailItem)
+		for _, v := range logs {
+			stakes[v.UID] = append(stakes[v.UID], MailItem{ID: v.ItemID, Count: v.Count, Type: v.ItemType})
+		}
+		for uid, stake := range stakes {
+			m.refund(wager.ID, uid, stake, "The server restarted before the battle ended.")
+		}
+	}
+}
+
+func (m *WagerManagerStruct) refund(wager uint64, uid uint32, stake []MailItem, reason string) {
+	for _, v := range stake {
+		m.log(wager, uid, "refund", v)
+	}
+	MailManager.SendMail(uid, &Mail{
+		Title:   "Wager Refund",
+		Content: reason + " Your stake has been returned.",
+		Sender:  "System",
+		Items:   stake,
+	})
+}
+
+// claim 将押注从押注中的状态改为结算后的状态，已经结算过时返回false，避免重复发放
+func (m *WagerManagerStruct) claim(id uint64, status uint8, winner uint32) bool {
+	result := m.db.Model(&db.Wagers{}).Where("id = ? AND status = ?", id, WagerEscrowed).
+		Updates(map[string]any{"status": status, "winner": winner})
+	if result.Error != nil {
+		fmt.Println("update wager error:", result.Error)
+		return false
+	}
+	return result.RowsAffected == 1
+}
+
+func (m *WagerManagerStruct) log(wager uint64, uid uint32, action string, item MailItem) {
+	err := m.db.Create(&db.WagerLogs{
+		Wager:    wager,
+		UID:      uid,
+		Action:   action,
+		ItemType: item.Type,
+		ItemID:   item.ID,
+		Count:    item.Count,
+	}).Error
+	if err != nil {
+		fmt.Println("write wager log error:", err)
+	}
+}
+
+// ownedCount 玩家背包中物品的数量，物品不存在时返回false
+func ownedCount(player *Player, item MailItem) (int, bool) {
+	count := 0
+	switch item.Type {
+	case 1:
+		if PetItemManager.PetItemList[item.ID] == nil {
+			return 0, false
+		}
+		for _, v := range PetItemManager.GetBags(player) {
+			if v.ID == item.ID {
+				count += v.Count
+			}
+		}
+	case 2:
+		if ItemManager.ItemMap[item.ID] == nil {
+			return 0, false
+		}
+		for _, v := range ItemManager.GetBags(player) {
+			if v.ID == item.ID {
+				count += v.Count
+			}
+		}
+	default:
+		return 0, false
+	}
+	return count, true
+}
+
+func deleteStake(player *Player, item MailItem) error {
+	if item.Type == 1 {
+		return PetItemManager.DeleteItem(player, item.ID, int(item.Count))
+	}
+	return ItemManager.DeleteItem(player, item.ID, int(item.Count))
+}
+
+func compensateStake(player *Player, item MailItem) {
+	if item.Type == 1 {
+		PetItemManager.CompensateItem(player, item.ID, int(item.Count))
+		return
+	}
+	ItemManager.CompensateItem(player, item.ID, int(item.Count))
+}
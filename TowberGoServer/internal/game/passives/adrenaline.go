@@ -0,0 +1,27 @@
+package passives
+
+import "TowberGoServer/internal/game/objects"
+
+// Adrenaline 肾上腺素，生命值低于30%时速度提升50%
+type Adrenaline struct {
+	objects.BasePassive
+}
+
+func (a *Adrenaline) ID() uint32 {
+	return 2
+}
+
+func (a *Adrenaline) Name() string {
+	return "Adrenaline"
+}
+
+func (a *Adrenaline) GetEvent(event *objects.BattleEvent, owner *objects.BattlePet, battleRoom *objects.BattleRoom) {
+	if event.Pet != owner {
+		return
+	}
+	if owner.Stats().HP > 0 && owner.Stats().HP*10 < owner.Stats().MaxHP*3 {
+		owner.SetModifier(a.Name(), objects.Stats{Speed: owner.Stats().Speed / 2})
+	} else {
+		owner.RemoveModifier(a.Name())
+	}
+}
@@ -0,0 +1,23 @@
+package passives
+
+import "TowberGoServer/internal/game/objects"
+
+// Regeneration 再生，回合结束时恢复5%的最大生命值
+type Regeneration struct {
+	objects.BasePassive
+}
+
+func (r *Regeneration) ID() uint32 {
+	return 1
+}
+
+func (r *Regeneration) Name() string {
+	return "Regeneration"
+}
+
+func (r *Regeneration) GetEvent(event *objects.BattleEvent, owner *objects.BattlePet, battleRoom *objects.BattleRoom) {
+	if event.Type != objects.RoundEnded || owner.Stats().HP <= 0 || owner.Stats().HP >= owner.Stats().MaxHP {
+		return
+	}
+	battleRoom.HealPet(owner, max(1, owner.Stats().MaxHP*5/100))
+}
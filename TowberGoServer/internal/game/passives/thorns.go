@@ -0,0 +1,20 @@
+package passives
+
+import "TowberGoServer/internal/game/objects"
+
+// Thorns 荆棘，受到物理伤害时将10%的伤害反弹给攻击方
+type Thorns struct {
+	objects.BasePassive
+}
+
+func (t *Thorns) ID() uint32 {
+	return 3
+}
+
+func (t *Thorns) Name() string {
+	return "Thorns"
+}
+
+func (t *Thorns) Defend(attack *objects.AttackInfo, owner *objects.BattlePet) {
+	attack.ReflectDamage += attack.PhysicalDamage / 10
+}
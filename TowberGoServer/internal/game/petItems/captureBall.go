@@ -0,0 +1,42 @@
+package petItems
+
+import (
+	"TowberGoServer/internal/game/objects"
+	"errors"
+)
+
+// CaptureBall 捕捉球，在野生宠物战斗中使用
+type CaptureBall struct {
+	id       uint32
+	name     string
+	strength float64
+	count    int
+}
+
+func NewCaptureBall(id uint32, name string, strength float64) *CaptureBall {
+	return &CaptureBall{id: id, name: name, strength: strength}
+}
+
+func (c *CaptureBall) Use(pet objects.Pet, count int) error {
+	return errors.New("this item can only be used in battle")
+}
+
+func (c *CaptureBall) Strength() float64 {
+	return c.strength
+}
+
+func (c *CaptureBall) Count() int {
+	return c.count
+}
+
+func (c *CaptureBall) ID() uint32 {
+	return c.id
+}
+
+func (c *CaptureBall) Name() string {
+	return c.name
+}
+
+func (c *CaptureBall) Clone(count int) objects.PetItem {
+	return &CaptureBall{id: c.id, name: c.name, strength: c.strength, count: count}
+}
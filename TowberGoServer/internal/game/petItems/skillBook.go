@@ -0,0 +1,44 @@
+package petItems
+
+import (
+	"TowberGoServer/internal/game/objects"
+	"errors"
+)
+
+// SkillBook 技能书，使用后宠物可以学会种族技能表之外的技能
+type SkillBook struct {
+	id    uint32
+	skill uint32
+	name  string
+	count int
+}
+
+func NewSkillBook(id uint32, skill uint32, name string) *SkillBook {
+	return &SkillBook{id: id, skill: skill, name: name}
+}
+
+func (s *SkillBook) Use(pet objects.Pet, count int) error {
+	if pet == nil {
+		return errors.New("pet error")
+	}
+	if count != 1 {
+		return errors.New("skill book can only be used one at a time")
+	}
+	return objects.PetManager.TeachSkill(pet, s.skill)
+}
+
+func (s *SkillBook) Count() int {
+	return s.count
+}
+
+func (s *SkillBook) ID() uint32 {
+	return s.id
+}
+
+func (s *SkillBook) Name() string {
+	return s.name
+}
+
+func (s *SkillBook) Clone(count int) objects.PetItem {
+	return &SkillBook{id: s.id, skill: s.skill, name: s.name, count: count}
+}
@@ -2,12 +2,32 @@ package pets
 
 import (
 	"TowberGoServer/internal/game/objects"
+	"TowberGoServer/internal/game/passives"
 	"TowberGoServer/internal/game/skills"
 )
 
-var BuroSkillList map[int]objects.Skill = map[int]objects.Skill{
-	1: &skills.Bite{},
-	5: &skills.TripleStrike{},
+var BuroSkillList = []objects.SkillUnlock{
+	{Level: 1, Skill: &skills.Bite{}},
+	{Level: 2, Skill: &skills.Growl{}},
+	{Level: 3, Skill: &skills.Roar{}},
+	{Level: 5, Skill: &skills.TripleStrike{}},
+	{Level: 8, Skill: &skills.Recover{}},
+	{Level: 10, Skill: &skills.Slam{}},
+	{Level: 12, Skill: &skills.Harden{}},
+}
+
+var BuroPassives = []objects.Passive{
+	&passives.Adrenaline{},
+}
+
+// buroGrowth 每次升级时的基础成长值
+var buroGrowth = objects.Stats{
+	MaxHP:        5,
+	MaxMana:      3,
+	Strength:     5,
+	Intelligence: 1,
+	Speed:        1,
+	Defense:      2,
 }
 
 type Buro struct {
@@ -17,6 +37,8 @@ type Buro struct {
 	equippedSkills [4]objects.Skill
 	owner          *objects.Player
 	id             uint64
+	talent         objects.Talent
+	learnedSkills  []objects.Skill
 }
 
 func (b *Buro) PetID() uint32 {
@@ -35,7 +57,19 @@ func (b *Buro) Name() string {
 	return "Buro"
 }
 
-func (b *Buro) SkillList() map[int]objects.Skill {
+func (b *Buro) Elements() []objects.Element {
+	return []objects.Element{objects.ElementEarth}
+}
+
+func (b *Buro) Passives() []objects.Passive {
+	return BuroPassives
+}
+
+func (b *Buro) CaptureRate() float64 {
+	return 0.6
+}
+
+func (b *Buro) SkillList() []objects.SkillUnlock {
 	return BuroSkillList
 }
 
@@ -49,26 +83,29 @@ func (b *Buro) Level() int {
 
 func (b *Buro) LevelUp() {
 	b.level += 1
-	b.stats.MaxHP += 5
+	b.talent.Grow(&b.stats, buroGrowth)
 	b.stats.HP = b.stats.MaxHP
-	b.stats.MaxMana += 3
 	b.stats.Mana = b.stats.MaxMana
-	b.stats.Defense += 2
-	b.stats.Intelligence += 1
-	b.stats.Strength += 5
-	b.stats.Speed += 1
 }
 
 func (b *Buro) UnlockedSkillList() []objects.Skill {
 	s := make([]objects.Skill, 0)
-	for i := range BuroSkillList {
-		if b.level >= i {
-			s = append(s, BuroSkillList[i])
+	for _, v := range BuroSkillList {
+		if b.level >= v.Level {
+			s = append(s, v.Skill)
 		}
 	}
 	return s
 }
 
+func (b *Buro) LearnedSkills() []objects.Skill {
+	return b.learnedSkills
+}
+
+func (b *Buro) AddLearnedSkill(skill objects.Skill) {
+	b.learnedSkills = append(b.learnedSkills, skill)
+}
+
 func (b *Buro) EquippedSkills() [4]objects.Skill {
 	return b.equippedSkills
 }
@@ -77,7 +114,7 @@ func (b *Buro) SetSkill(pos int, skill objects.Skill) {
 	b.equippedSkills[pos] = skill
 }
 
-func (b *Buro) Initialize(exp int, equippedSkills []uint32, stats *objects.Stats, owner *objects.Player) objects.Pet {
+func (b *Buro) Initialize(exp int, equippedSkills []uint32, stats *objects.Stats, talent *objects.Talent, owner *objects.Player) objects.Pet {
 	res := Buro{
 		exp:            exp,
 		stats:          *stats,
@@ -85,6 +122,9 @@ func (b *Buro) Initialize(exp int, equippedSkills []uint32, stats *objects.Stats
 		equippedSkills: [4]objects.Skill{},
 		owner:          owner,
 	}
+	if talent != nil {
+		res.talent = *talent
+	}
 	if len(equippedSkills) > 4 {
 		equippedSkills = equippedSkills[:4]
 	}
@@ -113,6 +153,10 @@ func (b *Buro) BaseStats() objects.Stats {
 	}
 }
 
+func (b *Buro) Talent() *objects.Talent {
+	return &b.talent
+}
+
 func (b *Buro) Owner() *objects.Player {
 	return b.owner
 }
@@ -121,6 +165,6 @@ func (b *Buro) SetExp(exp int) {
 	b.exp = exp
 }
 
-func (b *Buro) GetEvent(event int, self bool, battleRoom *objects.BattleRoom) {
+func (b *Buro) GetEvent(event *objects.BattleEvent, self bool, battleRoom *objects.BattleRoom) {
 
 }
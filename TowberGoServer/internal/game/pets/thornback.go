@@ -0,0 +1,171 @@
+package pets
+
+import (
+	"TowberGoServer/internal/game/objects"
+	"TowberGoServer/internal/game/passives"
+	"TowberGoServer/internal/game/skills"
+)
+
+var ThornbackSkillList = []objects.SkillUnlock{
+	{Level: 1, Skill: &skills.Bite{}},
+	{Level: 3, Skill: &skills.Harden{}},
+	{Level: 5, Skill: &skills.PoisonFang{}},
+	{Level: 8, Skill: &skills.Recover{}},
+	{Level: 10, Skill: &skills.Growl{}},
+}
+
+// ThornbackPassives 刺背兽依靠回复和反伤进行消耗战
+var ThornbackPassives = []objects.Passive{
+	&passives.Regeneration{},
+	&passives.Thorns{},
+}
+
+// thornbackGrowth 每次升级时的基础成长值
+var thornbackGrowth = objects.Stats{
+	MaxHP:        7,
+	MaxMana:      3,
+	Strength:     3,
+	Intelligence: 1,
+	Speed:        1,
+	Defense:      4,
+}
+
+// Thornback 刺背兽，生命和防御较高
+type Thornback struct {
+	exp            int
+	stats          objects.Stats
+	level          int
+	equippedSkills [4]objects.Skill
+	owner          *objects.Player
+	id             uint64
+	talent         objects.Talent
+	learnedSkills  []objects.Skill
+}
+
+func (t *Thornback) PetID() uint32 {
+	return 2
+}
+
+func (t *Thornback) ID() uint64 {
+	return t.id
+}
+
+func (t *Thornback) SetID(id uint64) {
+	t.id = id
+}
+
+func (t *Thornback) Name() string {
+	return "Thornback"
+}
+
+func (t *Thornback) Elements() []objects.Element {
+	return []objects.Element{objects.ElementGrass}
+}
+
+func (t *Thornback) Passives() []objects.Passive {
+	return ThornbackPassives
+}
+
+func (t *Thornback) CaptureRate() float64 {
+	return 0.4
+}
+
+func (t *Thornback) SkillList() []objects.SkillUnlock {
+	return ThornbackSkillList
+}
+
+func (t *Thornback) Exp() int {
+	return t.exp
+}
+
+func (t *Thornback) Level() int {
+	return t.level
+}
+
+func (t *Thornback) LevelUp() {
+	t.level += 1
+	t.talent.Grow(&t.stats, thornbackGrowth)
+	t.stats.HP = t.stats.MaxHP
+	t.stats.Mana = t.stats.MaxMana
+}
+
+func (t *Thornback) UnlockedSkillList() []objects.Skill {
+	s := make([]objects.Skill, 0)
+	for _, v := range ThornbackSkillList {
+		if t.level >= v.Level {
+			s = append(s, v.Skill)
+		}
+	}
+	return s
+}
+
+func (t *Thornback) LearnedSkills() []objects.Skill {
+	return t.learnedSkills
+}
+
+func (t *Thornback) AddLearnedSkill(skill objects.Skill) {
+	t.learnedSkills = append(t.learnedSkills, skill)
+}
+
+func (t *Thornback) EquippedSkills() [4]objects.Skill {
+	return t.equippedSkills
+}
+
+func (t *Thornback) SetSkill(pos int, skill objects.Skill) {
+	t.equippedSkills[pos] = skill
+}
+
+func (t *Thornback) Initialize(exp int, equippedSkills []uint32, stats *objects.Stats, talent *objects.Talent, owner *objects.Player) objects.Pet {
+	res := Thornback{
+		exp:            exp,
+		stats:          *stats,
+		level:          objects.ConvertExpToLevel(exp),
+		equippedSkills: [4]objects.Skill{},
+		owner:          owner,
+	}
+	if talent != nil {
+		res.talent = *talent
+	}
+	if len(equippedSkills) > 4 {
+		equippedSkills = equippedSkills[:4]
+	}
+	for i := range equippedSkills {
+		if equippedSkills[i] != 0 {
+			res.equippedSkills[i] = objects.SkillManager.SkillList[equippedSkills[i]]
+		}
+	}
+	return &res
+}
+
+func (t *Thornback) Stats() *objects.Stats {
+	return &t.stats
+}
+
+func (t *Thornback) BaseStats() objects.Stats {
+	return objects.Stats{
+		MaxHP:        70,
+		HP:           70,
+		MaxMana:      50,
+		Mana:         50,
+		Strength:     40,
+		Intelligence: 20,
+		Speed:        35,
+		Defense:      25,
+	}
+}
+
+func (t *Thornback) Talent() *objects.Talent {
+	return &t.talent
+}
+
+func (t *Thornback) Owner() *objects.Player {
+	return t.owner
+}
+
+func (t *Thornback) SetExp(exp int) {
+	t.exp = exp
+}
+
+func (t *Thornback) GetEvent(event *objects.BattleEvent, self bool, battleRoom *objects.BattleRoom) {
+
+}
@@ -2,9 +2,7 @@ package skills
 
 import "TowberGoServer/internal/game/objects"
 
-type Bite struct {
-	coolDown int
-}
+type Bite struct{}
 
 func (b *Bite) Name() string {
 	return "bite"
@@ -14,6 +12,10 @@ func (b *Bite) ID() uint32 {
 	return 1
 }
 
+func (b *Bite) Element() objects.Element {
+	return objects.ElementNormal
+}
+
 func (b *Bite) Use(self *objects.BattlePet, enemy *objects.BattlePet) []*objects.AttackInfo {
 	return []*objects.AttackInfo{
 		{
@@ -31,3 +33,19 @@ func (b *Bite) Speed() int {
 func (b *Bite) Cost() int {
 	return 0
 }
+
+func (b *Bite) CoolDown() int {
+	return 0
+}
+
+func (b *Bite) MaxUses() int {
+	return 0
+}
+
+func (b *Bite) Target() objects.SkillTarget {
+	return objects.TargetEnemy
+}
+
+func (b *Bite) Accuracy() int {
+	return 100
+}
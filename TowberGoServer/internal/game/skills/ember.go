@@ -0,0 +1,53 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// Ember 火花，命中后使对方灼烧
+type Ember struct{}
+
+func (e *Ember) Name() string {
+	return "Ember"
+}
+
+func (e *Ember) ID() uint32 {
+	return 9
+}
+
+func (e *Ember) Element() objects.Element {
+	return objects.ElementFire
+}
+
+func (e *Ember) Use(self *objects.BattlePet, enemy *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			PhysicalDamage: 0,
+			MagicDamage:    40,
+			Skill:          e.ID(),
+			BuffDamage:     []*objects.BuffDamage{{ID: 2, Level: 1}},
+		},
+	}
+}
+
+func (e *Ember) Speed() int {
+	return 1
+}
+
+func (e *Ember) Accuracy() int {
+	return 95
+}
+
+func (e *Ember) Cost() int {
+	return 10
+}
+
+func (e *Ember) CoolDown() int {
+	return 0
+}
+
+func (e *Ember) MaxUses() int {
+	return 0
+}
+
+func (e *Ember) Target() objects.SkillTarget {
+	return objects.TargetEnemy
+}
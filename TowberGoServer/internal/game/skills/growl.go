@@ -0,0 +1,51 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// Growl 低吼，降低对方的攻击
+type Growl struct{}
+
+func (g *Growl) Name() string {
+	return "Growl"
+}
+
+func (g *Growl) ID() uint32 {
+	return 11
+}
+
+func (g *Growl) Element() objects.Element {
+	return objects.ElementNormal
+}
+
+func (g *Growl) Use(self *objects.BattlePet, enemy *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			Skill:      g.ID(),
+			BuffDamage: []*objects.BuffDamage{{ID: 5, Level: 1}},
+		},
+	}
+}
+
+func (g *Growl) Speed() int {
+	return 2
+}
+
+func (g *Growl) Accuracy() int {
+	return 100
+}
+
+func (g *Growl) Cost() int {
+	return 5
+}
+
+func (g *Growl) CoolDown() int {
+	return 0
+}
+
+func (g *Growl) MaxUses() int {
+	return 0
+}
+
+func (g *Growl) Target() objects.SkillTarget {
+	return objects.TargetEnemy
+}
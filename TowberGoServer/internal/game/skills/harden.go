@@ -0,0 +1,51 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// Harden 硬化，为自身附加吸收伤害的护盾
+type Harden struct{}
+
+func (h *Harden) Name() string {
+	return "Harden"
+}
+
+func (h *Harden) ID() uint32 {
+	return 12
+}
+
+func (h *Harden) Element() objects.Element {
+	return objects.ElementEarth
+}
+
+func (h *Harden) Use(self *objects.BattlePet, target *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			Skill:      h.ID(),
+			BuffDamage: []*objects.BuffDamage{{ID: 6, Level: 3, Self: true}},
+		},
+	}
+}
+
+func (h *Harden) Speed() int {
+	return 2
+}
+
+func (h *Harden) Accuracy() int {
+	return 100
+}
+
+func (h *Harden) Cost() int {
+	return 8
+}
+
+func (h *Harden) CoolDown() int {
+	return 2
+}
+
+func (h *Harden) MaxUses() int {
+	return 0
+}
+
+func (h *Harden) Target() objects.SkillTarget {
+	return objects.TargetSelf
+}
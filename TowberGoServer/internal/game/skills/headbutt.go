@@ -0,0 +1,52 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// Headbutt 头槌，只能通过技能书学会
+type Headbutt struct{}
+
+func (h *Headbutt) Name() string {
+	return "Headbutt"
+}
+
+func (h *Headbutt) ID() uint32 {
+	return 8
+}
+
+func (h *Headbutt) Element() objects.Element {
+	return objects.ElementNormal
+}
+
+func (h *Headbutt) Use(self *objects.BattlePet, enemy *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			PhysicalDamage: 70,
+			MagicDamage:    0,
+			Skill:          h.ID(),
+		},
+	}
+}
+
+func (h *Headbutt) Speed() int {
+	return 1
+}
+
+func (h *Headbutt) Accuracy() int {
+	return 90
+}
+
+func (h *Headbutt) Cost() int {
+	return 5
+}
+
+func (h *Headbutt) CoolDown() int {
+	return 0
+}
+
+func (h *Headbutt) MaxUses() int {
+	return 0
+}
+
+func (h *Headbutt) Target() objects.SkillTarget {
+	return objects.TargetEnemy
+}
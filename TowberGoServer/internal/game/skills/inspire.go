@@ -0,0 +1,51 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// Inspire 鼓舞，为一只己方宠物恢复魔力，双打时可以对队友使用
+type Inspire struct{}
+
+func (i *Inspire) Name() string {
+	return "Inspire"
+}
+
+func (i *Inspire) ID() uint32 {
+	return 6
+}
+
+func (i *Inspire) Element() objects.Element {
+	return objects.ElementNormal
+}
+
+func (i *Inspire) Use(self *objects.BattlePet, target *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			Skill:       i.ID(),
+			ManaRestore: 30,
+		},
+	}
+}
+
+func (i *Inspire) Speed() int {
+	return 1
+}
+
+func (i *Inspire) Accuracy() int {
+	return 100
+}
+
+func (i *Inspire) Cost() int {
+	return 0
+}
+
+func (i *Inspire) CoolDown() int {
+	return 3
+}
+
+func (i *Inspire) MaxUses() int {
+	return 0
+}
+
+func (i *Inspire) Target() objects.SkillTarget {
+	return objects.TargetAlly
+}
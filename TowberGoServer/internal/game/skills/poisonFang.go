@@ -0,0 +1,53 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// PoisonFang 毒牙，命中后使对方中毒
+type PoisonFang struct{}
+
+func (p *PoisonFang) Name() string {
+	return "PoisonFang"
+}
+
+func (p *PoisonFang) ID() uint32 {
+	return 3
+}
+
+func (p *PoisonFang) Element() objects.Element {
+	return objects.ElementDark
+}
+
+func (p *PoisonFang) Use(self *objects.BattlePet, enemy *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			PhysicalDamage: 30,
+			MagicDamage:    0,
+			Skill:          p.ID(),
+			BuffDamage:     []*objects.BuffDamage{{ID: 1, Level: 1}},
+		},
+	}
+}
+
+func (p *PoisonFang) Speed() int {
+	return 1
+}
+
+func (p *PoisonFang) Accuracy() int {
+	return 95
+}
+
+func (p *PoisonFang) Cost() int {
+	return 8
+}
+
+func (p *PoisonFang) CoolDown() int {
+	return 0
+}
+
+func (p *PoisonFang) MaxUses() int {
+	return 0
+}
+
+func (p *PoisonFang) Target() objects.SkillTarget {
+	return objects.TargetEnemy
+}
@@ -0,0 +1,51 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// Recover 恢复，恢复自身40%的最大生命值
+type Recover struct{}
+
+func (r *Recover) Name() string {
+	return "Recover"
+}
+
+func (r *Recover) ID() uint32 {
+	return 5
+}
+
+func (r *Recover) Element() objects.Element {
+	return objects.ElementNormal
+}
+
+func (r *Recover) Use(self *objects.BattlePet, target *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			Skill: r.ID(),
+			Heal:  self.Stats().MaxHP * 40 / 100,
+		},
+	}
+}
+
+func (r *Recover) Speed() int {
+	return 1
+}
+
+func (r *Recover) Accuracy() int {
+	return 100
+}
+
+func (r *Recover) Cost() int {
+	return 15
+}
+
+func (r *Recover) CoolDown() int {
+	return 3
+}
+
+func (r *Recover) MaxUses() int {
+	return 0
+}
+
+func (r *Recover) Target() objects.SkillTarget {
+	return objects.TargetSelf
+}
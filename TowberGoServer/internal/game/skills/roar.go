@@ -0,0 +1,51 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// Roar 咆哮，提升自身的攻击
+type Roar struct{}
+
+func (r *Roar) Name() string {
+	return "Roar"
+}
+
+func (r *Roar) ID() uint32 {
+	return 4
+}
+
+func (r *Roar) Element() objects.Element {
+	return objects.ElementNormal
+}
+
+func (r *Roar) Use(self *objects.BattlePet, target *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			Skill:      r.ID(),
+			BuffDamage: []*objects.BuffDamage{{ID: 4, Level: 1, Self: true}},
+		},
+	}
+}
+
+func (r *Roar) Speed() int {
+	return 2
+}
+
+func (r *Roar) Accuracy() int {
+	return 100
+}
+
+func (r *Roar) Cost() int {
+	return 5
+}
+
+func (r *Roar) CoolDown() int {
+	return 2
+}
+
+func (r *Roar) MaxUses() int {
+	return 0
+}
+
+func (r *Roar) Target() objects.SkillTarget {
+	return objects.TargetSelf
+}
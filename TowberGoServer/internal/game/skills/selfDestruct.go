@@ -0,0 +1,52 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// SelfDestruct 自爆，对除自身以外所有在场的宠物造成大量伤害，释放后自身阵亡
+type SelfDestruct struct{}
+
+func (s *SelfDestruct) Name() string {
+	return "SelfDestruct"
+}
+
+func (s *SelfDestruct) ID() uint32 {
+	return 7
+}
+
+func (s *SelfDestruct) Element() objects.Element {
+	return objects.ElementNormal
+}
+
+func (s *SelfDestruct) Use(self *objects.BattlePet, target *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			PhysicalDamage: 150,
+			Skill:          s.ID(),
+			SelfDamage:     self.Stats().HP,
+		},
+	}
+}
+
+func (s *SelfDestruct) Speed() int {
+	return 1
+}
+
+func (s *SelfDestruct) Accuracy() int {
+	return 100
+}
+
+func (s *SelfDestruct) Cost() int {
+	return 0
+}
+
+func (s *SelfDestruct) CoolDown() int {
+	return 0
+}
+
+func (s *SelfDestruct) MaxUses() int {
+	return 1
+}
+
+func (s *SelfDestruct) Target() objects.SkillTarget {
+	return objects.TargetAll
+}
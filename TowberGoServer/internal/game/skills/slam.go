@@ -0,0 +1,53 @@
+package skills
+
+import "TowberGoServer/internal/game/objects"
+
+// Slam 猛撞，命中后使对方眩晕，命中率较低
+type Slam struct{}
+
+func (s *Slam) Name() string {
+	return "Slam"
+}
+
+func (s *Slam) ID() uint32 {
+	return 10
+}
+
+func (s *Slam) Element() objects.Element {
+	return objects.ElementNormal
+}
+
+func (s *Slam) Use(self *objects.BattlePet, enemy *objects.BattlePet) []*objects.AttackInfo {
+	return []*objects.AttackInfo{
+		{
+			PhysicalDamage: 40,
+			MagicDamage:    0,
+			Skill:          s.ID(),
+			BuffDamage:     []*objects.BuffDamage{{ID: 3, Level: 1}},
+		},
+	}
+}
+
+func (s *Slam) Speed() int {
+	return 1
+}
+
+func (s *Slam) Accuracy() int {
+	return 70
+}
+
+func (s *Slam) Cost() int {
+	return 15
+}
+
+func (s *Slam) CoolDown() int {
+	return 3
+}
+
+func (s *Slam) MaxUses() int {
+	return 0
+}
+
+func (s *Slam) Target() objects.SkillTarget {
+	return objects.TargetEnemy
+}
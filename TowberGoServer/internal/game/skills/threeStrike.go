@@ -12,6 +12,10 @@ func (t TripleStrike) ID() uint32 {
 	return 2
 }
 
+func (t TripleStrike) Element() objects.Element {
+	return objects.ElementEarth
+}
+
 func (t TripleStrike) Use(self *objects.BattlePet, enemy *objects.BattlePet) []*objects.AttackInfo {
 	return []*objects.AttackInfo{
 		{
@@ -39,3 +43,19 @@ func (t TripleStrike) Speed() int {
 func (t TripleStrike) Cost() int {
 	return 10
 }
+
+func (t TripleStrike) CoolDown() int {
+	return 1
+}
+
+func (t TripleStrike) MaxUses() int {
+	return 5
+}
+
+func (t TripleStrike) Target() objects.SkillTarget {
+	return objects.TargetEnemy
+}
+
+func (t TripleStrike) Accuracy() int {
+	return 90
+}
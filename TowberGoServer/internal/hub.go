@@ -62,6 +62,9 @@ type Hub struct {
 }
 
 func NewHub() *Hub {
+	if err := db.InitRedis(); err != nil {
+		log.Fatal(err)
+	}
 	database, err := db.NewDb()
 	if err != nil {
 		log.Fatal(err)
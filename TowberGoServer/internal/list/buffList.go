@@ -0,0 +1,15 @@
+package list
+
+import (
+	"TowberGoServer/internal/game/buffs"
+	"TowberGoServer/internal/game/objects"
+)
+
+var BuffList = map[int]objects.Buff{
+	1: &buffs.Poison{},
+	2: &buffs.Burn{},
+	3: &buffs.Stun{},
+	4: &buffs.AttackUp{},
+	5: &buffs.AttackDown{},
+	6: &buffs.Shield{},
+}
@@ -12,4 +12,8 @@ var ItemList = map[uint32]objects.Item{
 
 var PetItemList = map[uint32]objects.PetItem{
 	1: &petItems.OrangeSugar{},
+	2: petItems.NewSkillBook(2, 8, "HeadbuttBook"),
+	3: petItems.NewCaptureBall(3, "CaptureBall", 1),
+	4: petItems.NewCaptureBall(4, "GreatCaptureBall", 1.5),
+	5: petItems.NewSkillBook(5, 9, "EmberBook"),
 }
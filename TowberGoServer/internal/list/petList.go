@@ -7,4 +7,5 @@ import (
 
 var PetList = map[uint32]objects.Pet{
 	1: &pets.Buro{},
+	2: &pets.Thornback{},
 }
@@ -6,6 +6,16 @@ import (
 )
 
 var SkillsList = map[uint32]objects.Skill{
-	1: &skills.Bite{},
-	2: &skills.TripleStrike{},
+	1:  &skills.Bite{},
+	2:  &skills.TripleStrike{},
+	3:  &skills.PoisonFang{},
+	4:  &skills.Roar{},
+	5:  &skills.Recover{},
+	6:  &skills.Inspire{},
+	7:  &skills.SelfDestruct{},
+	8:  &skills.Headbutt{},
+	9:  &skills.Ember{},
+	10: &skills.Slam{},
+	11: &skills.Growl{},
+	12: &skills.Harden{},
 }
@@ -56,12 +56,25 @@ func (c *Connected) handleLoginRequest(senderID uint32, message *packets.Packet_
 		Uid:      userInfo.ID,
 	}})
 	c.client.Login(userInfo.ID)
-	// 转换状态
-	c.client.SetState(&InGame{Player: &objects.Player{
+	player := &objects.Player{
 		UserName:     userInfo.UserName,
 		UID:          userInfo.ID,
 		EquippedPets: [5]objects.Pet{},
-	}})
+	}
+	// 掉线前的战斗还在等待重连
+	if reconnect := objects.BattleManager.GetReconnect(userInfo.ID); reconnect != nil {
+		state := NewReconnectInBattle(player, reconnect)
+		c.client.SocketSend(&packets.Packet_StartBattle{StartBattle: &packets.StartBattleMessage{Number: int64(reconnect.Number)}})
+		c.client.SetState(state)
+		if reconnect.Reconnect(state) {
+			return
+		}
+		// 战斗已经结束
+		c.client.SetState(state.SavedState)
+		return
+	}
+	// 转换状态
+	c.client.SetState(&InGame{Player: player})
 }
 
 func (c *Connected) handleRegisterRequest(senderID uint32, message *packets.Packet_RegisterRequest) {
@@ -15,6 +15,25 @@ type InBattle struct {
 	currentPet  *objects.BattlePet
 	equippedPet [5]*objects.BattlePet
 	SavedState  internal.ClientStateHandler
+	// reconnect 重连时等待接管的战斗
+	reconnect *objects.ReconnectingBattlePlayer
+}
+
+// NewReconnectInBattle 玩家重连后接管掉线前的战斗，沿用战斗中的队伍
+func NewReconnectInBattle(player *objects.Player, reconnect *objects.ReconnectingBattlePlayer) *InBattle {
+	for i, v := range reconnect.EquippedPets() {
+		if v != nil {
+			player.EquippedPets[i] = v.Pet
+		}
+	}
+	return &InBattle{
+		Player:      player,
+		Num:         reconnect.Number,
+		currentPet:  reconnect.CurrentPet(),
+		equippedPet: reconnect.EquippedPets(),
+		SavedState:  &InGame{Player: player},
+		reconnect:   reconnect,
+	}
 }
 
 func (i *InBattle) Name() string {
@@ -28,6 +47,9 @@ func (i *InBattle) SetClient(client internal.ClientInterface) {
 func (i *InBattle) OnEnter() {
 	msg := packets.Packet_SyncState{SyncState: &packets.SyncState{State: 3}}
 	i.client.SocketSend(&msg)
+	if i.currentPet != nil {
+		return
+	}
 	i.Player.PetBagLock.RLock()
 	defer i.Player.PetBagLock.RUnlock()
 	for k, v := range i.Player.EquippedPets {
@@ -53,6 +75,9 @@ func (i *InBattle) ClearResources() {
 	if i.Player.Area != nil {
 		i.Player.Area.RemovePlayer(i.Player.UID)
 	}
+	if i.reconnect != nil && i.reconnect.Cancel(i) {
+		return
+	}
 	if i.BattleRoom != nil {
 		// 替换自动
 		i.BattleRoom.ReplacePlayerAuto(i.Num)
@@ -60,27 +85,36 @@ func (i *InBattle) ClearResources() {
 }
 
 func (i *InBattle) ProcessMessage(message packets.BattleMsg) {
+	// 重连后回到房间之前不处理客户端的指令
+	if i.BattleRoom == nil {
+		if _, ok := message.(*packets.BattlePacket_BattleEnd); !ok {
+			return
+		}
+	}
 	switch battleMsg := message.(type) {
 	case *packets.BattlePacket_Command:
 		command := objects.Command{
 			Msg:    battleMsg.Command,
 			Number: i.Num,
 		}
-		i.BattleRoom.CommandChan <- &command
+		i.BattleRoom.SendCommand(&command)
 	case *packets.BattlePacket_RoundConfirm:
-		i.BattleRoom.NextRoundChan <- i.Num
+		i.BattleRoom.SendReady(i.Num)
 	case *packets.BattlePacket_ChangePet:
-		i.BattleRoom.CommandChan <- &objects.Command{
+		i.BattleRoom.SendCommand(&objects.Command{
 			Msg: &packets.RoundCommandMessage{Command: &packets.RoundCommandMessage_ChangePet{
-				ChangePet: &packets.ChangePet{PetPosition: battleMsg.ChangePet.PetPosition}}},
+				ChangePet: &packets.ChangePet{PetPosition: battleMsg.ChangePet.PetPosition}}, Slot: battleMsg.ChangePet.Slot},
 			Number: i.Num,
-		}
+		})
 	case *packets.BattlePacket_BattleEnd:
 		fmt.Println(i.Player.UserName, "结束战斗")
 		i.client.SocketSend(&packets.Packet_BattlePacket{BattlePacket: &packets.BattlePacket{Msg: battleMsg}})
 		i.client.SetState(i.SavedState)
 	case *packets.BattlePacket_StartNextRound, *packets.BattlePacket_DenyCommand, *packets.BattlePacket_AttackStats,
-		*packets.BattlePacket_ChangePetRequest, *packets.BattlePacket_SyncBattleInformation, *packets.BattlePacket_RoundEnd:
+		*packets.BattlePacket_ChangePetRequest, *packets.BattlePacket_SyncBattleInformation, *packets.BattlePacket_RoundEnd,
+		*packets.BattlePacket_PlayerDisconnected, *packets.BattlePacket_PlayerReconnected, *packets.BattlePacket_CaptureResult,
+		*packets.BattlePacket_BattleEndStats, *packets.BattlePacket_BattleFormat, *packets.BattlePacket_PetSwitched,
+		*packets.BattlePacket_BattleSnapshot:
 		i.client.SocketSend(&packets.Packet_BattlePacket{BattlePacket: &packets.BattlePacket{Msg: battleMsg}})
 	}
 }
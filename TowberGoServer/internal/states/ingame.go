@@ -7,12 +7,17 @@ import (
 	"TowberGoServer/pkg/packets"
 	"TowberGoServer/pkg/utils"
 	"fmt"
+	"time"
 )
 
+// encounterCoolDown 战斗结束后一段时间内不会再次遭遇野生宠物
+const encounterCoolDown = 5 * time.Second
+
 type InGame struct {
 	Player     *objects.Player
 	client     internal.ClientInterface
 	hasEntered bool
+	lastBattle time.Time
 }
 
 func (g *InGame) Name() string {
@@ -30,13 +35,18 @@ func (g *InGame) OnEnter() {
 	msg := packets.Packet_SyncState{SyncState: &packets.SyncState{State: 2}}
 	g.client.SocketSend(&msg)
 	if !g.hasEntered {
-		g.Player.EquippedPets = objects.PetManager.GetPetBag(g.Player)
+		// 重连回到战斗的玩家沿用战斗中的宠物
+		if g.Player.EquippedPets == [5]objects.Pet{} {
+			g.Player.EquippedPets = objects.PetManager.GetPetBag(g.Player)
+		}
 		g.hasEntered = true
 		g.HandleMessage(0, &packets.Packet_PlayerEnterRequest{PlayerEnterRequest: &packets.PlayerEnterAreaRequestMessage{
 			AreaName:   "InitialVillage",
 			EntranceId: 0,
 		}})
 	} else {
+		// 从战斗中返回
+		g.lastBattle = time.Now()
 		rsp := utils.NewPlayerEnterAreaResponse(true, "", g.Player.Area.Name())
 		g.client.SocketSend(rsp)
 	}
@@ -83,6 +93,7 @@ func (g *InGame) HandleMessage(senderID uint32, message packets.Msg) {
 			Y: message.PlayerMovement.ToY,
 		}
 		g.Player.Area.ProcessMessage(g.Player, message)
+		g.handleEncounter()
 	case *packets.Packet_MailRequest:
 		g.handleMailRequest()
 	case *packets.Packet_MailDelete:
@@ -101,12 +112,28 @@ func (g *InGame) HandleMessage(senderID uint32, message packets.Msg) {
 		g.handlePetBagRequest()
 	case *packets.Packet_LearnSkillRequest:
 		g.handleLearnSkill(message.LearnSkillRequest)
+	case *packets.Packet_ForgetSkillRequest:
+		g.handleForgetSkill(message.ForgetSkillRequest)
 	case *packets.Packet_PetItemBagRequest:
 		g.handlePetItemBagRequest()
 	case *packets.Packet_UsePetItemRequest:
 		g.handleUsePetItemRequest(message.UsePetItemRequest)
 	case *packets.Packet_EquippedPetInfoRequest:
 		g.handleEquippedPetInfoRequest(message.EquippedPetInfoRequest.Id)
+	case *packets.Packet_ReplayListRequest:
+		g.client.SocketSend(&packets.Packet_ReplayListResponse{ReplayListResponse: &packets.ReplayListResponseMessage{
+			Replays: objects.ReplayManager.List(g.Player),
+		}})
+	case *packets.Packet_ReplayRequest:
+		g.handleReplayRequest(message.ReplayRequest.Id)
+	case *packets.Packet_RankListRequest:
+		g.handleRankList(message.RankListRequest)
+	case *packets.Packet_LeaderboardRequest:
+		g.handleLeaderboard(message.LeaderboardRequest)
+	case *packets.Packet_BattleHistoryRequest:
+		g.client.SocketSend(&packets.Packet_BattleHistoryResponse{BattleHistoryResponse: &packets.BattleHistoryResponseMessage{
+			Records: objects.RankManager.History(g.Player, int(message.BattleHistoryRequest.Count)),
+		}})
 	case *packets.Packet_StartBattle:
 		g.client.SocketSend(message)
 	case *packets.Packet_GetAreaRequest:
@@ -216,6 +243,10 @@ func (g *InGame) handlePetBagRequest() {
 			Speed:        int64(v.Stats().Speed),
 			Defense:      int64(v.Stats().Defense),
 		}
+		passives := make([]uint32, 0)
+		for _, b := range v.Passives() {
+			passives = append(passives, b.ID())
+		}
 		fmt.Println(v.Stats())
 		pets[i] = &packets.PetMessage{
 			PetId:          v.PetID(),
@@ -224,6 +255,8 @@ func (g *InGame) handlePetBagRequest() {
 			Level:          int64(v.Level()),
 			EquippedSkills: equippedSkills,
 			PetStats:       &stats,
+			Talent:         objects.NewPetTalentMessage(v.Talent()),
+			Passives:       passives,
 		}
 	}
 	response := packets.Packet_PetBagResponse{PetBagResponse: &packets.PetBagResponseMessage{Pet: pets}}
@@ -235,7 +268,7 @@ func (g *InGame) handleLearnSkill(msg *packets.LearnSkillRequestMessage) {
 	defer g.Player.PetBagLock.RUnlock()
 	rsp := &packets.LearnSkillResponseMessage{Success: true}
 	for i, v := range g.Player.EquippedPets {
-		if v.ID() == msg.PetId {
+		if v != nil && v.ID() == msg.PetId {
 			if err := objects.PetManager.LearnSkill(v, msg.GetSkillId(), int(msg.GetPosition())); err != nil {
 				rsp.Success = false
 				rsp.Reason = err.Error()
@@ -250,6 +283,23 @@ func (g *InGame) handleLearnSkill(msg *packets.LearnSkillRequestMessage) {
 	g.client.SocketSend(&packets.Packet_LearnSkillResponse{LearnSkillResponse: rsp})
 }
 
+func (g *InGame) handleForgetSkill(msg *packets.ForgetSkillRequestMessage) {
+	g.Player.PetBagLock.RLock()
+	defer g.Player.PetBagLock.RUnlock()
+	rsp := &packets.ForgetSkillResponseMessage{Success: false, Reason: "no such pet"}
+	for _, v := range g.Player.EquippedPets {
+		if v != nil && v.ID() == msg.PetId {
+			rsp.Success, rsp.Reason = true, ""
+			if err := objects.PetManager.ForgetSkill(v, int(msg.GetPosition())); err != nil {
+				rsp.Success = false
+				rsp.Reason = err.Error()
+			}
+			break
+		}
+	}
+	g.client.SocketSend(&packets.Packet_ForgetSkillResponse{ForgetSkillResponse: rsp})
+}
+
 // 发送宠物背包中的物品
 func (g *InGame) handlePetItemBagRequest() {
 	bags := objects.PetItemManager.GetBags(g.Player)
@@ -321,5 +371,94 @@ func (g *InGame) handleInitialPetRequest(msg *packets.InitialPetRequestMessage)
 		g.client.SocketSend(&packets.Packet_DenyResponse{DenyResponse: &packets.DenyResponseMessage{Reason: err.Error()}})
 		return
 	}
-	objects.PetManager.CreatePet(g.Player, msg.RequestId)
+	if _, _, err := objects.PetManager.CreatePet(g.Player, msg.RequestId, nil); err != nil {
+		objects.ItemManager.CompensateItem(g.Player, 1, 1)
+		g.client.SocketSend(&packets.Packet_DenyResponse{DenyResponse: &packets.DenyResponseMessage{Reason: err.Error()}})
+	}
+}
+
+// handleEncounter 玩家移动后检查是否遭遇野生宠物，遭遇后进入战斗
+func (g *InGame) handleEncounter() {
+	if time.Since(g.lastBattle) < encounterCoolDown {
+		return
+	}
+	// 没有存活的宠物时不会遭遇
+	alive := false
+	g.Player.PetBagLock.RLock()
+	for _, v := range g.Player.EquippedPets {
+		if v != nil && v.Stats().HP > 0 {
+			alive = true
+		}
+	}
+	g.Player.PetBagLock.RUnlock()
+	if !alive {
+		return
+	}
+	zone, entry, level := objects.RollEncounter(g.Player.Area, g.Player.Position)
+	if entry == nil {
+		return
+	}
+	wildPet := objects.PetManager.CreateWildPet(entry.PetID, level)
+	if wildPet == nil {
+		return
+	}
+	g.lastBattle = time.Now()
+
+	g.client.SocketSend(&packets.Packet_StartBattle{StartBattle: &packets.StartBattleMessage{Number: 0}})
+	state := &InBattle{Player: g.Player, Num: 0, SavedState: g}
+	g.client.SetState(state)
+	wild := objects.NewAIBattlePlayer(1, objects.AIEasy, wildPet.Name(), wildPet)
+	objects.BattleManager.CreateRoom([]objects.BattlePlayer{state, wild}, func(room *objects.BattleRoom) {
+		room.Looter = zone.Looter
+		room.Config.AllowCapture = true
+		room.Config.Timer = objects.WildBattleTimer
+	})
+}
+
+// handleReplayRequest 在服务器上重新播放回放，并以玩家在战斗中的视角发送消息流
+func (g *InGame) handleReplayRequest(id uint64) {
+	rsp := &packets.ReplayResponseMessage{Success: true}
+	replay, err := objects.ReplayManager.Get(g.Player, id)
+	if err != nil {
+		rsp.Success = false
+		rsp.Reason = err.Error()
+	} else {
+		number := 0
+		for i, v := range replay.Teams {
+			if v.PlayerName == g.Player.UserName {
+				number = i
+			}
+		}
+		rsp.Replay = replay
+		rsp.Packets = objects.ReplayManager.Play(replay, number)
+	}
+	g.client.SocketSend(&packets.Packet_ReplayResponse{ReplayResponse: rsp})
+}
+
+// handleRankList 查询当前赛季的排行榜，type为1时返回自己前后的玩家
+func (g *InGame) handleRankList(message *packets.RankListRequestMessage) {
+	rsp := &packets.RankListResponseMessage{Season: objects.RankManager.Season().ID}
+	if message.Type == 1 {
+		rsp.Entries, rsp.Self = objects.RankManager.AroundMe(g.Player, int(message.Count))
+	} else {
+		rsp.Entries = objects.RankManager.Leaderboard(int(message.Count))
+		_, rsp.Self = objects.RankManager.AroundMe(g.Player, 0)
+	}
+	g.client.SocketSend(&packets.Packet_RankListResponse{RankListResponse: rsp})
+}
+
+// handleLeaderboard 查询排行榜的一页以及自己的名次
+func (g *InGame) handleLeaderboard(message *packets.LeaderboardRequestMessage) {
+	board, window := objects.BoardType(message.Board), objects.BoardWindow(message.Window)
+	rsp := &packets.LeaderboardResponseMessage{Success: true, Board: message.Board, Window: message.Window, Page: message.Page}
+	entries, err := objects.LeaderboardManager.Page(board, window, int(message.Page), int(message.Size))
+	if err == nil {
+		rsp.Entries = entries
+		rsp.Self, err = objects.LeaderboardManager.Rank(g.Player, board, window)
+	}
+	if err != nil {
+		rsp.Success = false
+		rsp.Reason = err.Error()
+	}
+	g.client.SocketSend(&packets.Packet_LeaderboardResponse{LeaderboardResponse: rsp})
 }
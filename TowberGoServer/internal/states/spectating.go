@@ -0,0 +1,64 @@
+package states
+
+import (
+	"TowberGoServer/internal"
+	"TowberGoServer/internal/game/objects"
+	"TowberGoServer/pkg/packets"
+)
+
+// Spectating 观战状态，只接收战斗消息，客户端发来的战斗指令全部忽略
+type Spectating struct {
+	client     internal.ClientInterface
+	Player     *objects.Player
+	BattleRoom *objects.BattleRoom
+	SavedState internal.ClientStateHandler
+}
+
+func (s *Spectating) Name() string {
+	return "Spectating"
+}
+
+func (s *Spectating) SetClient(client internal.ClientInterface) {
+	s.client = client
+}
+
+func (s *Spectating) OnEnter() {
+	msg := packets.Packet_SyncState{SyncState: &packets.SyncState{State: 4}}
+	s.client.SocketSend(&msg)
+}
+
+func (s *Spectating) HandleMessage(senderID uint32, message packets.Msg) {
+	switch message.(type) {
+	case *packets.Packet_StopSpectate:
+		s.BattleRoom.RemoveSpectator(s.Player.UID)
+		s.client.SetState(s.SavedState)
+	case *packets.Packet_Chat:
+		if s.Player.Area != nil {
+			s.Player.Area.ProcessMessage(s.Player, message)
+		}
+	}
+}
+
+func (s *Spectating) OnExit() {}
+
+func (s *Spectating) ClearResources() {
+	s.BattleRoom.RemoveSpectator(s.Player.UID)
+	if s.Player.Area != nil {
+		s.Player.Area.RemovePlayer(s.Player.UID)
+	}
+	for _, v := range s.Player.EquippedPets {
+		objects.PetManager.SavePet(s.Player, v)
+	}
+}
+
+func (s *Spectating) SpectatorID() uint32 {
+	return s.Player.UID
+}
+
+// ProcessMessage 由战斗房间调用，将公开的战斗消息转发给客户端
+func (s *Spectating) ProcessMessage(message packets.BattleMsg) {
+	s.client.SocketSend(&packets.Packet_BattlePacket{BattlePacket: &packets.BattlePacket{Msg: message}})
+	if _, ok := message.(*packets.BattlePacket_BattleEnd); ok {
+		s.client.SetState(s.SavedState)
+	}
+}
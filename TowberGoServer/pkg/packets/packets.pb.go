@@ -1257,7 +1257,7 @@ func (*GetAreaRequest) Descriptor() ([]byte, []int) {
 // 同步客户端和服务器的状态
 type SyncState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	State         uint32                 `protobuf:"varint,1,opt,name=state,proto3" json:"state,omitempty"` // 1=Connect,2=InGame,3=InBattle
+	State         uint32                 `protobuf:"varint,1,opt,name=state,proto3" json:"state,omitempty"` // 1=Connect,2=InGame,3=InBattle,4=Spectating
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1589,6 +1589,8 @@ type PetMessage struct {
 	Level          int64                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"`
 	EquippedSkills []uint32               `protobuf:"varint,5,rep,packed,name=equipped_skills,json=equippedSkills,proto3" json:"equipped_skills,omitempty"`
 	PetStats       *PetStatsMessage       `protobuf:"bytes,6,opt,name=pet_stats,json=petStats,proto3" json:"pet_stats,omitempty"`
+	Talent         *PetTalentMessage      `protobuf:"bytes,7,opt,name=talent,proto3" json:"talent,omitempty"`
+	Passives       []uint32               `protobuf:"varint,8,rep,packed,name=passives,proto3" json:"passives,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -1665,6 +1667,113 @@ func (x *PetMessage) GetPetStats() *PetStatsMessage {
 	return nil
 }
 
+func (x *PetMessage) GetTalent() *PetTalentMessage {
+	if x != nil {
+		return x.Talent
+	}
+	return nil
+}
+
+func (x *PetMessage) GetPassives() []uint32 {
+	if x != nil {
+		return x.Passives
+	}
+	return nil
+}
+
+// 宠物个体天赋，nature为性格编号，其余为各项属性的个体值
+type PetTalentMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nature        uint32                 `protobuf:"varint,1,opt,name=nature,proto3" json:"nature,omitempty"`
+	Hp            int64                  `protobuf:"varint,2,opt,name=hp,proto3" json:"hp,omitempty"`
+	Mana          int64                  `protobuf:"varint,3,opt,name=mana,proto3" json:"mana,omitempty"`
+	Strength      int64                  `protobuf:"varint,4,opt,name=strength,proto3" json:"strength,omitempty"`
+	Intelligence  int64                  `protobuf:"varint,5,opt,name=intelligence,proto3" json:"intelligence,omitempty"`
+	Speed         int64                  `protobuf:"varint,6,opt,name=speed,proto3" json:"speed,omitempty"`
+	Defense       int64                  `protobuf:"varint,7,opt,name=defense,proto3" json:"defense,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PetTalentMessage) Reset() {
+	*x = PetTalentMessage{}
+	mi := &file_shared_packets_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PetTalentMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PetTalentMessage) ProtoMessage() {}
+
+func (x *PetTalentMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PetTalentMessage.ProtoReflect.Descriptor instead.
+func (*PetTalentMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PetTalentMessage) GetNature() uint32 {
+	if x != nil {
+		return x.Nature
+	}
+	return 0
+}
+
+func (x *PetTalentMessage) GetHp() int64 {
+	if x != nil {
+		return x.Hp
+	}
+	return 0
+}
+
+func (x *PetTalentMessage) GetMana() int64 {
+	if x != nil {
+		return x.Mana
+	}
+	return 0
+}
+
+func (x *PetTalentMessage) GetStrength() int64 {
+	if x != nil {
+		return x.Strength
+	}
+	return 0
+}
+
+func (x *PetTalentMessage) GetIntelligence() int64 {
+	if x != nil {
+		return x.Intelligence
+	}
+	return 0
+}
+
+func (x *PetTalentMessage) GetSpeed() int64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *PetTalentMessage) GetDefense() int64 {
+	if x != nil {
+		return x.Defense
+	}
+	return 0
+}
+
 type PetStatsMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MaxHp         int64                  `protobuf:"varint,1,opt,name=max_hp,json=maxHp,proto3" json:"max_hp,omitempty"`
@@ -1681,7 +1790,7 @@ type PetStatsMessage struct {
 
 func (x *PetStatsMessage) Reset() {
 	*x = PetStatsMessage{}
-	mi := &file_shared_packets_proto_msgTypes[32]
+	mi := &file_shared_packets_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1693,7 +1802,7 @@ func (x *PetStatsMessage) String() string {
 func (*PetStatsMessage) ProtoMessage() {}
 
 func (x *PetStatsMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[32]
+	mi := &file_shared_packets_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1706,7 +1815,7 @@ func (x *PetStatsMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PetStatsMessage.ProtoReflect.Descriptor instead.
 func (*PetStatsMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{32}
+	return file_shared_packets_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *PetStatsMessage) GetMaxHp() int64 {
@@ -1773,7 +1882,7 @@ type SavePetMessage struct {
 
 func (x *SavePetMessage) Reset() {
 	*x = SavePetMessage{}
-	mi := &file_shared_packets_proto_msgTypes[33]
+	mi := &file_shared_packets_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1785,7 +1894,7 @@ func (x *SavePetMessage) String() string {
 func (*SavePetMessage) ProtoMessage() {}
 
 func (x *SavePetMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[33]
+	mi := &file_shared_packets_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1798,7 +1907,7 @@ func (x *SavePetMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SavePetMessage.ProtoReflect.Descriptor instead.
 func (*SavePetMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{33}
+	return file_shared_packets_proto_rawDescGZIP(), []int{34}
 }
 
 type LearnSkillRequestMessage struct {
@@ -1812,7 +1921,7 @@ type LearnSkillRequestMessage struct {
 
 func (x *LearnSkillRequestMessage) Reset() {
 	*x = LearnSkillRequestMessage{}
-	mi := &file_shared_packets_proto_msgTypes[34]
+	mi := &file_shared_packets_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1824,7 +1933,7 @@ func (x *LearnSkillRequestMessage) String() string {
 func (*LearnSkillRequestMessage) ProtoMessage() {}
 
 func (x *LearnSkillRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[34]
+	mi := &file_shared_packets_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1837,7 +1946,7 @@ func (x *LearnSkillRequestMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LearnSkillRequestMessage.ProtoReflect.Descriptor instead.
 func (*LearnSkillRequestMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{34}
+	return file_shared_packets_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *LearnSkillRequestMessage) GetPosition() int64 {
@@ -1871,7 +1980,7 @@ type LearnSkillResponseMessage struct {
 
 func (x *LearnSkillResponseMessage) Reset() {
 	*x = LearnSkillResponseMessage{}
-	mi := &file_shared_packets_proto_msgTypes[35]
+	mi := &file_shared_packets_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1883,7 +1992,7 @@ func (x *LearnSkillResponseMessage) String() string {
 func (*LearnSkillResponseMessage) ProtoMessage() {}
 
 func (x *LearnSkillResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[35]
+	mi := &file_shared_packets_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1896,7 +2005,7 @@ func (x *LearnSkillResponseMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LearnSkillResponseMessage.ProtoReflect.Descriptor instead.
 func (*LearnSkillResponseMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{35}
+	return file_shared_packets_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *LearnSkillResponseMessage) GetSuccess() bool {
@@ -1913,6 +2022,110 @@ func (x *LearnSkillResponseMessage) GetReason() string {
 	return ""
 }
 
+type ForgetSkillRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      int64                  `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	PetId         uint64                 `protobuf:"varint,2,opt,name=pet_id,json=petId,proto3" json:"pet_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForgetSkillRequestMessage) Reset() {
+	*x = ForgetSkillRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForgetSkillRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForgetSkillRequestMessage) ProtoMessage() {}
+
+func (x *ForgetSkillRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForgetSkillRequestMessage.ProtoReflect.Descriptor instead.
+func (*ForgetSkillRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ForgetSkillRequestMessage) GetPosition() int64 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ForgetSkillRequestMessage) GetPetId() uint64 {
+	if x != nil {
+		return x.PetId
+	}
+	return 0
+}
+
+type ForgetSkillResponseMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForgetSkillResponseMessage) Reset() {
+	*x = ForgetSkillResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForgetSkillResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForgetSkillResponseMessage) ProtoMessage() {}
+
+func (x *ForgetSkillResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForgetSkillResponseMessage.ProtoReflect.Descriptor instead.
+func (*ForgetSkillResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ForgetSkillResponseMessage) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ForgetSkillResponseMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type EquippedPetInfoRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1922,7 +2135,7 @@ type EquippedPetInfoRequestMessage struct {
 
 func (x *EquippedPetInfoRequestMessage) Reset() {
 	*x = EquippedPetInfoRequestMessage{}
-	mi := &file_shared_packets_proto_msgTypes[36]
+	mi := &file_shared_packets_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1934,7 +2147,7 @@ func (x *EquippedPetInfoRequestMessage) String() string {
 func (*EquippedPetInfoRequestMessage) ProtoMessage() {}
 
 func (x *EquippedPetInfoRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[36]
+	mi := &file_shared_packets_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1947,7 +2160,7 @@ func (x *EquippedPetInfoRequestMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EquippedPetInfoRequestMessage.ProtoReflect.Descriptor instead.
 func (*EquippedPetInfoRequestMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{36}
+	return file_shared_packets_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *EquippedPetInfoRequestMessage) GetId() uint64 {
@@ -1967,7 +2180,7 @@ type EquippedPetInfoResponseMessage struct {
 
 func (x *EquippedPetInfoResponseMessage) Reset() {
 	*x = EquippedPetInfoResponseMessage{}
-	mi := &file_shared_packets_proto_msgTypes[37]
+	mi := &file_shared_packets_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1979,7 +2192,7 @@ func (x *EquippedPetInfoResponseMessage) String() string {
 func (*EquippedPetInfoResponseMessage) ProtoMessage() {}
 
 func (x *EquippedPetInfoResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[37]
+	mi := &file_shared_packets_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1992,7 +2205,7 @@ func (x *EquippedPetInfoResponseMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EquippedPetInfoResponseMessage.ProtoReflect.Descriptor instead.
 func (*EquippedPetInfoResponseMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{37}
+	return file_shared_packets_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *EquippedPetInfoResponseMessage) GetId() uint64 {
@@ -2021,7 +2234,7 @@ type AddPetItemMessage struct {
 
 func (x *AddPetItemMessage) Reset() {
 	*x = AddPetItemMessage{}
-	mi := &file_shared_packets_proto_msgTypes[38]
+	mi := &file_shared_packets_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2033,7 +2246,7 @@ func (x *AddPetItemMessage) String() string {
 func (*AddPetItemMessage) ProtoMessage() {}
 
 func (x *AddPetItemMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[38]
+	mi := &file_shared_packets_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2046,7 +2259,7 @@ func (x *AddPetItemMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddPetItemMessage.ProtoReflect.Descriptor instead.
 func (*AddPetItemMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{38}
+	return file_shared_packets_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *AddPetItemMessage) GetId() uint32 {
@@ -2080,7 +2293,7 @@ type DeletePetItemMessage struct {
 
 func (x *DeletePetItemMessage) Reset() {
 	*x = DeletePetItemMessage{}
-	mi := &file_shared_packets_proto_msgTypes[39]
+	mi := &file_shared_packets_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2092,7 +2305,7 @@ func (x *DeletePetItemMessage) String() string {
 func (*DeletePetItemMessage) ProtoMessage() {}
 
 func (x *DeletePetItemMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[39]
+	mi := &file_shared_packets_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2105,7 +2318,7 @@ func (x *DeletePetItemMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePetItemMessage.ProtoReflect.Descriptor instead.
 func (*DeletePetItemMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{39}
+	return file_shared_packets_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *DeletePetItemMessage) GetId() uint32 {
@@ -2132,7 +2345,7 @@ type PetItemMessage struct {
 
 func (x *PetItemMessage) Reset() {
 	*x = PetItemMessage{}
-	mi := &file_shared_packets_proto_msgTypes[40]
+	mi := &file_shared_packets_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2144,7 +2357,7 @@ func (x *PetItemMessage) String() string {
 func (*PetItemMessage) ProtoMessage() {}
 
 func (x *PetItemMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[40]
+	mi := &file_shared_packets_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2157,7 +2370,7 @@ func (x *PetItemMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PetItemMessage.ProtoReflect.Descriptor instead.
 func (*PetItemMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{40}
+	return file_shared_packets_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *PetItemMessage) GetId() uint32 {
@@ -2182,7 +2395,7 @@ type PetItemBagRequestMessage struct {
 
 func (x *PetItemBagRequestMessage) Reset() {
 	*x = PetItemBagRequestMessage{}
-	mi := &file_shared_packets_proto_msgTypes[41]
+	mi := &file_shared_packets_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2194,7 +2407,7 @@ func (x *PetItemBagRequestMessage) String() string {
 func (*PetItemBagRequestMessage) ProtoMessage() {}
 
 func (x *PetItemBagRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[41]
+	mi := &file_shared_packets_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2207,7 +2420,7 @@ func (x *PetItemBagRequestMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PetItemBagRequestMessage.ProtoReflect.Descriptor instead.
 func (*PetItemBagRequestMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{41}
+	return file_shared_packets_proto_rawDescGZIP(), []int{44}
 }
 
 type PetItemBagResponseMessage struct {
@@ -2220,7 +2433,7 @@ type PetItemBagResponseMessage struct {
 
 func (x *PetItemBagResponseMessage) Reset() {
 	*x = PetItemBagResponseMessage{}
-	mi := &file_shared_packets_proto_msgTypes[42]
+	mi := &file_shared_packets_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2232,7 +2445,7 @@ func (x *PetItemBagResponseMessage) String() string {
 func (*PetItemBagResponseMessage) ProtoMessage() {}
 
 func (x *PetItemBagResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[42]
+	mi := &file_shared_packets_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2245,7 +2458,7 @@ func (x *PetItemBagResponseMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PetItemBagResponseMessage.ProtoReflect.Descriptor instead.
 func (*PetItemBagResponseMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{42}
+	return file_shared_packets_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *PetItemBagResponseMessage) GetId() []uint32 {
@@ -2273,7 +2486,7 @@ type UsePetItemRequestMessage struct {
 
 func (x *UsePetItemRequestMessage) Reset() {
 	*x = UsePetItemRequestMessage{}
-	mi := &file_shared_packets_proto_msgTypes[43]
+	mi := &file_shared_packets_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2285,7 +2498,7 @@ func (x *UsePetItemRequestMessage) String() string {
 func (*UsePetItemRequestMessage) ProtoMessage() {}
 
 func (x *UsePetItemRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[43]
+	mi := &file_shared_packets_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2298,7 +2511,7 @@ func (x *UsePetItemRequestMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UsePetItemRequestMessage.ProtoReflect.Descriptor instead.
 func (*UsePetItemRequestMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{43}
+	return file_shared_packets_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *UsePetItemRequestMessage) GetId() uint32 {
@@ -2332,7 +2545,7 @@ type UsePetItemResponseMessage struct {
 
 func (x *UsePetItemResponseMessage) Reset() {
 	*x = UsePetItemResponseMessage{}
-	mi := &file_shared_packets_proto_msgTypes[44]
+	mi := &file_shared_packets_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2344,7 +2557,7 @@ func (x *UsePetItemResponseMessage) String() string {
 func (*UsePetItemResponseMessage) ProtoMessage() {}
 
 func (x *UsePetItemResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[44]
+	mi := &file_shared_packets_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2357,7 +2570,7 @@ func (x *UsePetItemResponseMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UsePetItemResponseMessage.ProtoReflect.Descriptor instead.
 func (*UsePetItemResponseMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{44}
+	return file_shared_packets_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *UsePetItemResponseMessage) GetSuccess() bool {
@@ -2378,13 +2591,15 @@ func (x *UsePetItemResponseMessage) GetReason() string {
 type BattleRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Target        uint32                 `protobuf:"varint,1,opt,name=target,proto3" json:"target,omitempty"`
+	Format        uint32                 `protobuf:"varint,2,opt,name=format,proto3" json:"format,omitempty"` // 0=单打，1=双打
+	Stake         []*WagerItemMessage    `protobuf:"bytes,3,rep,name=stake,proto3" json:"stake,omitempty"`    // 押注的物品，双方押上相同的物品，为空时不押注
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BattleRequestMessage) Reset() {
 	*x = BattleRequestMessage{}
-	mi := &file_shared_packets_proto_msgTypes[45]
+	mi := &file_shared_packets_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2396,7 +2611,7 @@ func (x *BattleRequestMessage) String() string {
 func (*BattleRequestMessage) ProtoMessage() {}
 
 func (x *BattleRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[45]
+	mi := &file_shared_packets_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2409,7 +2624,7 @@ func (x *BattleRequestMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BattleRequestMessage.ProtoReflect.Descriptor instead.
 func (*BattleRequestMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{45}
+	return file_shared_packets_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *BattleRequestMessage) GetTarget() uint32 {
@@ -2419,17 +2634,94 @@ func (x *BattleRequestMessage) GetTarget() uint32 {
 	return 0
 }
 
-type BattleInvitingMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomID        uint32                 `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
-	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
+func (x *BattleRequestMessage) GetFormat() uint32 {
+	if x != nil {
+		return x.Format
+	}
+	return 0
+}
+
+func (x *BattleRequestMessage) GetStake() []*WagerItemMessage {
+	if x != nil {
+		return x.Stake
+	}
+	return nil
+}
+
+// 押注的物品，type 1是宠物物品，2是普通物品
+type WagerItemMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Count         uint32                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Type          uint32                 `protobuf:"varint,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WagerItemMessage) Reset() {
+	*x = WagerItemMessage{}
+	mi := &file_shared_packets_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WagerItemMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WagerItemMessage) ProtoMessage() {}
+
+func (x *WagerItemMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WagerItemMessage.ProtoReflect.Descriptor instead.
+func (*WagerItemMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *WagerItemMessage) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WagerItemMessage) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *WagerItemMessage) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+type BattleInvitingMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomID        uint32                 `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	UserName      string                 `protobuf:"bytes,2,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Timeout       int64                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"` // 邀请的有效时间，单位为秒
+	Stake         []*WagerItemMessage    `protobuf:"bytes,4,rep,name=stake,proto3" json:"stake,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
 func (x *BattleInvitingMessage) Reset() {
 	*x = BattleInvitingMessage{}
-	mi := &file_shared_packets_proto_msgTypes[46]
+	mi := &file_shared_packets_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2441,7 +2733,7 @@ func (x *BattleInvitingMessage) String() string {
 func (*BattleInvitingMessage) ProtoMessage() {}
 
 func (x *BattleInvitingMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[46]
+	mi := &file_shared_packets_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2454,7 +2746,7 @@ func (x *BattleInvitingMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BattleInvitingMessage.ProtoReflect.Descriptor instead.
 func (*BattleInvitingMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{46}
+	return file_shared_packets_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *BattleInvitingMessage) GetRoomID() uint32 {
@@ -2471,29 +2763,46 @@ func (x *BattleInvitingMessage) GetUserName() string {
 	return ""
 }
 
-type BattleInvitingResponseMessage struct {
+func (x *BattleInvitingMessage) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *BattleInvitingMessage) GetStake() []*WagerItemMessage {
+	if x != nil {
+		return x.Stake
+	}
+	return nil
+}
+
+// 发起邀请的结果，成功时roomID用于取消邀请
+type BattleRequestResponseMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomID        uint32                 `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
-	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	RoomID        uint32                 `protobuf:"varint,3,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Timeout       int64                  `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleInvitingResponseMessage) Reset() {
-	*x = BattleInvitingResponseMessage{}
-	mi := &file_shared_packets_proto_msgTypes[47]
+func (x *BattleRequestResponseMessage) Reset() {
+	*x = BattleRequestResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleInvitingResponseMessage) String() string {
+func (x *BattleRequestResponseMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleInvitingResponseMessage) ProtoMessage() {}
+func (*BattleRequestResponseMessage) ProtoMessage() {}
 
-func (x *BattleInvitingResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[47]
+func (x *BattleRequestResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2504,47 +2813,62 @@ func (x *BattleInvitingResponseMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleInvitingResponseMessage.ProtoReflect.Descriptor instead.
-func (*BattleInvitingResponseMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use BattleRequestResponseMessage.ProtoReflect.Descriptor instead.
+func (*BattleRequestResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *BattleInvitingResponseMessage) GetRoomID() uint32 {
+func (x *BattleRequestResponseMessage) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BattleRequestResponseMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BattleRequestResponseMessage) GetRoomID() uint32 {
 	if x != nil {
 		return x.RoomID
 	}
 	return 0
 }
 
-func (x *BattleInvitingResponseMessage) GetAccepted() bool {
+func (x *BattleRequestResponseMessage) GetTimeout() int64 {
 	if x != nil {
-		return x.Accepted
+		return x.Timeout
 	}
-	return false
+	return 0
 }
 
-type StartBattleMessage struct {
+// 发起方取消邀请
+type CancelBattleInviteMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	RoomID        uint32                 `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartBattleMessage) Reset() {
-	*x = StartBattleMessage{}
-	mi := &file_shared_packets_proto_msgTypes[48]
+func (x *CancelBattleInviteMessage) Reset() {
+	*x = CancelBattleInviteMessage{}
+	mi := &file_shared_packets_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartBattleMessage) String() string {
+func (x *CancelBattleInviteMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartBattleMessage) ProtoMessage() {}
+func (*CancelBattleInviteMessage) ProtoMessage() {}
 
-func (x *StartBattleMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[48]
+func (x *CancelBattleInviteMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2555,91 +2879,43 @@ func (x *StartBattleMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartBattleMessage.ProtoReflect.Descriptor instead.
-func (*StartBattleMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{48}
+// Deprecated: Use CancelBattleInviteMessage.ProtoReflect.Descriptor instead.
+func (*CancelBattleInviteMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *StartBattleMessage) GetNumber() int64 {
+func (x *CancelBattleInviteMessage) GetRoomID() uint32 {
 	if x != nil {
-		return x.Number
+		return x.RoomID
 	}
 	return 0
 }
 
-// ---------------------------------------------------------------------
-type Packet struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Uid   uint32                 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*Packet_LoginRequest
-	//	*Packet_RegisterRequest
-	//	*Packet_OkResponse
-	//	*Packet_DenyResponse
-	//	*Packet_LoginSuccess
-	//	*Packet_PlayerEnter
-	//	*Packet_PlayerLeave
-	//	*Packet_PlayerMovement
-	//	*Packet_PlayerEnterRequest
-	//	*Packet_Chat
-	//	*Packet_PlayerEnterAreaResponse
-	//	*Packet_Mail
-	//	*Packet_MailRequest
-	//	*Packet_MailCollect
-	//	*Packet_MailDelete
-	//	*Packet_MailCollectResponse
-	//	*Packet_BagRequest
-	//	*Packet_Bag
-	//	*Packet_AddBagItem
-	//	*Packet_DeleteBagItem
-	//	*Packet_UseBagItemRequest
-	//	*Packet_UseBagItemResponse
-	//	*Packet_UiPacket
-	//	*Packet_GetPet
-	//	*Packet_PetBagRequest
-	//	*Packet_PetBagResponse
-	//	*Packet_SavePet
-	//	*Packet_LearnSkillRequest
-	//	*Packet_LearnSkillResponse
-	//	*Packet_AddPetItem
-	//	*Packet_DeletePetItem
-	//	*Packet_PetItemBagRequest
-	//	*Packet_UsePetItemRequest
-	//	*Packet_UsePetItemResponse
-	//	*Packet_PetItemBagResponse
-	//	*Packet_EquippedPetInfoRequest
-	//	*Packet_EquippedPetInfoResponse
-	//	*Packet_BattlePacket
-	//	*Packet_BattleRequest
-	//	*Packet_BattleInvitingResponse
-	//	*Packet_BattleInviting
-	//	*Packet_StartBattle
-	//	*Packet_GetAreaRequest
-	//	*Packet_SyncState
-	//	*Packet_GetAreaNpcs
-	//	*Packet_InteractNpcRequest
-	//	*Packet_NpcInteract
-	Msg           isPacket_Msg `protobuf_oneof:"msg"`
+// 邀请被拒绝、超时或者取消，user_name为另一方的名字
+type BattleInviteClosedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomID        uint32                 `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Reason        uint32                 `protobuf:"varint,2,opt,name=reason,proto3" json:"reason,omitempty"` // 1=拒绝，2=超时，3=取消，4=有一方不能开始战斗，5=有一方押注的物品不足
+	UserName      string                 `protobuf:"bytes,3,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) Reset() {
-	*x = Packet{}
-	mi := &file_shared_packets_proto_msgTypes[49]
+func (x *BattleInviteClosedMessage) Reset() {
+	*x = BattleInviteClosedMessage{}
+	mi := &file_shared_packets_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) String() string {
+func (x *BattleInviteClosedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Packet) ProtoMessage() {}
+func (*BattleInviteClosedMessage) ProtoMessage() {}
 
-func (x *Packet) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[49]
+func (x *BattleInviteClosedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2650,760 +2926,3500 @@ func (x *Packet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
-func (*Packet) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use BattleInviteClosedMessage.ProtoReflect.Descriptor instead.
+func (*BattleInviteClosedMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *Packet) GetUid() uint32 {
+func (x *BattleInviteClosedMessage) GetRoomID() uint32 {
 	if x != nil {
-		return x.Uid
+		return x.RoomID
 	}
 	return 0
 }
 
-func (x *Packet) GetMsg() isPacket_Msg {
+func (x *BattleInviteClosedMessage) GetReason() uint32 {
 	if x != nil {
-		return x.Msg
+		return x.Reason
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetLoginRequest() *LoginRequestMessage {
+func (x *BattleInviteClosedMessage) GetUserName() string {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_LoginRequest); ok {
-			return x.LoginRequest
-		}
+		return x.UserName
 	}
-	return nil
+	return ""
 }
 
-func (x *Packet) GetRegisterRequest() *RegisterRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_RegisterRequest); ok {
-			return x.RegisterRequest
-		}
-	}
-	return nil
+type BattleInvitingResponseMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomID        uint32                 `protobuf:"varint,1,opt,name=roomID,proto3" json:"roomID,omitempty"`
+	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetOkResponse() *OKResponseMessage {
+func (x *BattleInvitingResponseMessage) Reset() {
+	*x = BattleInvitingResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleInvitingResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleInvitingResponseMessage) ProtoMessage() {}
+
+func (x *BattleInvitingResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[54]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_OkResponse); ok {
-			return x.OkResponse
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetDenyResponse() *DenyResponseMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_DenyResponse); ok {
-			return x.DenyResponse
-		}
-	}
-	return nil
+// Deprecated: Use BattleInvitingResponseMessage.ProtoReflect.Descriptor instead.
+func (*BattleInvitingResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *Packet) GetLoginSuccess() *LoginSuccessMessage {
+func (x *BattleInvitingResponseMessage) GetRoomID() uint32 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_LoginSuccess); ok {
-			return x.LoginSuccess
-		}
+		return x.RoomID
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetPlayerEnter() *PlayerEnterAreaMessage {
+func (x *BattleInvitingResponseMessage) GetAccepted() bool {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerEnter); ok {
-			return x.PlayerEnter
-		}
+		return x.Accepted
 	}
-	return nil
+	return false
 }
 
-func (x *Packet) GetPlayerLeave() *PlayerLeaveAreaMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerLeave); ok {
-			return x.PlayerLeave
-		}
-	}
-	return nil
+type StartBattleMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetPlayerMovement() *PlayerMoveMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerMovement); ok {
-			return x.PlayerMovement
-		}
-	}
-	return nil
+func (x *StartBattleMessage) Reset() {
+	*x = StartBattleMessage{}
+	mi := &file_shared_packets_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetPlayerEnterRequest() *PlayerEnterAreaRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerEnterRequest); ok {
-			return x.PlayerEnterRequest
-		}
-	}
-	return nil
+func (x *StartBattleMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetChat() *ChatMessage {
+func (*StartBattleMessage) ProtoMessage() {}
+
+func (x *StartBattleMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[55]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Chat); ok {
-			return x.Chat
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetPlayerEnterAreaResponse() *PlayerEnterAreaResponseMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_PlayerEnterAreaResponse); ok {
-			return x.PlayerEnterAreaResponse
-		}
-	}
-	return nil
+// Deprecated: Use StartBattleMessage.ProtoReflect.Descriptor instead.
+func (*StartBattleMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *Packet) GetMail() *MailMessage {
+func (x *StartBattleMessage) GetNumber() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Mail); ok {
-			return x.Mail
-		}
+		return x.Number
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetMailRequest() *MailRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_MailRequest); ok {
-			return x.MailRequest
-		}
-	}
-	return nil
+// 观战区域内正在战斗的玩家
+type SpectateRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        uint32                 `protobuf:"varint,1,opt,name=target,proto3" json:"target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetMailCollect() *MailCollectMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_MailCollect); ok {
-			return x.MailCollect
-		}
-	}
-	return nil
+func (x *SpectateRequestMessage) Reset() {
+	*x = SpectateRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetMailDelete() *MailDeleteMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_MailDelete); ok {
-			return x.MailDelete
-		}
-	}
-	return nil
+func (x *SpectateRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetMailCollectResponse() *MailCollectResponseMessage {
+func (*SpectateRequestMessage) ProtoMessage() {}
+
+func (x *SpectateRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[56]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_MailCollectResponse); ok {
-			return x.MailCollectResponse
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetBagRequest() *BagRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_BagRequest); ok {
-			return x.BagRequest
-		}
-	}
-	return nil
+// Deprecated: Use SpectateRequestMessage.ProtoReflect.Descriptor instead.
+func (*SpectateRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *Packet) GetBag() *BagMessage {
+func (x *SpectateRequestMessage) GetTarget() uint32 {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_Bag); ok {
-			return x.Bag
-		}
+		return x.Target
 	}
-	return nil
+	return 0
 }
 
-func (x *Packet) GetAddBagItem() *AddBagItemMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_AddBagItem); ok {
-			return x.AddBagItem
-		}
-	}
-	return nil
+type SpectateResponseMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetDeleteBagItem() *DeleteBagItemMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_DeleteBagItem); ok {
-			return x.DeleteBagItem
-		}
-	}
-	return nil
+func (x *SpectateResponseMessage) Reset() {
+	*x = SpectateResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetUseBagItemRequest() *UseBagItemRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_UseBagItemRequest); ok {
-			return x.UseBagItemRequest
-		}
-	}
-	return nil
+func (x *SpectateResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetUseBagItemResponse() *UseBagItemResponseMessage {
+func (*SpectateResponseMessage) ProtoMessage() {}
+
+func (x *SpectateResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[57]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_UseBagItemResponse); ok {
-			return x.UseBagItemResponse
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetUiPacket() *UiPacket {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_UiPacket); ok {
-			return x.UiPacket
-		}
-	}
-	return nil
+// Deprecated: Use SpectateResponseMessage.ProtoReflect.Descriptor instead.
+func (*SpectateResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *Packet) GetGetPet() *GetPetMessage {
+func (x *SpectateResponseMessage) GetSuccess() bool {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_GetPet); ok {
-			return x.GetPet
-		}
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *Packet) GetPetBagRequest() *PetBagRequestMessage {
+func (x *SpectateResponseMessage) GetReason() string {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_PetBagRequest); ok {
-			return x.PetBagRequest
-		}
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-func (x *Packet) GetPetBagResponse() *PetBagResponseMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_PetBagResponse); ok {
-			return x.PetBagResponse
-		}
-	}
-	return nil
+type StopSpectateMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetSavePet() *SavePetMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_SavePet); ok {
-			return x.SavePet
-		}
-	}
-	return nil
+func (x *StopSpectateMessage) Reset() {
+	*x = StopSpectateMessage{}
+	mi := &file_shared_packets_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetLearnSkillRequest() *LearnSkillRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_LearnSkillRequest); ok {
-			return x.LearnSkillRequest
-		}
-	}
-	return nil
+func (x *StopSpectateMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetLearnSkillResponse() *LearnSkillResponseMessage {
+func (*StopSpectateMessage) ProtoMessage() {}
+
+func (x *StopSpectateMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[58]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_LearnSkillResponse); ok {
-			return x.LearnSkillResponse
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetAddPetItem() *AddPetItemMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_AddPetItem); ok {
-			return x.AddPetItem
-		}
-	}
-	return nil
+// Deprecated: Use StopSpectateMessage.ProtoReflect.Descriptor instead.
+func (*StopSpectateMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *Packet) GetDeletePetItem() *DeletePetItemMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_DeletePetItem); ok {
-			return x.DeletePetItem
-		}
-	}
-	return nil
+// ---------------------------------匹配--------------------------------
+type JoinMatchmakingMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetPetItemBagRequest() *PetItemBagRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_PetItemBagRequest); ok {
-			return x.PetItemBagRequest
-		}
-	}
-	return nil
+func (x *JoinMatchmakingMessage) Reset() {
+	*x = JoinMatchmakingMessage{}
+	mi := &file_shared_packets_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetUsePetItemRequest() *UsePetItemRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_UsePetItemRequest); ok {
-			return x.UsePetItemRequest
-		}
-	}
-	return nil
+func (x *JoinMatchmakingMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetUsePetItemResponse() *UsePetItemResponseMessage {
+func (*JoinMatchmakingMessage) ProtoMessage() {}
+
+func (x *JoinMatchmakingMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[59]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_UsePetItemResponse); ok {
-			return x.UsePetItemResponse
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetPetItemBagResponse() *PetItemBagResponseMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_PetItemBagResponse); ok {
-			return x.PetItemBagResponse
-		}
-	}
-	return nil
+// Deprecated: Use JoinMatchmakingMessage.ProtoReflect.Descriptor instead.
+func (*JoinMatchmakingMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *Packet) GetEquippedPetInfoRequest() *EquippedPetInfoRequestMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_EquippedPetInfoRequest); ok {
-			return x.EquippedPetInfoRequest
-		}
-	}
-	return nil
+type LeaveMatchmakingMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetEquippedPetInfoResponse() *EquippedPetInfoResponseMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_EquippedPetInfoResponse); ok {
-			return x.EquippedPetInfoResponse
-		}
-	}
-	return nil
+func (x *LeaveMatchmakingMessage) Reset() {
+	*x = LeaveMatchmakingMessage{}
+	mi := &file_shared_packets_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetBattlePacket() *BattlePacket {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_BattlePacket); ok {
-			return x.BattlePacket
-		}
-	}
-	return nil
+func (x *LeaveMatchmakingMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetBattleRequest() *BattleRequestMessage {
+func (*LeaveMatchmakingMessage) ProtoMessage() {}
+
+func (x *LeaveMatchmakingMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[60]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_BattleRequest); ok {
-			return x.BattleRequest
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetBattleInvitingResponse() *BattleInvitingResponseMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_BattleInvitingResponse); ok {
-			return x.BattleInvitingResponse
-		}
-	}
-	return nil
+// Deprecated: Use LeaveMatchmakingMessage.ProtoReflect.Descriptor instead.
+func (*LeaveMatchmakingMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *Packet) GetBattleInviting() *BattleInvitingMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_BattleInviting); ok {
-			return x.BattleInviting
-		}
-	}
-	return nil
+// 加入或离开匹配队列的结果，queued为当前是否在队列中
+type MatchmakingResponseMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Queued        bool                   `protobuf:"varint,3,opt,name=queued,proto3" json:"queued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Packet) GetStartBattle() *StartBattleMessage {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_StartBattle); ok {
-			return x.StartBattle
-		}
-	}
-	return nil
+func (x *MatchmakingResponseMessage) Reset() {
+	*x = MatchmakingResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Packet) GetGetAreaRequest() *GetAreaRequest {
-	if x != nil {
-		if x, ok := x.Msg.(*Packet_GetAreaRequest); ok {
-			return x.GetAreaRequest
-		}
-	}
-	return nil
+func (x *MatchmakingResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Packet) GetSyncState() *SyncState {
+func (*MatchmakingResponseMessage) ProtoMessage() {}
+
+func (x *MatchmakingResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[61]
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_SyncState); ok {
-			return x.SyncState
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Packet) GetGetAreaNpcs() *GetAreaNPCsMessage {
+// Deprecated: Use MatchmakingResponseMessage.ProtoReflect.Descriptor instead.
+func (*MatchmakingResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *MatchmakingResponseMessage) GetSuccess() bool {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_GetAreaNpcs); ok {
-			return x.GetAreaNpcs
-		}
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *Packet) GetInteractNpcRequest() *InteractNPCRequestMessage {
+func (x *MatchmakingResponseMessage) GetReason() string {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_InteractNpcRequest); ok {
-			return x.InteractNpcRequest
-		}
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-func (x *Packet) GetNpcInteract() *NPCInteractPacket {
+func (x *MatchmakingResponseMessage) GetQueued() bool {
 	if x != nil {
-		if x, ok := x.Msg.(*Packet_NpcInteract); ok {
-			return x.NpcInteract
-		}
+		return x.Queued
 	}
-	return nil
+	return false
 }
 
-type isPacket_Msg interface {
-	isPacket_Msg()
+// 匹配成功，需要在timeout秒内确认
+type MatchFoundMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       uint32                 `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Opponent      string                 `protobuf:"bytes,2,opt,name=opponent,proto3" json:"opponent,omitempty"`
+	Timeout       int64                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Packet_LoginRequest struct {
-	LoginRequest *LoginRequestMessage `protobuf:"bytes,2,opt,name=login_request,json=loginRequest,proto3,oneof"`
+func (x *MatchFoundMessage) Reset() {
+	*x = MatchFoundMessage{}
+	mi := &file_shared_packets_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Packet_RegisterRequest struct {
-	RegisterRequest *RegisterRequestMessage `protobuf:"bytes,3,opt,name=register_request,json=registerRequest,proto3,oneof"`
+func (x *MatchFoundMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Packet_OkResponse struct {
-	OkResponse *OKResponseMessage `protobuf:"bytes,4,opt,name=ok_response,json=okResponse,proto3,oneof"`
-}
+func (*MatchFoundMessage) ProtoMessage() {}
 
-type Packet_DenyResponse struct {
-	DenyResponse *DenyResponseMessage `protobuf:"bytes,5,opt,name=deny_response,json=denyResponse,proto3,oneof"`
+func (x *MatchFoundMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Packet_LoginSuccess struct {
-	LoginSuccess *LoginSuccessMessage `protobuf:"bytes,6,opt,name=login_success,json=loginSuccess,proto3,oneof"`
+// Deprecated: Use MatchFoundMessage.ProtoReflect.Descriptor instead.
+func (*MatchFoundMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{62}
 }
 
-type Packet_PlayerEnter struct {
-	PlayerEnter *PlayerEnterAreaMessage `protobuf:"bytes,7,opt,name=player_enter,json=playerEnter,proto3,oneof"`
+func (x *MatchFoundMessage) GetMatchId() uint32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
 }
 
-type Packet_PlayerLeave struct {
-	PlayerLeave *PlayerLeaveAreaMessage `protobuf:"bytes,8,opt,name=player_leave,json=playerLeave,proto3,oneof"`
+func (x *MatchFoundMessage) GetOpponent() string {
+	if x != nil {
+		return x.Opponent
+	}
+	return ""
 }
 
-type Packet_PlayerMovement struct {
-	PlayerMovement *PlayerMoveMessage `protobuf:"bytes,9,opt,name=player_movement,json=playerMovement,proto3,oneof"`
+func (x *MatchFoundMessage) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
 }
 
-type Packet_PlayerEnterRequest struct {
-	PlayerEnterRequest *PlayerEnterAreaRequestMessage `protobuf:"bytes,10,opt,name=player_enter_request,json=playerEnterRequest,proto3,oneof"`
+type MatchReadyMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       uint32                 `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Packet_Chat struct {
-	Chat *ChatMessage `protobuf:"bytes,11,opt,name=chat,proto3,oneof"`
+func (x *MatchReadyMessage) Reset() {
+	*x = MatchReadyMessage{}
+	mi := &file_shared_packets_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Packet_PlayerEnterAreaResponse struct {
-	PlayerEnterAreaResponse *PlayerEnterAreaResponseMessage `protobuf:"bytes,12,opt,name=player_enter_area_response,json=playerEnterAreaResponse,proto3,oneof"`
+func (x *MatchReadyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Packet_Mail struct {
-	Mail *MailMessage `protobuf:"bytes,13,opt,name=mail,proto3,oneof"`
+func (*MatchReadyMessage) ProtoMessage() {}
+
+func (x *MatchReadyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Packet_MailRequest struct {
-	MailRequest *MailRequestMessage `protobuf:"bytes,14,opt,name=mail_request,json=mailRequest,proto3,oneof"`
+// Deprecated: Use MatchReadyMessage.ProtoReflect.Descriptor instead.
+func (*MatchReadyMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{63}
 }
 
-type Packet_MailCollect struct {
-	MailCollect *MailCollectMessage `protobuf:"bytes,15,opt,name=mail_collect,json=mailCollect,proto3,oneof"`
+func (x *MatchReadyMessage) GetMatchId() uint32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
 }
 
-type Packet_MailDelete struct {
-	MailDelete *MailDeleteMessage `protobuf:"bytes,16,opt,name=mail_delete,json=mailDelete,proto3,oneof"`
+func (x *MatchReadyMessage) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
 }
 
-type Packet_MailCollectResponse struct {
-	MailCollectResponse *MailCollectResponseMessage `protobuf:"bytes,17,opt,name=mail_collect_response,json=mailCollectResponse,proto3,oneof"`
+// 对方拒绝或者确认超时，requeued为是否已经重新加入队列
+type MatchCancelledMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MatchId       uint32                 `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Requeued      bool                   `protobuf:"varint,3,opt,name=requeued,proto3" json:"requeued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Packet_BagRequest struct {
-	BagRequest *BagRequestMessage `protobuf:"bytes,18,opt,name=bag_request,json=bagRequest,proto3,oneof"`
+func (x *MatchCancelledMessage) Reset() {
+	*x = MatchCancelledMessage{}
+	mi := &file_shared_packets_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Packet_Bag struct {
-	Bag *BagMessage `protobuf:"bytes,19,opt,name=bag,proto3,oneof"`
+func (x *MatchCancelledMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchCancelledMessage) ProtoMessage() {}
+
+func (x *MatchCancelledMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchCancelledMessage.ProtoReflect.Descriptor instead.
+func (*MatchCancelledMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *MatchCancelledMessage) GetMatchId() uint32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *MatchCancelledMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MatchCancelledMessage) GetRequeued() bool {
+	if x != nil {
+		return x.Requeued
+	}
+	return false
+}
+
+// ---------------------------------------------------------------------
+type Packet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uid   uint32                 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*Packet_LoginRequest
+	//	*Packet_RegisterRequest
+	//	*Packet_OkResponse
+	//	*Packet_DenyResponse
+	//	*Packet_LoginSuccess
+	//	*Packet_PlayerEnter
+	//	*Packet_PlayerLeave
+	//	*Packet_PlayerMovement
+	//	*Packet_PlayerEnterRequest
+	//	*Packet_Chat
+	//	*Packet_PlayerEnterAreaResponse
+	//	*Packet_Mail
+	//	*Packet_MailRequest
+	//	*Packet_MailCollect
+	//	*Packet_MailDelete
+	//	*Packet_MailCollectResponse
+	//	*Packet_BagRequest
+	//	*Packet_Bag
+	//	*Packet_AddBagItem
+	//	*Packet_DeleteBagItem
+	//	*Packet_UseBagItemRequest
+	//	*Packet_UseBagItemResponse
+	//	*Packet_UiPacket
+	//	*Packet_GetPet
+	//	*Packet_PetBagRequest
+	//	*Packet_PetBagResponse
+	//	*Packet_SavePet
+	//	*Packet_LearnSkillRequest
+	//	*Packet_LearnSkillResponse
+	//	*Packet_AddPetItem
+	//	*Packet_DeletePetItem
+	//	*Packet_PetItemBagRequest
+	//	*Packet_UsePetItemRequest
+	//	*Packet_UsePetItemResponse
+	//	*Packet_PetItemBagResponse
+	//	*Packet_EquippedPetInfoRequest
+	//	*Packet_EquippedPetInfoResponse
+	//	*Packet_BattlePacket
+	//	*Packet_BattleRequest
+	//	*Packet_BattleInvitingResponse
+	//	*Packet_BattleInviting
+	//	*Packet_StartBattle
+	//	*Packet_GetAreaRequest
+	//	*Packet_SyncState
+	//	*Packet_GetAreaNpcs
+	//	*Packet_InteractNpcRequest
+	//	*Packet_NpcInteract
+	//	*Packet_ForgetSkillRequest
+	//	*Packet_ForgetSkillResponse
+	//	*Packet_ReplayListRequest
+	//	*Packet_ReplayListResponse
+	//	*Packet_ReplayRequest
+	//	*Packet_ReplayResponse
+	//	*Packet_SpectateRequest
+	//	*Packet_SpectateResponse
+	//	*Packet_StopSpectate
+	//	*Packet_JoinMatchmaking
+	//	*Packet_LeaveMatchmaking
+	//	*Packet_MatchmakingResponse
+	//	*Packet_MatchFound
+	//	*Packet_MatchReady
+	//	*Packet_MatchCancelled
+	//	*Packet_RankListRequest
+	//	*Packet_RankListResponse
+	//	*Packet_BattleHistoryRequest
+	//	*Packet_BattleHistoryResponse
+	//	*Packet_LeaderboardRequest
+	//	*Packet_LeaderboardResponse
+	//	*Packet_BattleRequestResponse
+	//	*Packet_CancelBattleInvite
+	//	*Packet_BattleInviteClosed
+	Msg           isPacket_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Packet) Reset() {
+	*x = Packet{}
+	mi := &file_shared_packets_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Packet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Packet) ProtoMessage() {}
+
+func (x *Packet) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Packet.ProtoReflect.Descriptor instead.
+func (*Packet) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *Packet) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Packet) GetMsg() isPacket_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *Packet) GetLoginRequest() *LoginRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LoginRequest); ok {
+			return x.LoginRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRegisterRequest() *RegisterRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RegisterRequest); ok {
+			return x.RegisterRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetOkResponse() *OKResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_OkResponse); ok {
+			return x.OkResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDenyResponse() *DenyResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_DenyResponse); ok {
+			return x.DenyResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLoginSuccess() *LoginSuccessMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LoginSuccess); ok {
+			return x.LoginSuccess
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerEnter() *PlayerEnterAreaMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerEnter); ok {
+			return x.PlayerEnter
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerLeave() *PlayerLeaveAreaMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerLeave); ok {
+			return x.PlayerLeave
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerMovement() *PlayerMoveMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerMovement); ok {
+			return x.PlayerMovement
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerEnterRequest() *PlayerEnterAreaRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerEnterRequest); ok {
+			return x.PlayerEnterRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetChat() *ChatMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Chat); ok {
+			return x.Chat
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPlayerEnterAreaResponse() *PlayerEnterAreaResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PlayerEnterAreaResponse); ok {
+			return x.PlayerEnterAreaResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMail() *MailMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Mail); ok {
+			return x.Mail
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMailRequest() *MailRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_MailRequest); ok {
+			return x.MailRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMailCollect() *MailCollectMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_MailCollect); ok {
+			return x.MailCollect
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMailDelete() *MailDeleteMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_MailDelete); ok {
+			return x.MailDelete
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMailCollectResponse() *MailCollectResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_MailCollectResponse); ok {
+			return x.MailCollectResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBagRequest() *BagRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BagRequest); ok {
+			return x.BagRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBag() *BagMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_Bag); ok {
+			return x.Bag
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetAddBagItem() *AddBagItemMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_AddBagItem); ok {
+			return x.AddBagItem
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDeleteBagItem() *DeleteBagItemMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_DeleteBagItem); ok {
+			return x.DeleteBagItem
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetUseBagItemRequest() *UseBagItemRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_UseBagItemRequest); ok {
+			return x.UseBagItemRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetUseBagItemResponse() *UseBagItemResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_UseBagItemResponse); ok {
+			return x.UseBagItemResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetUiPacket() *UiPacket {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_UiPacket); ok {
+			return x.UiPacket
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetGetPet() *GetPetMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_GetPet); ok {
+			return x.GetPet
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPetBagRequest() *PetBagRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PetBagRequest); ok {
+			return x.PetBagRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPetBagResponse() *PetBagResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PetBagResponse); ok {
+			return x.PetBagResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSavePet() *SavePetMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SavePet); ok {
+			return x.SavePet
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLearnSkillRequest() *LearnSkillRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LearnSkillRequest); ok {
+			return x.LearnSkillRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLearnSkillResponse() *LearnSkillResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LearnSkillResponse); ok {
+			return x.LearnSkillResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetAddPetItem() *AddPetItemMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_AddPetItem); ok {
+			return x.AddPetItem
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetDeletePetItem() *DeletePetItemMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_DeletePetItem); ok {
+			return x.DeletePetItem
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPetItemBagRequest() *PetItemBagRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PetItemBagRequest); ok {
+			return x.PetItemBagRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetUsePetItemRequest() *UsePetItemRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_UsePetItemRequest); ok {
+			return x.UsePetItemRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetUsePetItemResponse() *UsePetItemResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_UsePetItemResponse); ok {
+			return x.UsePetItemResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetPetItemBagResponse() *PetItemBagResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_PetItemBagResponse); ok {
+			return x.PetItemBagResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetEquippedPetInfoRequest() *EquippedPetInfoRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_EquippedPetInfoRequest); ok {
+			return x.EquippedPetInfoRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetEquippedPetInfoResponse() *EquippedPetInfoResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_EquippedPetInfoResponse); ok {
+			return x.EquippedPetInfoResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBattlePacket() *BattlePacket {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BattlePacket); ok {
+			return x.BattlePacket
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBattleRequest() *BattleRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BattleRequest); ok {
+			return x.BattleRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBattleInvitingResponse() *BattleInvitingResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BattleInvitingResponse); ok {
+			return x.BattleInvitingResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBattleInviting() *BattleInvitingMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BattleInviting); ok {
+			return x.BattleInviting
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetStartBattle() *StartBattleMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_StartBattle); ok {
+			return x.StartBattle
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetGetAreaRequest() *GetAreaRequest {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_GetAreaRequest); ok {
+			return x.GetAreaRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSyncState() *SyncState {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SyncState); ok {
+			return x.SyncState
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetGetAreaNpcs() *GetAreaNPCsMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_GetAreaNpcs); ok {
+			return x.GetAreaNpcs
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetInteractNpcRequest() *InteractNPCRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_InteractNpcRequest); ok {
+			return x.InteractNpcRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetNpcInteract() *NPCInteractPacket {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_NpcInteract); ok {
+			return x.NpcInteract
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetForgetSkillRequest() *ForgetSkillRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ForgetSkillRequest); ok {
+			return x.ForgetSkillRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetForgetSkillResponse() *ForgetSkillResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ForgetSkillResponse); ok {
+			return x.ForgetSkillResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetReplayListRequest() *ReplayListRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ReplayListRequest); ok {
+			return x.ReplayListRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetReplayListResponse() *ReplayListResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ReplayListResponse); ok {
+			return x.ReplayListResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetReplayRequest() *ReplayRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ReplayRequest); ok {
+			return x.ReplayRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetReplayResponse() *ReplayResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_ReplayResponse); ok {
+			return x.ReplayResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSpectateRequest() *SpectateRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SpectateRequest); ok {
+			return x.SpectateRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetSpectateResponse() *SpectateResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_SpectateResponse); ok {
+			return x.SpectateResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetStopSpectate() *StopSpectateMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_StopSpectate); ok {
+			return x.StopSpectate
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetJoinMatchmaking() *JoinMatchmakingMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_JoinMatchmaking); ok {
+			return x.JoinMatchmaking
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLeaveMatchmaking() *LeaveMatchmakingMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LeaveMatchmaking); ok {
+			return x.LeaveMatchmaking
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMatchmakingResponse() *MatchmakingResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_MatchmakingResponse); ok {
+			return x.MatchmakingResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMatchFound() *MatchFoundMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_MatchFound); ok {
+			return x.MatchFound
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMatchReady() *MatchReadyMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_MatchReady); ok {
+			return x.MatchReady
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetMatchCancelled() *MatchCancelledMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_MatchCancelled); ok {
+			return x.MatchCancelled
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRankListRequest() *RankListRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RankListRequest); ok {
+			return x.RankListRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetRankListResponse() *RankListResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_RankListResponse); ok {
+			return x.RankListResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBattleHistoryRequest() *BattleHistoryRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BattleHistoryRequest); ok {
+			return x.BattleHistoryRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBattleHistoryResponse() *BattleHistoryResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BattleHistoryResponse); ok {
+			return x.BattleHistoryResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLeaderboardRequest() *LeaderboardRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LeaderboardRequest); ok {
+			return x.LeaderboardRequest
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetLeaderboardResponse() *LeaderboardResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_LeaderboardResponse); ok {
+			return x.LeaderboardResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBattleRequestResponse() *BattleRequestResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BattleRequestResponse); ok {
+			return x.BattleRequestResponse
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetCancelBattleInvite() *CancelBattleInviteMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_CancelBattleInvite); ok {
+			return x.CancelBattleInvite
+		}
+	}
+	return nil
+}
+
+func (x *Packet) GetBattleInviteClosed() *BattleInviteClosedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*Packet_BattleInviteClosed); ok {
+			return x.BattleInviteClosed
+		}
+	}
+	return nil
+}
+
+type isPacket_Msg interface {
+	isPacket_Msg()
+}
+
+type Packet_LoginRequest struct {
+	LoginRequest *LoginRequestMessage `protobuf:"bytes,2,opt,name=login_request,json=loginRequest,proto3,oneof"`
+}
+
+type Packet_RegisterRequest struct {
+	RegisterRequest *RegisterRequestMessage `protobuf:"bytes,3,opt,name=register_request,json=registerRequest,proto3,oneof"`
+}
+
+type Packet_OkResponse struct {
+	OkResponse *OKResponseMessage `protobuf:"bytes,4,opt,name=ok_response,json=okResponse,proto3,oneof"`
+}
+
+type Packet_DenyResponse struct {
+	DenyResponse *DenyResponseMessage `protobuf:"bytes,5,opt,name=deny_response,json=denyResponse,proto3,oneof"`
+}
+
+type Packet_LoginSuccess struct {
+	LoginSuccess *LoginSuccessMessage `protobuf:"bytes,6,opt,name=login_success,json=loginSuccess,proto3,oneof"`
+}
+
+type Packet_PlayerEnter struct {
+	PlayerEnter *PlayerEnterAreaMessage `protobuf:"bytes,7,opt,name=player_enter,json=playerEnter,proto3,oneof"`
+}
+
+type Packet_PlayerLeave struct {
+	PlayerLeave *PlayerLeaveAreaMessage `protobuf:"bytes,8,opt,name=player_leave,json=playerLeave,proto3,oneof"`
+}
+
+type Packet_PlayerMovement struct {
+	PlayerMovement *PlayerMoveMessage `protobuf:"bytes,9,opt,name=player_movement,json=playerMovement,proto3,oneof"`
+}
+
+type Packet_PlayerEnterRequest struct {
+	PlayerEnterRequest *PlayerEnterAreaRequestMessage `protobuf:"bytes,10,opt,name=player_enter_request,json=playerEnterRequest,proto3,oneof"`
+}
+
+type Packet_Chat struct {
+	Chat *ChatMessage `protobuf:"bytes,11,opt,name=chat,proto3,oneof"`
+}
+
+type Packet_PlayerEnterAreaResponse struct {
+	PlayerEnterAreaResponse *PlayerEnterAreaResponseMessage `protobuf:"bytes,12,opt,name=player_enter_area_response,json=playerEnterAreaResponse,proto3,oneof"`
+}
+
+type Packet_Mail struct {
+	Mail *MailMessage `protobuf:"bytes,13,opt,name=mail,proto3,oneof"`
+}
+
+type Packet_MailRequest struct {
+	MailRequest *MailRequestMessage `protobuf:"bytes,14,opt,name=mail_request,json=mailRequest,proto3,oneof"`
+}
+
+type Packet_MailCollect struct {
+	MailCollect *MailCollectMessage `protobuf:"bytes,15,opt,name=mail_collect,json=mailCollect,proto3,oneof"`
+}
+
+type Packet_MailDelete struct {
+	MailDelete *MailDeleteMessage `protobuf:"bytes,16,opt,name=mail_delete,json=mailDelete,proto3,oneof"`
+}
+
+type Packet_MailCollectResponse struct {
+	MailCollectResponse *MailCollectResponseMessage `protobuf:"bytes,17,opt,name=mail_collect_response,json=mailCollectResponse,proto3,oneof"`
+}
+
+type Packet_BagRequest struct {
+	BagRequest *BagRequestMessage `protobuf:"bytes,18,opt,name=bag_request,json=bagRequest,proto3,oneof"`
+}
+
+type Packet_Bag struct {
+	Bag *BagMessage `protobuf:"bytes,19,opt,name=bag,proto3,oneof"`
 }
 
 type Packet_AddBagItem struct {
 	AddBagItem *AddBagItemMessage `protobuf:"bytes,20,opt,name=add_bag_item,json=addBagItem,proto3,oneof"`
 }
 
-type Packet_DeleteBagItem struct {
-	DeleteBagItem *DeleteBagItemMessage `protobuf:"bytes,21,opt,name=delete_bag_item,json=deleteBagItem,proto3,oneof"`
+type Packet_DeleteBagItem struct {
+	DeleteBagItem *DeleteBagItemMessage `protobuf:"bytes,21,opt,name=delete_bag_item,json=deleteBagItem,proto3,oneof"`
+}
+
+type Packet_UseBagItemRequest struct {
+	UseBagItemRequest *UseBagItemRequestMessage `protobuf:"bytes,22,opt,name=use_bag_item_request,json=useBagItemRequest,proto3,oneof"`
+}
+
+type Packet_UseBagItemResponse struct {
+	UseBagItemResponse *UseBagItemResponseMessage `protobuf:"bytes,23,opt,name=use_bag_item_response,json=useBagItemResponse,proto3,oneof"`
+}
+
+type Packet_UiPacket struct {
+	UiPacket *UiPacket `protobuf:"bytes,24,opt,name=ui_packet,json=uiPacket,proto3,oneof"`
+}
+
+type Packet_GetPet struct {
+	GetPet *GetPetMessage `protobuf:"bytes,25,opt,name=get_pet,json=getPet,proto3,oneof"`
+}
+
+type Packet_PetBagRequest struct {
+	PetBagRequest *PetBagRequestMessage `protobuf:"bytes,26,opt,name=pet_bag_request,json=petBagRequest,proto3,oneof"`
+}
+
+type Packet_PetBagResponse struct {
+	PetBagResponse *PetBagResponseMessage `protobuf:"bytes,27,opt,name=pet_bag_response,json=petBagResponse,proto3,oneof"`
+}
+
+type Packet_SavePet struct {
+	SavePet *SavePetMessage `protobuf:"bytes,28,opt,name=save_pet,json=savePet,proto3,oneof"`
+}
+
+type Packet_LearnSkillRequest struct {
+	LearnSkillRequest *LearnSkillRequestMessage `protobuf:"bytes,29,opt,name=learn_skill_request,json=learnSkillRequest,proto3,oneof"`
+}
+
+type Packet_LearnSkillResponse struct {
+	LearnSkillResponse *LearnSkillResponseMessage `protobuf:"bytes,30,opt,name=learn_skill_response,json=learnSkillResponse,proto3,oneof"`
+}
+
+type Packet_AddPetItem struct {
+	AddPetItem *AddPetItemMessage `protobuf:"bytes,31,opt,name=add_pet_item,json=addPetItem,proto3,oneof"`
+}
+
+type Packet_DeletePetItem struct {
+	DeletePetItem *DeletePetItemMessage `protobuf:"bytes,32,opt,name=delete_pet_item,json=deletePetItem,proto3,oneof"`
+}
+
+type Packet_PetItemBagRequest struct {
+	PetItemBagRequest *PetItemBagRequestMessage `protobuf:"bytes,33,opt,name=pet_item_bag_request,json=petItemBagRequest,proto3,oneof"`
+}
+
+type Packet_UsePetItemRequest struct {
+	UsePetItemRequest *UsePetItemRequestMessage `protobuf:"bytes,34,opt,name=use_pet_item_request,json=usePetItemRequest,proto3,oneof"`
+}
+
+type Packet_UsePetItemResponse struct {
+	UsePetItemResponse *UsePetItemResponseMessage `protobuf:"bytes,35,opt,name=use_pet_item_response,json=usePetItemResponse,proto3,oneof"`
+}
+
+type Packet_PetItemBagResponse struct {
+	PetItemBagResponse *PetItemBagResponseMessage `protobuf:"bytes,36,opt,name=pet_item_bag_response,json=petItemBagResponse,proto3,oneof"`
+}
+
+type Packet_EquippedPetInfoRequest struct {
+	EquippedPetInfoRequest *EquippedPetInfoRequestMessage `protobuf:"bytes,37,opt,name=equipped_pet_info_request,json=equippedPetInfoRequest,proto3,oneof"`
+}
+
+type Packet_EquippedPetInfoResponse struct {
+	EquippedPetInfoResponse *EquippedPetInfoResponseMessage `protobuf:"bytes,38,opt,name=equipped_pet_info_response,json=equippedPetInfoResponse,proto3,oneof"`
+}
+
+type Packet_BattlePacket struct {
+	BattlePacket *BattlePacket `protobuf:"bytes,39,opt,name=battle_packet,json=battlePacket,proto3,oneof"`
+}
+
+type Packet_BattleRequest struct {
+	BattleRequest *BattleRequestMessage `protobuf:"bytes,40,opt,name=battle_request,json=battleRequest,proto3,oneof"`
+}
+
+type Packet_BattleInvitingResponse struct {
+	BattleInvitingResponse *BattleInvitingResponseMessage `protobuf:"bytes,41,opt,name=battle_inviting_response,json=battleInvitingResponse,proto3,oneof"`
+}
+
+type Packet_BattleInviting struct {
+	BattleInviting *BattleInvitingMessage `protobuf:"bytes,42,opt,name=battle_inviting,json=battleInviting,proto3,oneof"`
+}
+
+type Packet_StartBattle struct {
+	StartBattle *StartBattleMessage `protobuf:"bytes,43,opt,name=start_battle,json=startBattle,proto3,oneof"`
+}
+
+type Packet_GetAreaRequest struct {
+	GetAreaRequest *GetAreaRequest `protobuf:"bytes,44,opt,name=get_area_request,json=getAreaRequest,proto3,oneof"`
+}
+
+type Packet_SyncState struct {
+	SyncState *SyncState `protobuf:"bytes,45,opt,name=sync_state,json=syncState,proto3,oneof"`
+}
+
+type Packet_GetAreaNpcs struct {
+	GetAreaNpcs *GetAreaNPCsMessage `protobuf:"bytes,46,opt,name=get_area_npcs,json=getAreaNpcs,proto3,oneof"`
+}
+
+type Packet_InteractNpcRequest struct {
+	InteractNpcRequest *InteractNPCRequestMessage `protobuf:"bytes,47,opt,name=interact_npc_request,json=interactNpcRequest,proto3,oneof"`
+}
+
+type Packet_NpcInteract struct {
+	NpcInteract *NPCInteractPacket `protobuf:"bytes,48,opt,name=npc_interact,json=npcInteract,proto3,oneof"`
+}
+
+type Packet_ForgetSkillRequest struct {
+	ForgetSkillRequest *ForgetSkillRequestMessage `protobuf:"bytes,49,opt,name=forget_skill_request,json=forgetSkillRequest,proto3,oneof"`
+}
+
+type Packet_ForgetSkillResponse struct {
+	ForgetSkillResponse *ForgetSkillResponseMessage `protobuf:"bytes,50,opt,name=forget_skill_response,json=forgetSkillResponse,proto3,oneof"`
+}
+
+type Packet_ReplayListRequest struct {
+	ReplayListRequest *ReplayListRequestMessage `protobuf:"bytes,51,opt,name=replay_list_request,json=replayListRequest,proto3,oneof"`
+}
+
+type Packet_ReplayListResponse struct {
+	ReplayListResponse *ReplayListResponseMessage `protobuf:"bytes,52,opt,name=replay_list_response,json=replayListResponse,proto3,oneof"`
+}
+
+type Packet_ReplayRequest struct {
+	ReplayRequest *ReplayRequestMessage `protobuf:"bytes,53,opt,name=replay_request,json=replayRequest,proto3,oneof"`
+}
+
+type Packet_ReplayResponse struct {
+	ReplayResponse *ReplayResponseMessage `protobuf:"bytes,54,opt,name=replay_response,json=replayResponse,proto3,oneof"`
+}
+
+type Packet_SpectateRequest struct {
+	SpectateRequest *SpectateRequestMessage `protobuf:"bytes,55,opt,name=spectate_request,json=spectateRequest,proto3,oneof"`
+}
+
+type Packet_SpectateResponse struct {
+	SpectateResponse *SpectateResponseMessage `protobuf:"bytes,56,opt,name=spectate_response,json=spectateResponse,proto3,oneof"`
+}
+
+type Packet_StopSpectate struct {
+	StopSpectate *StopSpectateMessage `protobuf:"bytes,57,opt,name=stop_spectate,json=stopSpectate,proto3,oneof"`
+}
+
+type Packet_JoinMatchmaking struct {
+	JoinMatchmaking *JoinMatchmakingMessage `protobuf:"bytes,58,opt,name=join_matchmaking,json=joinMatchmaking,proto3,oneof"`
+}
+
+type Packet_LeaveMatchmaking struct {
+	LeaveMatchmaking *LeaveMatchmakingMessage `protobuf:"bytes,59,opt,name=leave_matchmaking,json=leaveMatchmaking,proto3,oneof"`
+}
+
+type Packet_MatchmakingResponse struct {
+	MatchmakingResponse *MatchmakingResponseMessage `protobuf:"bytes,60,opt,name=matchmaking_response,json=matchmakingResponse,proto3,oneof"`
+}
+
+type Packet_MatchFound struct {
+	MatchFound *MatchFoundMessage `protobuf:"bytes,61,opt,name=match_found,json=matchFound,proto3,oneof"`
+}
+
+type Packet_MatchReady struct {
+	MatchReady *MatchReadyMessage `protobuf:"bytes,62,opt,name=match_ready,json=matchReady,proto3,oneof"`
+}
+
+type Packet_MatchCancelled struct {
+	MatchCancelled *MatchCancelledMessage `protobuf:"bytes,63,opt,name=match_cancelled,json=matchCancelled,proto3,oneof"`
+}
+
+type Packet_RankListRequest struct {
+	RankListRequest *RankListRequestMessage `protobuf:"bytes,64,opt,name=rank_list_request,json=rankListRequest,proto3,oneof"`
+}
+
+type Packet_RankListResponse struct {
+	RankListResponse *RankListResponseMessage `protobuf:"bytes,65,opt,name=rank_list_response,json=rankListResponse,proto3,oneof"`
+}
+
+type Packet_BattleHistoryRequest struct {
+	BattleHistoryRequest *BattleHistoryRequestMessage `protobuf:"bytes,66,opt,name=battle_history_request,json=battleHistoryRequest,proto3,oneof"`
+}
+
+type Packet_BattleHistoryResponse struct {
+	BattleHistoryResponse *BattleHistoryResponseMessage `protobuf:"bytes,67,opt,name=battle_history_response,json=battleHistoryResponse,proto3,oneof"`
+}
+
+type Packet_LeaderboardRequest struct {
+	LeaderboardRequest *LeaderboardRequestMessage `protobuf:"bytes,68,opt,name=leaderboard_request,json=leaderboardRequest,proto3,oneof"`
+}
+
+type Packet_LeaderboardResponse struct {
+	LeaderboardResponse *LeaderboardResponseMessage `protobuf:"bytes,69,opt,name=leaderboard_response,json=leaderboardResponse,proto3,oneof"`
+}
+
+type Packet_BattleRequestResponse struct {
+	BattleRequestResponse *BattleRequestResponseMessage `protobuf:"bytes,70,opt,name=battle_request_response,json=battleRequestResponse,proto3,oneof"`
+}
+
+type Packet_CancelBattleInvite struct {
+	CancelBattleInvite *CancelBattleInviteMessage `protobuf:"bytes,71,opt,name=cancel_battle_invite,json=cancelBattleInvite,proto3,oneof"`
+}
+
+type Packet_BattleInviteClosed struct {
+	BattleInviteClosed *BattleInviteClosedMessage `protobuf:"bytes,72,opt,name=battle_invite_closed,json=battleInviteClosed,proto3,oneof"`
+}
+
+func (*Packet_LoginRequest) isPacket_Msg() {}
+
+func (*Packet_RegisterRequest) isPacket_Msg() {}
+
+func (*Packet_OkResponse) isPacket_Msg() {}
+
+func (*Packet_DenyResponse) isPacket_Msg() {}
+
+func (*Packet_LoginSuccess) isPacket_Msg() {}
+
+func (*Packet_PlayerEnter) isPacket_Msg() {}
+
+func (*Packet_PlayerLeave) isPacket_Msg() {}
+
+func (*Packet_PlayerMovement) isPacket_Msg() {}
+
+func (*Packet_PlayerEnterRequest) isPacket_Msg() {}
+
+func (*Packet_Chat) isPacket_Msg() {}
+
+func (*Packet_PlayerEnterAreaResponse) isPacket_Msg() {}
+
+func (*Packet_Mail) isPacket_Msg() {}
+
+func (*Packet_MailRequest) isPacket_Msg() {}
+
+func (*Packet_MailCollect) isPacket_Msg() {}
+
+func (*Packet_MailDelete) isPacket_Msg() {}
+
+func (*Packet_MailCollectResponse) isPacket_Msg() {}
+
+func (*Packet_BagRequest) isPacket_Msg() {}
+
+func (*Packet_Bag) isPacket_Msg() {}
+
+func (*Packet_AddBagItem) isPacket_Msg() {}
+
+func (*Packet_DeleteBagItem) isPacket_Msg() {}
+
+func (*Packet_UseBagItemRequest) isPacket_Msg() {}
+
+func (*Packet_UseBagItemResponse) isPacket_Msg() {}
+
+func (*Packet_UiPacket) isPacket_Msg() {}
+
+func (*Packet_GetPet) isPacket_Msg() {}
+
+func (*Packet_PetBagRequest) isPacket_Msg() {}
+
+func (*Packet_PetBagResponse) isPacket_Msg() {}
+
+func (*Packet_SavePet) isPacket_Msg() {}
+
+func (*Packet_LearnSkillRequest) isPacket_Msg() {}
+
+func (*Packet_LearnSkillResponse) isPacket_Msg() {}
+
+func (*Packet_AddPetItem) isPacket_Msg() {}
+
+func (*Packet_DeletePetItem) isPacket_Msg() {}
+
+func (*Packet_PetItemBagRequest) isPacket_Msg() {}
+
+func (*Packet_UsePetItemRequest) isPacket_Msg() {}
+
+func (*Packet_UsePetItemResponse) isPacket_Msg() {}
+
+func (*Packet_PetItemBagResponse) isPacket_Msg() {}
+
+func (*Packet_EquippedPetInfoRequest) isPacket_Msg() {}
+
+func (*Packet_EquippedPetInfoResponse) isPacket_Msg() {}
+
+func (*Packet_BattlePacket) isPacket_Msg() {}
+
+func (*Packet_BattleRequest) isPacket_Msg() {}
+
+func (*Packet_BattleInvitingResponse) isPacket_Msg() {}
+
+func (*Packet_BattleInviting) isPacket_Msg() {}
+
+func (*Packet_StartBattle) isPacket_Msg() {}
+
+func (*Packet_GetAreaRequest) isPacket_Msg() {}
+
+func (*Packet_SyncState) isPacket_Msg() {}
+
+func (*Packet_GetAreaNpcs) isPacket_Msg() {}
+
+func (*Packet_InteractNpcRequest) isPacket_Msg() {}
+
+func (*Packet_NpcInteract) isPacket_Msg() {}
+
+func (*Packet_ForgetSkillRequest) isPacket_Msg() {}
+
+func (*Packet_ForgetSkillResponse) isPacket_Msg() {}
+
+func (*Packet_ReplayListRequest) isPacket_Msg() {}
+
+func (*Packet_ReplayListResponse) isPacket_Msg() {}
+
+func (*Packet_ReplayRequest) isPacket_Msg() {}
+
+func (*Packet_ReplayResponse) isPacket_Msg() {}
+
+func (*Packet_SpectateRequest) isPacket_Msg() {}
+
+func (*Packet_SpectateResponse) isPacket_Msg() {}
+
+func (*Packet_StopSpectate) isPacket_Msg() {}
+
+func (*Packet_JoinMatchmaking) isPacket_Msg() {}
+
+func (*Packet_LeaveMatchmaking) isPacket_Msg() {}
+
+func (*Packet_MatchmakingResponse) isPacket_Msg() {}
+
+func (*Packet_MatchFound) isPacket_Msg() {}
+
+func (*Packet_MatchReady) isPacket_Msg() {}
+
+func (*Packet_MatchCancelled) isPacket_Msg() {}
+
+func (*Packet_RankListRequest) isPacket_Msg() {}
+
+func (*Packet_RankListResponse) isPacket_Msg() {}
+
+func (*Packet_BattleHistoryRequest) isPacket_Msg() {}
+
+func (*Packet_BattleHistoryResponse) isPacket_Msg() {}
+
+func (*Packet_LeaderboardRequest) isPacket_Msg() {}
+
+func (*Packet_LeaderboardResponse) isPacket_Msg() {}
+
+func (*Packet_BattleRequestResponse) isPacket_Msg() {}
+
+func (*Packet_CancelBattleInvite) isPacket_Msg() {}
+
+func (*Packet_BattleInviteClosed) isPacket_Msg() {}
+
+type UiPacket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*UiPacket_OpenUi
+	//	*UiPacket_InitialPetRequest
+	Msg           isUiPacket_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UiPacket) Reset() {
+	*x = UiPacket{}
+	mi := &file_shared_packets_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UiPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UiPacket) ProtoMessage() {}
+
+func (x *UiPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UiPacket.ProtoReflect.Descriptor instead.
+func (*UiPacket) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *UiPacket) GetMsg() isUiPacket_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *UiPacket) GetOpenUi() *OpenUIMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*UiPacket_OpenUi); ok {
+			return x.OpenUi
+		}
+	}
+	return nil
+}
+
+func (x *UiPacket) GetInitialPetRequest() *InitialPetRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*UiPacket_InitialPetRequest); ok {
+			return x.InitialPetRequest
+		}
+	}
+	return nil
+}
+
+type isUiPacket_Msg interface {
+	isUiPacket_Msg()
+}
+
+type UiPacket_OpenUi struct {
+	OpenUi *OpenUIMessage `protobuf:"bytes,1,opt,name=open_ui,json=openUi,proto3,oneof"`
+}
+
+type UiPacket_InitialPetRequest struct {
+	InitialPetRequest *InitialPetRequestMessage `protobuf:"bytes,2,opt,name=initial_pet_request,json=initialPetRequest,proto3,oneof"`
+}
+
+func (*UiPacket_OpenUi) isUiPacket_Msg() {}
+
+func (*UiPacket_InitialPetRequest) isUiPacket_Msg() {}
+
+type OpenUIMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenUIMessage) Reset() {
+	*x = OpenUIMessage{}
+	mi := &file_shared_packets_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenUIMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenUIMessage) ProtoMessage() {}
+
+func (x *OpenUIMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenUIMessage.ProtoReflect.Descriptor instead.
+func (*OpenUIMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *OpenUIMessage) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type InitialPetRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     uint32                 `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitialPetRequestMessage) Reset() {
+	*x = InitialPetRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitialPetRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitialPetRequestMessage) ProtoMessage() {}
+
+func (x *InitialPetRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitialPetRequestMessage.ProtoReflect.Descriptor instead.
+func (*InitialPetRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *InitialPetRequestMessage) GetRequestId() uint32 {
+	if x != nil {
+		return x.RequestId
+	}
+	return 0
+}
+
+type NPCInteractPacket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*NPCInteractPacket_Heal
+	//	*NPCInteractPacket_InitialVillageHeader
+	Msg           isNPCInteractPacket_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NPCInteractPacket) Reset() {
+	*x = NPCInteractPacket{}
+	mi := &file_shared_packets_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NPCInteractPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NPCInteractPacket) ProtoMessage() {}
+
+func (x *NPCInteractPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NPCInteractPacket.ProtoReflect.Descriptor instead.
+func (*NPCInteractPacket) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *NPCInteractPacket) GetMsg() isNPCInteractPacket_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *NPCInteractPacket) GetHeal() *HealMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*NPCInteractPacket_Heal); ok {
+			return x.Heal
+		}
+	}
+	return nil
+}
+
+func (x *NPCInteractPacket) GetInitialVillageHeader() *InitialVillageHeaderMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*NPCInteractPacket_InitialVillageHeader); ok {
+			return x.InitialVillageHeader
+		}
+	}
+	return nil
+}
+
+type isNPCInteractPacket_Msg interface {
+	isNPCInteractPacket_Msg()
+}
+
+type NPCInteractPacket_Heal struct {
+	Heal *HealMessage `protobuf:"bytes,1,opt,name=heal,proto3,oneof"`
+}
+
+type NPCInteractPacket_InitialVillageHeader struct {
+	InitialVillageHeader *InitialVillageHeaderMessage `protobuf:"bytes,2,opt,name=initial_village_header,json=initialVillageHeader,proto3,oneof"`
+}
+
+func (*NPCInteractPacket_Heal) isNPCInteractPacket_Msg() {}
+
+func (*NPCInteractPacket_InitialVillageHeader) isNPCInteractPacket_Msg() {}
+
+type HealMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealMessage) Reset() {
+	*x = HealMessage{}
+	mi := &file_shared_packets_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealMessage) ProtoMessage() {}
+
+func (x *HealMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealMessage.ProtoReflect.Descriptor instead.
+func (*HealMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{70}
+}
+
+type InitialVillageHeaderMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Section:
+	//
+	//	*InitialVillageHeaderMessage_NewRewardRequest
+	//	*InitialVillageHeaderMessage_UpdateInfo
+	Section       isInitialVillageHeaderMessage_Section `protobuf_oneof:"section"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InitialVillageHeaderMessage) Reset() {
+	*x = InitialVillageHeaderMessage{}
+	mi := &file_shared_packets_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InitialVillageHeaderMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InitialVillageHeaderMessage) ProtoMessage() {}
+
+func (x *InitialVillageHeaderMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InitialVillageHeaderMessage.ProtoReflect.Descriptor instead.
+func (*InitialVillageHeaderMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *InitialVillageHeaderMessage) GetSection() isInitialVillageHeaderMessage_Section {
+	if x != nil {
+		return x.Section
+	}
+	return nil
+}
+
+func (x *InitialVillageHeaderMessage) GetNewRewardRequest() *NewRewardRequest {
+	if x != nil {
+		if x, ok := x.Section.(*InitialVillageHeaderMessage_NewRewardRequest); ok {
+			return x.NewRewardRequest
+		}
+	}
+	return nil
+}
+
+func (x *InitialVillageHeaderMessage) GetUpdateInfo() *UpdateInitialVillageHeaderUIInfo {
+	if x != nil {
+		if x, ok := x.Section.(*InitialVillageHeaderMessage_UpdateInfo); ok {
+			return x.UpdateInfo
+		}
+	}
+	return nil
+}
+
+type isInitialVillageHeaderMessage_Section interface {
+	isInitialVillageHeaderMessage_Section()
+}
+
+type InitialVillageHeaderMessage_NewRewardRequest struct {
+	NewRewardRequest *NewRewardRequest `protobuf:"bytes,1,opt,name=new_reward_request,json=newRewardRequest,proto3,oneof"`
+}
+
+type InitialVillageHeaderMessage_UpdateInfo struct {
+	UpdateInfo *UpdateInitialVillageHeaderUIInfo `protobuf:"bytes,2,opt,name=update_info,json=updateInfo,proto3,oneof"`
+}
+
+func (*InitialVillageHeaderMessage_NewRewardRequest) isInitialVillageHeaderMessage_Section() {}
+
+func (*InitialVillageHeaderMessage_UpdateInfo) isInitialVillageHeaderMessage_Section() {}
+
+type NewRewardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NewRewardRequest) Reset() {
+	*x = NewRewardRequest{}
+	mi := &file_shared_packets_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NewRewardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewRewardRequest) ProtoMessage() {}
+
+func (x *NewRewardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewRewardRequest.ProtoReflect.Descriptor instead.
+func (*NewRewardRequest) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{72}
+}
+
+type UpdateInitialVillageHeaderUIInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CanGetNewReward bool                   `protobuf:"varint,1,opt,name=can_get_new_reward,json=canGetNewReward,proto3" json:"can_get_new_reward,omitempty"`
+	CanChallenge    bool                   `protobuf:"varint,2,opt,name=can_challenge,json=canChallenge,proto3" json:"can_challenge,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateInitialVillageHeaderUIInfo) Reset() {
+	*x = UpdateInitialVillageHeaderUIInfo{}
+	mi := &file_shared_packets_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateInitialVillageHeaderUIInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateInitialVillageHeaderUIInfo) ProtoMessage() {}
+
+func (x *UpdateInitialVillageHeaderUIInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateInitialVillageHeaderUIInfo.ProtoReflect.Descriptor instead.
+func (*UpdateInitialVillageHeaderUIInfo) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *UpdateInitialVillageHeaderUIInfo) GetCanGetNewReward() bool {
+	if x != nil {
+		return x.CanGetNewReward
+	}
+	return false
+}
+
+func (x *UpdateInitialVillageHeaderUIInfo) GetCanChallenge() bool {
+	if x != nil {
+		return x.CanChallenge
+	}
+	return false
+}
+
+// --------------------------------------战斗系统------------------------
+type BattlePacket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Msg:
+	//
+	//	*BattlePacket_Command
+	//	*BattlePacket_AttackStats
+	//	*BattlePacket_DenyCommand
+	//	*BattlePacket_StartNextRound
+	//	*BattlePacket_BattleEnd
+	//	*BattlePacket_RoundConfirm
+	//	*BattlePacket_ChangePet
+	//	*BattlePacket_ChangePetRequest
+	//	*BattlePacket_SyncBattleInformation
+	//	*BattlePacket_RoundEnd
+	//	*BattlePacket_PlayerDisconnected
+	//	*BattlePacket_PlayerReconnected
+	//	*BattlePacket_CaptureResult
+	//	*BattlePacket_BattleEndStats
+	//	*BattlePacket_BattleSnapshot
+	//	*BattlePacket_BattleFormat
+	//	*BattlePacket_PetSwitched
+	Msg           isBattlePacket_Msg `protobuf_oneof:"msg"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattlePacket) Reset() {
+	*x = BattlePacket{}
+	mi := &file_shared_packets_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattlePacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattlePacket) ProtoMessage() {}
+
+func (x *BattlePacket) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattlePacket.ProtoReflect.Descriptor instead.
+func (*BattlePacket) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *BattlePacket) GetMsg() isBattlePacket_Msg {
+	if x != nil {
+		return x.Msg
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetCommand() *RoundCommandMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_Command); ok {
+			return x.Command
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetAttackStats() *AttackStatsMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_AttackStats); ok {
+			return x.AttackStats
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetDenyCommand() *DenyCommandMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_DenyCommand); ok {
+			return x.DenyCommand
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetStartNextRound() *StartNextRoundMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_StartNextRound); ok {
+			return x.StartNextRound
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetBattleEnd() *BattleEndMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_BattleEnd); ok {
+			return x.BattleEnd
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetRoundConfirm() *RoundConfirmMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_RoundConfirm); ok {
+			return x.RoundConfirm
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetChangePet() *ChangePetResponseMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_ChangePet); ok {
+			return x.ChangePet
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetChangePetRequest() *ChangePetRequestMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_ChangePetRequest); ok {
+			return x.ChangePetRequest
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetSyncBattleInformation() *SyncBattleInformationMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_SyncBattleInformation); ok {
+			return x.SyncBattleInformation
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetRoundEnd() *RoundEndMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_RoundEnd); ok {
+			return x.RoundEnd
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetPlayerDisconnected() *PlayerDisconnectedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_PlayerDisconnected); ok {
+			return x.PlayerDisconnected
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetPlayerReconnected() *PlayerReconnectedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_PlayerReconnected); ok {
+			return x.PlayerReconnected
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetCaptureResult() *CaptureResultMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_CaptureResult); ok {
+			return x.CaptureResult
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetBattleEndStats() *BattleEndStats {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_BattleEndStats); ok {
+			return x.BattleEndStats
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetBattleSnapshot() *BattleSnapshotMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_BattleSnapshot); ok {
+			return x.BattleSnapshot
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetBattleFormat() *BattleFormatMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_BattleFormat); ok {
+			return x.BattleFormat
+		}
+	}
+	return nil
+}
+
+func (x *BattlePacket) GetPetSwitched() *PetSwitchedMessage {
+	if x != nil {
+		if x, ok := x.Msg.(*BattlePacket_PetSwitched); ok {
+			return x.PetSwitched
+		}
+	}
+	return nil
+}
+
+type isBattlePacket_Msg interface {
+	isBattlePacket_Msg()
+}
+
+type BattlePacket_Command struct {
+	Command *RoundCommandMessage `protobuf:"bytes,1,opt,name=command,proto3,oneof"`
+}
+
+type BattlePacket_AttackStats struct {
+	AttackStats *AttackStatsMessage `protobuf:"bytes,2,opt,name=attack_stats,json=attackStats,proto3,oneof"`
+}
+
+type BattlePacket_DenyCommand struct {
+	DenyCommand *DenyCommandMessage `protobuf:"bytes,3,opt,name=deny_command,json=denyCommand,proto3,oneof"`
+}
+
+type BattlePacket_StartNextRound struct {
+	StartNextRound *StartNextRoundMessage `protobuf:"bytes,4,opt,name=start_next_round,json=startNextRound,proto3,oneof"`
+}
+
+type BattlePacket_BattleEnd struct {
+	BattleEnd *BattleEndMessage `protobuf:"bytes,5,opt,name=battle_end,json=battleEnd,proto3,oneof"`
+}
+
+type BattlePacket_RoundConfirm struct {
+	RoundConfirm *RoundConfirmMessage `protobuf:"bytes,6,opt,name=round_confirm,json=roundConfirm,proto3,oneof"`
+}
+
+type BattlePacket_ChangePet struct {
+	ChangePet *ChangePetResponseMessage `protobuf:"bytes,7,opt,name=change_pet,json=changePet,proto3,oneof"`
+}
+
+type BattlePacket_ChangePetRequest struct {
+	ChangePetRequest *ChangePetRequestMessage `protobuf:"bytes,8,opt,name=change_pet_request,json=changePetRequest,proto3,oneof"`
+}
+
+type BattlePacket_SyncBattleInformation struct {
+	SyncBattleInformation *SyncBattleInformationMessage `protobuf:"bytes,9,opt,name=sync_battle_information,json=syncBattleInformation,proto3,oneof"`
+}
+
+type BattlePacket_RoundEnd struct {
+	RoundEnd *RoundEndMessage `protobuf:"bytes,10,opt,name=round_end,json=roundEnd,proto3,oneof"`
+}
+
+type BattlePacket_PlayerDisconnected struct {
+	PlayerDisconnected *PlayerDisconnectedMessage `protobuf:"bytes,11,opt,name=player_disconnected,json=playerDisconnected,proto3,oneof"`
+}
+
+type BattlePacket_PlayerReconnected struct {
+	PlayerReconnected *PlayerReconnectedMessage `protobuf:"bytes,12,opt,name=player_reconnected,json=playerReconnected,proto3,oneof"`
+}
+
+type BattlePacket_CaptureResult struct {
+	CaptureResult *CaptureResultMessage `protobuf:"bytes,13,opt,name=capture_result,json=captureResult,proto3,oneof"`
+}
+
+type BattlePacket_BattleEndStats struct {
+	BattleEndStats *BattleEndStats `protobuf:"bytes,14,opt,name=battle_end_stats,json=battleEndStats,proto3,oneof"`
+}
+
+type BattlePacket_BattleSnapshot struct {
+	BattleSnapshot *BattleSnapshotMessage `protobuf:"bytes,15,opt,name=battle_snapshot,json=battleSnapshot,proto3,oneof"`
+}
+
+type BattlePacket_BattleFormat struct {
+	BattleFormat *BattleFormatMessage `protobuf:"bytes,16,opt,name=battle_format,json=battleFormat,proto3,oneof"`
+}
+
+type BattlePacket_PetSwitched struct {
+	PetSwitched *PetSwitchedMessage `protobuf:"bytes,17,opt,name=pet_switched,json=petSwitched,proto3,oneof"`
+}
+
+func (*BattlePacket_Command) isBattlePacket_Msg() {}
+
+func (*BattlePacket_AttackStats) isBattlePacket_Msg() {}
+
+func (*BattlePacket_DenyCommand) isBattlePacket_Msg() {}
+
+func (*BattlePacket_StartNextRound) isBattlePacket_Msg() {}
+
+func (*BattlePacket_BattleEnd) isBattlePacket_Msg() {}
+
+func (*BattlePacket_RoundConfirm) isBattlePacket_Msg() {}
+
+func (*BattlePacket_ChangePet) isBattlePacket_Msg() {}
+
+func (*BattlePacket_ChangePetRequest) isBattlePacket_Msg() {}
+
+func (*BattlePacket_SyncBattleInformation) isBattlePacket_Msg() {}
+
+func (*BattlePacket_RoundEnd) isBattlePacket_Msg() {}
+
+func (*BattlePacket_PlayerDisconnected) isBattlePacket_Msg() {}
+
+func (*BattlePacket_PlayerReconnected) isBattlePacket_Msg() {}
+
+func (*BattlePacket_CaptureResult) isBattlePacket_Msg() {}
+
+func (*BattlePacket_BattleEndStats) isBattlePacket_Msg() {}
+
+func (*BattlePacket_BattleSnapshot) isBattlePacket_Msg() {}
+
+func (*BattlePacket_BattleFormat) isBattlePacket_Msg() {}
+
+func (*BattlePacket_PetSwitched) isBattlePacket_Msg() {}
+
+type RoundCommandMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Command:
+	//
+	//	*RoundCommandMessage_ChangePet
+	//	*RoundCommandMessage_Runaway
+	//	*RoundCommandMessage_Attack
+	//	*RoundCommandMessage_Capture
+	Command       isRoundCommandMessage_Command `protobuf_oneof:"command"`
+	Slot          int64                         `protobuf:"varint,5,opt,name=slot,proto3" json:"slot,omitempty"` // 发出指令的在场宠物位置
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoundCommandMessage) Reset() {
+	*x = RoundCommandMessage{}
+	mi := &file_shared_packets_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoundCommandMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundCommandMessage) ProtoMessage() {}
+
+func (x *RoundCommandMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundCommandMessage.ProtoReflect.Descriptor instead.
+func (*RoundCommandMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RoundCommandMessage) GetCommand() isRoundCommandMessage_Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *RoundCommandMessage) GetChangePet() *ChangePet {
+	if x != nil {
+		if x, ok := x.Command.(*RoundCommandMessage_ChangePet); ok {
+			return x.ChangePet
+		}
+	}
+	return nil
+}
+
+func (x *RoundCommandMessage) GetRunaway() *RunAway {
+	if x != nil {
+		if x, ok := x.Command.(*RoundCommandMessage_Runaway); ok {
+			return x.Runaway
+		}
+	}
+	return nil
+}
+
+func (x *RoundCommandMessage) GetAttack() *Attack {
+	if x != nil {
+		if x, ok := x.Command.(*RoundCommandMessage_Attack); ok {
+			return x.Attack
+		}
+	}
+	return nil
+}
+
+func (x *RoundCommandMessage) GetCapture() *Capture {
+	if x != nil {
+		if x, ok := x.Command.(*RoundCommandMessage_Capture); ok {
+			return x.Capture
+		}
+	}
+	return nil
+}
+
+func (x *RoundCommandMessage) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+type isRoundCommandMessage_Command interface {
+	isRoundCommandMessage_Command()
+}
+
+type RoundCommandMessage_ChangePet struct {
+	ChangePet *ChangePet `protobuf:"bytes,1,opt,name=change_pet,json=changePet,proto3,oneof"`
+}
+
+type RoundCommandMessage_Runaway struct {
+	Runaway *RunAway `protobuf:"bytes,2,opt,name=runaway,proto3,oneof"`
+}
+
+type RoundCommandMessage_Attack struct {
+	Attack *Attack `protobuf:"bytes,3,opt,name=attack,proto3,oneof"`
+}
+
+type RoundCommandMessage_Capture struct {
+	Capture *Capture `protobuf:"bytes,4,opt,name=capture,proto3,oneof"`
+}
+
+func (*RoundCommandMessage_ChangePet) isRoundCommandMessage_Command() {}
+
+func (*RoundCommandMessage_Runaway) isRoundCommandMessage_Command() {}
+
+func (*RoundCommandMessage_Attack) isRoundCommandMessage_Command() {}
+
+func (*RoundCommandMessage_Capture) isRoundCommandMessage_Command() {}
+
+// 技能的目标，number为目标所属的一方，slot为目标在场上的位置
+type BattleTarget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Slot          int64                  `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleTarget) Reset() {
+	*x = BattleTarget{}
+	mi := &file_shared_packets_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleTarget) ProtoMessage() {}
+
+func (x *BattleTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleTarget.ProtoReflect.Descriptor instead.
+func (*BattleTarget) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *BattleTarget) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *BattleTarget) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+type ChangePet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PetPosition   int64                  `protobuf:"varint,1,opt,name=pet_position,json=petPosition,proto3" json:"pet_position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePet) Reset() {
+	*x = ChangePet{}
+	mi := &file_shared_packets_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePet) ProtoMessage() {}
+
+func (x *ChangePet) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePet.ProtoReflect.Descriptor instead.
+func (*ChangePet) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ChangePet) GetPetPosition() int64 {
+	if x != nil {
+		return x.PetPosition
+	}
+	return 0
+}
+
+type RunAway struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunAway) Reset() {
+	*x = RunAway{}
+	mi := &file_shared_packets_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunAway) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunAway) ProtoMessage() {}
+
+func (x *RunAway) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunAway.ProtoReflect.Descriptor instead.
+func (*RunAway) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{78}
+}
+
+type Attack struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SkillPos      int64                  `protobuf:"varint,1,opt,name=skill_pos,json=skillPos,proto3" json:"skill_pos,omitempty"`
+	Target        *BattleTarget          `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"` // 为空时自动选择目标
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attack) Reset() {
+	*x = Attack{}
+	mi := &file_shared_packets_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attack) ProtoMessage() {}
+
+func (x *Attack) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attack.ProtoReflect.Descriptor instead.
+func (*Attack) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *Attack) GetSkillPos() int64 {
+	if x != nil {
+		return x.SkillPos
+	}
+	return 0
+}
+
+func (x *Attack) GetTarget() *BattleTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+// 使用捕捉道具捕捉对方当前的野生宠物
+type Capture struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        uint32                 `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Capture) Reset() {
+	*x = Capture{}
+	mi := &file_shared_packets_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Capture) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capture) ProtoMessage() {}
+
+func (x *Capture) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capture.ProtoReflect.Descriptor instead.
+func (*Capture) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *Capture) GetItemId() uint32 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+type AttackStatsMessage struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Number         int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	SkillId        uint32                 `protobuf:"varint,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	PhysicalDamage int64                  `protobuf:"varint,3,opt,name=physical_damage,json=physicalDamage,proto3" json:"physical_damage,omitempty"`
+	MagicDamage    int64                  `protobuf:"varint,4,opt,name=magic_damage,json=magicDamage,proto3" json:"magic_damage,omitempty"`
+	Buffs          []*Buff                `protobuf:"bytes,5,rep,name=buffs,proto3" json:"buffs,omitempty"`
+	PetStats       []*PetStatsMessage     `protobuf:"bytes,6,rep,name=pet_stats,json=petStats,proto3" json:"pet_stats,omitempty"`                  // 所有在场宠物的属性，下标为 number*active_pets+slot
+	Effectiveness  float32                `protobuf:"fixed32,7,opt,name=effectiveness,proto3" json:"effectiveness,omitempty"`                      // 属性克制倍率，大于1为效果拔群，小于1为效果不佳，0为无效
+	SameElement    bool                   `protobuf:"varint,8,opt,name=same_element,json=sameElement,proto3" json:"same_element,omitempty"`        // 是否获得同属性加成
+	Missed         bool                   `protobuf:"varint,9,opt,name=missed,proto3" json:"missed,omitempty"`                                     // 是否未命中
+	Critical       bool                   `protobuf:"varint,10,opt,name=critical,proto3" json:"critical,omitempty"`                                // 是否暴击
+	Variance       float32                `protobuf:"fixed32,11,opt,name=variance,proto3" json:"variance,omitempty"`                               // 伤害随机浮动倍率
+	BuffDamage     int64                  `protobuf:"varint,12,opt,name=buff_damage,json=buffDamage,proto3" json:"buff_damage,omitempty"`          // 回合开始时buff造成的伤害，此时number为受到伤害的一方，skill_id为0
+	ReflectDamage  int64                  `protobuf:"varint,13,opt,name=reflect_damage,json=reflectDamage,proto3" json:"reflect_damage,omitempty"` // 反弹给攻击方的伤害
+	Heal           int64                  `protobuf:"varint,14,opt,name=heal,proto3" json:"heal,omitempty"`                                        // 目标恢复的生命值，被动技能恢复生命时number为恢复生命的一方
+	Slot           int64                  `protobuf:"varint,15,opt,name=slot,proto3" json:"slot,omitempty"`                                        // number一方宠物的位置
+	Target         *BattleTarget          `protobuf:"bytes,16,opt,name=target,proto3" json:"target,omitempty"`                                     // 受到攻击的宠物
+	ManaRestore    int64                  `protobuf:"varint,17,opt,name=mana_restore,json=manaRestore,proto3" json:"mana_restore,omitempty"`       // 目标恢复的魔力值
+	SelfDamage     int64                  `protobuf:"varint,18,opt,name=self_damage,json=selfDamage,proto3" json:"self_damage,omitempty"`          // 攻击方对自身造成的伤害，例如自爆
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AttackStatsMessage) Reset() {
+	*x = AttackStatsMessage{}
+	mi := &file_shared_packets_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttackStatsMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttackStatsMessage) ProtoMessage() {}
+
+func (x *AttackStatsMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Packet_UseBagItemRequest struct {
-	UseBagItemRequest *UseBagItemRequestMessage `protobuf:"bytes,22,opt,name=use_bag_item_request,json=useBagItemRequest,proto3,oneof"`
+// Deprecated: Use AttackStatsMessage.ProtoReflect.Descriptor instead.
+func (*AttackStatsMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{81}
 }
 
-type Packet_UseBagItemResponse struct {
-	UseBagItemResponse *UseBagItemResponseMessage `protobuf:"bytes,23,opt,name=use_bag_item_response,json=useBagItemResponse,proto3,oneof"`
+func (x *AttackStatsMessage) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
 }
 
-type Packet_UiPacket struct {
-	UiPacket *UiPacket `protobuf:"bytes,24,opt,name=ui_packet,json=uiPacket,proto3,oneof"`
+func (x *AttackStatsMessage) GetSkillId() uint32 {
+	if x != nil {
+		return x.SkillId
+	}
+	return 0
 }
 
-type Packet_GetPet struct {
-	GetPet *GetPetMessage `protobuf:"bytes,25,opt,name=get_pet,json=getPet,proto3,oneof"`
+func (x *AttackStatsMessage) GetPhysicalDamage() int64 {
+	if x != nil {
+		return x.PhysicalDamage
+	}
+	return 0
 }
 
-type Packet_PetBagRequest struct {
-	PetBagRequest *PetBagRequestMessage `protobuf:"bytes,26,opt,name=pet_bag_request,json=petBagRequest,proto3,oneof"`
+func (x *AttackStatsMessage) GetMagicDamage() int64 {
+	if x != nil {
+		return x.MagicDamage
+	}
+	return 0
 }
 
-type Packet_PetBagResponse struct {
-	PetBagResponse *PetBagResponseMessage `protobuf:"bytes,27,opt,name=pet_bag_response,json=petBagResponse,proto3,oneof"`
+func (x *AttackStatsMessage) GetBuffs() []*Buff {
+	if x != nil {
+		return x.Buffs
+	}
+	return nil
 }
 
-type Packet_SavePet struct {
-	SavePet *SavePetMessage `protobuf:"bytes,28,opt,name=save_pet,json=savePet,proto3,oneof"`
+func (x *AttackStatsMessage) GetPetStats() []*PetStatsMessage {
+	if x != nil {
+		return x.PetStats
+	}
+	return nil
+}
+
+func (x *AttackStatsMessage) GetEffectiveness() float32 {
+	if x != nil {
+		return x.Effectiveness
+	}
+	return 0
+}
+
+func (x *AttackStatsMessage) GetSameElement() bool {
+	if x != nil {
+		return x.SameElement
+	}
+	return false
+}
+
+func (x *AttackStatsMessage) GetMissed() bool {
+	if x != nil {
+		return x.Missed
+	}
+	return false
+}
+
+func (x *AttackStatsMessage) GetCritical() bool {
+	if x != nil {
+		return x.Critical
+	}
+	return false
+}
+
+func (x *AttackStatsMessage) GetVariance() float32 {
+	if x != nil {
+		return x.Variance
+	}
+	return 0
+}
+
+func (x *AttackStatsMessage) GetBuffDamage() int64 {
+	if x != nil {
+		return x.BuffDamage
+	}
+	return 0
+}
+
+func (x *AttackStatsMessage) GetReflectDamage() int64 {
+	if x != nil {
+		return x.ReflectDamage
+	}
+	return 0
+}
+
+func (x *AttackStatsMessage) GetHeal() int64 {
+	if x != nil {
+		return x.Heal
+	}
+	return 0
+}
+
+func (x *AttackStatsMessage) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *AttackStatsMessage) GetTarget() *BattleTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *AttackStatsMessage) GetManaRestore() int64 {
+	if x != nil {
+		return x.ManaRestore
+	}
+	return 0
+}
+
+func (x *AttackStatsMessage) GetSelfDamage() int64 {
+	if x != nil {
+		return x.SelfDamage
+	}
+	return 0
+}
+
+type Buff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Level         int64                  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Number        int64                  `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"` // buff所在的一方
+	Rounds        int64                  `protobuf:"varint,4,opt,name=rounds,proto3" json:"rounds,omitempty"` // 剩余回合数
+	Slot          int64                  `protobuf:"varint,5,opt,name=slot,proto3" json:"slot,omitempty"`     // buff所在的宠物位置
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Buff) Reset() {
+	*x = Buff{}
+	mi := &file_shared_packets_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Buff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Buff) ProtoMessage() {}
+
+func (x *Buff) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Buff.ProtoReflect.Descriptor instead.
+func (*Buff) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *Buff) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Buff) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Buff) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Buff) GetRounds() int64 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *Buff) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+// 战斗结束后的结算，在BattleEndMessage之前发送，number为接收结算的一方
+type BattleEndStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Win           bool                   `protobuf:"varint,2,opt,name=win,proto3" json:"win,omitempty"`
+	Pets          []*PetEndStats         `protobuf:"bytes,3,rep,name=pets,proto3" json:"pets,omitempty"`
+	Items         []*ItemMessage         `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	PetItems      []*PetItemMessage      `protobuf:"bytes,5,rep,name=pet_items,json=petItems,proto3" json:"pet_items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BattleEndStats) Reset() {
+	*x = BattleEndStats{}
+	mi := &file_shared_packets_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BattleEndStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BattleEndStats) ProtoMessage() {}
+
+func (x *BattleEndStats) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BattleEndStats.ProtoReflect.Descriptor instead.
+func (*BattleEndStats) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *BattleEndStats) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *BattleEndStats) GetWin() bool {
+	if x != nil {
+		return x.Win
+	}
+	return false
+}
+
+func (x *BattleEndStats) GetPets() []*PetEndStats {
+	if x != nil {
+		return x.Pets
+	}
+	return nil
+}
+
+func (x *BattleEndStats) GetItems() []*ItemMessage {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BattleEndStats) GetPetItems() []*PetItemMessage {
+	if x != nil {
+		return x.PetItems
+	}
+	return nil
+}
+
+// 参战宠物获得的经验
+type PetEndStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PetId         uint32                 `protobuf:"varint,2,opt,name=pet_id,json=petId,proto3" json:"pet_id,omitempty"`
+	Exp           int64                  `protobuf:"varint,3,opt,name=exp,proto3" json:"exp,omitempty"`
+	Level         int64                  `protobuf:"varint,4,opt,name=level,proto3" json:"level,omitempty"` // 结算后的等级
+	LevelUps      int64                  `protobuf:"varint,5,opt,name=level_ups,json=levelUps,proto3" json:"level_ups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Packet_LearnSkillRequest struct {
-	LearnSkillRequest *LearnSkillRequestMessage `protobuf:"bytes,29,opt,name=learn_skill_request,json=learnSkillRequest,proto3,oneof"`
+func (x *PetEndStats) Reset() {
+	*x = PetEndStats{}
+	mi := &file_shared_packets_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Packet_LearnSkillResponse struct {
-	LearnSkillResponse *LearnSkillResponseMessage `protobuf:"bytes,30,opt,name=learn_skill_response,json=learnSkillResponse,proto3,oneof"`
+func (x *PetEndStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Packet_AddPetItem struct {
-	AddPetItem *AddPetItemMessage `protobuf:"bytes,31,opt,name=add_pet_item,json=addPetItem,proto3,oneof"`
-}
+func (*PetEndStats) ProtoMessage() {}
 
-type Packet_DeletePetItem struct {
-	DeletePetItem *DeletePetItemMessage `protobuf:"bytes,32,opt,name=delete_pet_item,json=deletePetItem,proto3,oneof"`
+func (x *PetEndStats) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Packet_PetItemBagRequest struct {
-	PetItemBagRequest *PetItemBagRequestMessage `protobuf:"bytes,33,opt,name=pet_item_bag_request,json=petItemBagRequest,proto3,oneof"`
+// Deprecated: Use PetEndStats.ProtoReflect.Descriptor instead.
+func (*PetEndStats) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{84}
 }
 
-type Packet_UsePetItemRequest struct {
-	UsePetItemRequest *UsePetItemRequestMessage `protobuf:"bytes,34,opt,name=use_pet_item_request,json=usePetItemRequest,proto3,oneof"`
+func (x *PetEndStats) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
 }
 
-type Packet_UsePetItemResponse struct {
-	UsePetItemResponse *UsePetItemResponseMessage `protobuf:"bytes,35,opt,name=use_pet_item_response,json=usePetItemResponse,proto3,oneof"`
+func (x *PetEndStats) GetPetId() uint32 {
+	if x != nil {
+		return x.PetId
+	}
+	return 0
 }
 
-type Packet_PetItemBagResponse struct {
-	PetItemBagResponse *PetItemBagResponseMessage `protobuf:"bytes,36,opt,name=pet_item_bag_response,json=petItemBagResponse,proto3,oneof"`
+func (x *PetEndStats) GetExp() int64 {
+	if x != nil {
+		return x.Exp
+	}
+	return 0
 }
 
-type Packet_EquippedPetInfoRequest struct {
-	EquippedPetInfoRequest *EquippedPetInfoRequestMessage `protobuf:"bytes,37,opt,name=equipped_pet_info_request,json=equippedPetInfoRequest,proto3,oneof"`
+func (x *PetEndStats) GetLevel() int64 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
 }
 
-type Packet_EquippedPetInfoResponse struct {
-	EquippedPetInfoResponse *EquippedPetInfoResponseMessage `protobuf:"bytes,38,opt,name=equipped_pet_info_response,json=equippedPetInfoResponse,proto3,oneof"`
+func (x *PetEndStats) GetLevelUps() int64 {
+	if x != nil {
+		return x.LevelUps
+	}
+	return 0
 }
 
-type Packet_BattlePacket struct {
-	BattlePacket *BattlePacket `protobuf:"bytes,39,opt,name=battle_packet,json=battlePacket,proto3,oneof"`
+type DenyCommandMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Packet_BattleRequest struct {
-	BattleRequest *BattleRequestMessage `protobuf:"bytes,40,opt,name=battle_request,json=battleRequest,proto3,oneof"`
+func (x *DenyCommandMessage) Reset() {
+	*x = DenyCommandMessage{}
+	mi := &file_shared_packets_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Packet_BattleInvitingResponse struct {
-	BattleInvitingResponse *BattleInvitingResponseMessage `protobuf:"bytes,41,opt,name=battle_inviting_response,json=battleInvitingResponse,proto3,oneof"`
+func (x *DenyCommandMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Packet_BattleInviting struct {
-	BattleInviting *BattleInvitingMessage `protobuf:"bytes,42,opt,name=battle_inviting,json=battleInviting,proto3,oneof"`
-}
+func (*DenyCommandMessage) ProtoMessage() {}
 
-type Packet_StartBattle struct {
-	StartBattle *StartBattleMessage `protobuf:"bytes,43,opt,name=start_battle,json=startBattle,proto3,oneof"`
+func (x *DenyCommandMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Packet_GetAreaRequest struct {
-	GetAreaRequest *GetAreaRequest `protobuf:"bytes,44,opt,name=get_area_request,json=getAreaRequest,proto3,oneof"`
+// Deprecated: Use DenyCommandMessage.ProtoReflect.Descriptor instead.
+func (*DenyCommandMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{85}
 }
 
-type Packet_SyncState struct {
-	SyncState *SyncState `protobuf:"bytes,45,opt,name=sync_state,json=syncState,proto3,oneof"`
+func (x *DenyCommandMessage) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-type Packet_GetAreaNpcs struct {
-	GetAreaNpcs *GetAreaNPCsMessage `protobuf:"bytes,46,opt,name=get_area_npcs,json=getAreaNpcs,proto3,oneof"`
+type StartNextRoundMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timeout       int64                  `protobuf:"varint,1,opt,name=timeout,proto3" json:"timeout,omitempty"` // 本回合等待指令的时间，单位为秒，超时后按照战斗模式执行默认行动
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Packet_InteractNpcRequest struct {
-	InteractNpcRequest *InteractNPCRequestMessage `protobuf:"bytes,47,opt,name=interact_npc_request,json=interactNpcRequest,proto3,oneof"`
+func (x *StartNextRoundMessage) Reset() {
+	*x = StartNextRoundMessage{}
+	mi := &file_shared_packets_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Packet_NpcInteract struct {
-	NpcInteract *NPCInteractPacket `protobuf:"bytes,48,opt,name=npc_interact,json=npcInteract,proto3,oneof"`
+func (x *StartNextRoundMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Packet_LoginRequest) isPacket_Msg() {}
-
-func (*Packet_RegisterRequest) isPacket_Msg() {}
-
-func (*Packet_OkResponse) isPacket_Msg() {}
-
-func (*Packet_DenyResponse) isPacket_Msg() {}
-
-func (*Packet_LoginSuccess) isPacket_Msg() {}
-
-func (*Packet_PlayerEnter) isPacket_Msg() {}
-
-func (*Packet_PlayerLeave) isPacket_Msg() {}
-
-func (*Packet_PlayerMovement) isPacket_Msg() {}
-
-func (*Packet_PlayerEnterRequest) isPacket_Msg() {}
-
-func (*Packet_Chat) isPacket_Msg() {}
-
-func (*Packet_PlayerEnterAreaResponse) isPacket_Msg() {}
-
-func (*Packet_Mail) isPacket_Msg() {}
-
-func (*Packet_MailRequest) isPacket_Msg() {}
-
-func (*Packet_MailCollect) isPacket_Msg() {}
-
-func (*Packet_MailDelete) isPacket_Msg() {}
-
-func (*Packet_MailCollectResponse) isPacket_Msg() {}
-
-func (*Packet_BagRequest) isPacket_Msg() {}
-
-func (*Packet_Bag) isPacket_Msg() {}
-
-func (*Packet_AddBagItem) isPacket_Msg() {}
-
-func (*Packet_DeleteBagItem) isPacket_Msg() {}
-
-func (*Packet_UseBagItemRequest) isPacket_Msg() {}
-
-func (*Packet_UseBagItemResponse) isPacket_Msg() {}
-
-func (*Packet_UiPacket) isPacket_Msg() {}
-
-func (*Packet_GetPet) isPacket_Msg() {}
-
-func (*Packet_PetBagRequest) isPacket_Msg() {}
-
-func (*Packet_PetBagResponse) isPacket_Msg() {}
-
-func (*Packet_SavePet) isPacket_Msg() {}
-
-func (*Packet_LearnSkillRequest) isPacket_Msg() {}
-
-func (*Packet_LearnSkillResponse) isPacket_Msg() {}
-
-func (*Packet_AddPetItem) isPacket_Msg() {}
-
-func (*Packet_DeletePetItem) isPacket_Msg() {}
+func (*StartNextRoundMessage) ProtoMessage() {}
 
-func (*Packet_PetItemBagRequest) isPacket_Msg() {}
+func (x *StartNextRoundMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Packet_UsePetItemRequest) isPacket_Msg() {}
+// Deprecated: Use StartNextRoundMessage.ProtoReflect.Descriptor instead.
+func (*StartNextRoundMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{86}
+}
 
-func (*Packet_UsePetItemResponse) isPacket_Msg() {}
+func (x *StartNextRoundMessage) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
 
-func (*Packet_PetItemBagResponse) isPacket_Msg() {}
+type BattleEndMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Winner        int64                  `protobuf:"varint,1,opt,name=winner,proto3" json:"winner,omitempty"` // 获胜的队伍，单打时即获胜一方的编号
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-func (*Packet_EquippedPetInfoRequest) isPacket_Msg() {}
+func (x *BattleEndMessage) Reset() {
+	*x = BattleEndMessage{}
+	mi := &file_shared_packets_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (*Packet_EquippedPetInfoResponse) isPacket_Msg() {}
+func (x *BattleEndMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*Packet_BattlePacket) isPacket_Msg() {}
+func (*BattleEndMessage) ProtoMessage() {}
 
-func (*Packet_BattleRequest) isPacket_Msg() {}
+func (x *BattleEndMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Packet_BattleInvitingResponse) isPacket_Msg() {}
+// Deprecated: Use BattleEndMessage.ProtoReflect.Descriptor instead.
+func (*BattleEndMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{87}
+}
 
-func (*Packet_BattleInviting) isPacket_Msg() {}
+func (x *BattleEndMessage) GetWinner() int64 {
+	if x != nil {
+		return x.Winner
+	}
+	return 0
+}
 
-func (*Packet_StartBattle) isPacket_Msg() {}
+// 当客户端回合播放完毕后发送此消息
+type RoundConfirmMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-func (*Packet_GetAreaRequest) isPacket_Msg() {}
+func (x *RoundConfirmMessage) Reset() {
+	*x = RoundConfirmMessage{}
+	mi := &file_shared_packets_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (*Packet_SyncState) isPacket_Msg() {}
+func (x *RoundConfirmMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*Packet_GetAreaNpcs) isPacket_Msg() {}
+func (*RoundConfirmMessage) ProtoMessage() {}
 
-func (*Packet_InteractNpcRequest) isPacket_Msg() {}
+func (x *RoundConfirmMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Packet_NpcInteract) isPacket_Msg() {}
+// Deprecated: Use RoundConfirmMessage.ProtoReflect.Descriptor instead.
+func (*RoundConfirmMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{88}
+}
 
-type UiPacket struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*UiPacket_OpenUi
-	//	*UiPacket_InitialPetRequest
-	Msg           isUiPacket_Msg `protobuf_oneof:"msg"`
+// 更换宠物请求
+type ChangePetRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slot          int64                  `protobuf:"varint,1,opt,name=slot,proto3" json:"slot,omitempty"`       // 需要更换的宠物位置
+	Timeout       int64                  `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"` // 等待更换的时间，单位为秒，超时后自动选择
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UiPacket) Reset() {
-	*x = UiPacket{}
-	mi := &file_shared_packets_proto_msgTypes[50]
+func (x *ChangePetRequestMessage) Reset() {
+	*x = ChangePetRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UiPacket) String() string {
+func (x *ChangePetRequestMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UiPacket) ProtoMessage() {}
+func (*ChangePetRequestMessage) ProtoMessage() {}
 
-func (x *UiPacket) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[50]
+func (x *ChangePetRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3414,74 +6430,103 @@ func (x *UiPacket) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UiPacket.ProtoReflect.Descriptor instead.
-func (*UiPacket) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{50}
+// Deprecated: Use ChangePetRequestMessage.ProtoReflect.Descriptor instead.
+func (*ChangePetRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *UiPacket) GetMsg() isUiPacket_Msg {
+func (x *ChangePetRequestMessage) GetSlot() int64 {
 	if x != nil {
-		return x.Msg
+		return x.Slot
 	}
-	return nil
+	return 0
 }
 
-func (x *UiPacket) GetOpenUi() *OpenUIMessage {
+func (x *ChangePetRequestMessage) GetTimeout() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*UiPacket_OpenUi); ok {
-			return x.OpenUi
-		}
+		return x.Timeout
 	}
-	return nil
+	return 0
 }
 
-func (x *UiPacket) GetInitialPetRequest() *InitialPetRequestMessage {
+// 更换宠物
+type ChangePetResponseMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PetPosition   int64                  `protobuf:"varint,2,opt,name=pet_position,json=petPosition,proto3" json:"pet_position,omitempty"`
+	Slot          int64                  `protobuf:"varint,3,opt,name=slot,proto3" json:"slot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangePetResponseMessage) Reset() {
+	*x = ChangePetResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePetResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePetResponseMessage) ProtoMessage() {}
+
+func (x *ChangePetResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[90]
 	if x != nil {
-		if x, ok := x.Msg.(*UiPacket_InitialPetRequest); ok {
-			return x.InitialPetRequest
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type isUiPacket_Msg interface {
-	isUiPacket_Msg()
+// Deprecated: Use ChangePetResponseMessage.ProtoReflect.Descriptor instead.
+func (*ChangePetResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{90}
 }
 
-type UiPacket_OpenUi struct {
-	OpenUi *OpenUIMessage `protobuf:"bytes,1,opt,name=open_ui,json=openUi,proto3,oneof"`
+func (x *ChangePetResponseMessage) GetPetPosition() int64 {
+	if x != nil {
+		return x.PetPosition
+	}
+	return 0
 }
 
-type UiPacket_InitialPetRequest struct {
-	InitialPetRequest *InitialPetRequestMessage `protobuf:"bytes,2,opt,name=initial_pet_request,json=initialPetRequest,proto3,oneof"`
+func (x *ChangePetResponseMessage) GetSlot() int64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
 }
 
-func (*UiPacket_OpenUi) isUiPacket_Msg() {}
-
-func (*UiPacket_InitialPetRequest) isUiPacket_Msg() {}
-
-type OpenUIMessage struct {
+// 同步双方的信息
+type SyncBattleInformationMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	PlayerName    string                 `protobuf:"bytes,2,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	PetMessages   []*PetMessage          `protobuf:"bytes,3,rep,name=pet_messages,json=petMessages,proto3" json:"pet_messages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OpenUIMessage) Reset() {
-	*x = OpenUIMessage{}
-	mi := &file_shared_packets_proto_msgTypes[51]
+func (x *SyncBattleInformationMessage) Reset() {
+	*x = SyncBattleInformationMessage{}
+	mi := &file_shared_packets_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OpenUIMessage) String() string {
+func (x *SyncBattleInformationMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenUIMessage) ProtoMessage() {}
+func (*SyncBattleInformationMessage) ProtoMessage() {}
 
-func (x *OpenUIMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[51]
+func (x *SyncBattleInformationMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3492,40 +6537,53 @@ func (x *OpenUIMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OpenUIMessage.ProtoReflect.Descriptor instead.
-func (*OpenUIMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use SyncBattleInformationMessage.ProtoReflect.Descriptor instead.
+func (*SyncBattleInformationMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *OpenUIMessage) GetPath() string {
+func (x *SyncBattleInformationMessage) GetNumber() int64 {
 	if x != nil {
-		return x.Path
+		return x.Number
+	}
+	return 0
+}
+
+func (x *SyncBattleInformationMessage) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
 	}
 	return ""
 }
 
-type InitialPetRequestMessage struct {
+func (x *SyncBattleInformationMessage) GetPetMessages() []*PetMessage {
+	if x != nil {
+		return x.PetMessages
+	}
+	return nil
+}
+
+type RoundEndMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RequestId     uint32                 `protobuf:"varint,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InitialPetRequestMessage) Reset() {
-	*x = InitialPetRequestMessage{}
-	mi := &file_shared_packets_proto_msgTypes[52]
+func (x *RoundEndMessage) Reset() {
+	*x = RoundEndMessage{}
+	mi := &file_shared_packets_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InitialPetRequestMessage) String() string {
+func (x *RoundEndMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitialPetRequestMessage) ProtoMessage() {}
+func (*RoundEndMessage) ProtoMessage() {}
 
-func (x *InitialPetRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[52]
+func (x *RoundEndMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3536,44 +6594,36 @@ func (x *InitialPetRequestMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitialPetRequestMessage.ProtoReflect.Descriptor instead.
-func (*InitialPetRequestMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{52}
-}
-
-func (x *InitialPetRequestMessage) GetRequestId() uint32 {
-	if x != nil {
-		return x.RequestId
-	}
-	return 0
+// Deprecated: Use RoundEndMessage.ProtoReflect.Descriptor instead.
+func (*RoundEndMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{92}
 }
 
-type NPCInteractPacket struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*NPCInteractPacket_Heal
-	//	*NPCInteractPacket_InitialVillageHeader
-	Msg           isNPCInteractPacket_Msg `protobuf_oneof:"msg"`
+// 对方掉线时通知，policy 0=认输，1=AI托管，2=等待重连，timeout为等待重连的秒数
+type PlayerDisconnectedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Policy        uint32                 `protobuf:"varint,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	Timeout       int64                  `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NPCInteractPacket) Reset() {
-	*x = NPCInteractPacket{}
-	mi := &file_shared_packets_proto_msgTypes[53]
+func (x *PlayerDisconnectedMessage) Reset() {
+	*x = PlayerDisconnectedMessage{}
+	mi := &file_shared_packets_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NPCInteractPacket) String() string {
+func (x *PlayerDisconnectedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NPCInteractPacket) ProtoMessage() {}
+func (*PlayerDisconnectedMessage) ProtoMessage() {}
 
-func (x *NPCInteractPacket) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[53]
+func (x *PlayerDisconnectedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3584,73 +6634,58 @@ func (x *NPCInteractPacket) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NPCInteractPacket.ProtoReflect.Descriptor instead.
-func (*NPCInteractPacket) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{53}
+// Deprecated: Use PlayerDisconnectedMessage.ProtoReflect.Descriptor instead.
+func (*PlayerDisconnectedMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *NPCInteractPacket) GetMsg() isNPCInteractPacket_Msg {
+func (x *PlayerDisconnectedMessage) GetNumber() int64 {
 	if x != nil {
-		return x.Msg
+		return x.Number
 	}
-	return nil
+	return 0
 }
 
-func (x *NPCInteractPacket) GetHeal() *HealMessage {
+func (x *PlayerDisconnectedMessage) GetPolicy() uint32 {
 	if x != nil {
-		if x, ok := x.Msg.(*NPCInteractPacket_Heal); ok {
-			return x.Heal
-		}
+		return x.Policy
 	}
-	return nil
+	return 0
 }
 
-func (x *NPCInteractPacket) GetInitialVillageHeader() *InitialVillageHeaderMessage {
+func (x *PlayerDisconnectedMessage) GetTimeout() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*NPCInteractPacket_InitialVillageHeader); ok {
-			return x.InitialVillageHeader
-		}
+		return x.Timeout
 	}
-	return nil
-}
-
-type isNPCInteractPacket_Msg interface {
-	isNPCInteractPacket_Msg()
-}
-
-type NPCInteractPacket_Heal struct {
-	Heal *HealMessage `protobuf:"bytes,1,opt,name=heal,proto3,oneof"`
-}
-
-type NPCInteractPacket_InitialVillageHeader struct {
-	InitialVillageHeader *InitialVillageHeaderMessage `protobuf:"bytes,2,opt,name=initial_village_header,json=initialVillageHeader,proto3,oneof"`
+	return 0
 }
 
-func (*NPCInteractPacket_Heal) isNPCInteractPacket_Msg() {}
-
-func (*NPCInteractPacket_InitialVillageHeader) isNPCInteractPacket_Msg() {}
-
-type HealMessage struct {
+// 捕捉结果，number为使用道具的一方
+type CaptureResultMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	ItemId        uint32                 `protobuf:"varint,2,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Chance        float32                `protobuf:"fixed32,4,opt,name=chance,proto3" json:"chance,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealMessage) Reset() {
-	*x = HealMessage{}
-	mi := &file_shared_packets_proto_msgTypes[54]
+func (x *CaptureResultMessage) Reset() {
+	*x = CaptureResultMessage{}
+	mi := &file_shared_packets_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealMessage) String() string {
+func (x *CaptureResultMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealMessage) ProtoMessage() {}
+func (*CaptureResultMessage) ProtoMessage() {}
 
-func (x *HealMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[54]
+func (x *CaptureResultMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3661,37 +6696,66 @@ func (x *HealMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealMessage.ProtoReflect.Descriptor instead.
-func (*HealMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{54}
+// Deprecated: Use CaptureResultMessage.ProtoReflect.Descriptor instead.
+func (*CaptureResultMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{94}
 }
 
-type InitialVillageHeaderMessage struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Section:
-	//
-	//	*InitialVillageHeaderMessage_NewRewardRequest
-	//	*InitialVillageHeaderMessage_UpdateInfo
-	Section       isInitialVillageHeaderMessage_Section `protobuf_oneof:"section"`
+func (x *CaptureResultMessage) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *CaptureResultMessage) GetItemId() uint32 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *CaptureResultMessage) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CaptureResultMessage) GetChance() float32 {
+	if x != nil {
+		return x.Chance
+	}
+	return 0
+}
+
+// 观战者加入时的战斗快照，current_pets为所有在场宠物在队伍中的位置，下标为 number*active_pets+slot
+type BattleSnapshotMessage struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Teams         []*SyncBattleInformationMessage `protobuf:"bytes,1,rep,name=teams,proto3" json:"teams,omitempty"`
+	CurrentPets   []int64                         `protobuf:"varint,2,rep,packed,name=current_pets,json=currentPets,proto3" json:"current_pets,omitempty"`
+	Buffs         []*Buff                         `protobuf:"bytes,3,rep,name=buffs,proto3" json:"buffs,omitempty"`
+	Round         int64                           `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+	Format        *BattleFormatMessage            `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *InitialVillageHeaderMessage) Reset() {
-	*x = InitialVillageHeaderMessage{}
-	mi := &file_shared_packets_proto_msgTypes[55]
+func (x *BattleSnapshotMessage) Reset() {
+	*x = BattleSnapshotMessage{}
+	mi := &file_shared_packets_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *InitialVillageHeaderMessage) String() string {
+func (x *BattleSnapshotMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InitialVillageHeaderMessage) ProtoMessage() {}
+func (*BattleSnapshotMessage) ProtoMessage() {}
 
-func (x *InitialVillageHeaderMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[55]
+func (x *BattleSnapshotMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3702,73 +6766,70 @@ func (x *InitialVillageHeaderMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InitialVillageHeaderMessage.ProtoReflect.Descriptor instead.
-func (*InitialVillageHeaderMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use BattleSnapshotMessage.ProtoReflect.Descriptor instead.
+func (*BattleSnapshotMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *InitialVillageHeaderMessage) GetSection() isInitialVillageHeaderMessage_Section {
+func (x *BattleSnapshotMessage) GetTeams() []*SyncBattleInformationMessage {
 	if x != nil {
-		return x.Section
+		return x.Teams
 	}
 	return nil
 }
 
-func (x *InitialVillageHeaderMessage) GetNewRewardRequest() *NewRewardRequest {
+func (x *BattleSnapshotMessage) GetCurrentPets() []int64 {
 	if x != nil {
-		if x, ok := x.Section.(*InitialVillageHeaderMessage_NewRewardRequest); ok {
-			return x.NewRewardRequest
-		}
+		return x.CurrentPets
 	}
 	return nil
 }
 
-func (x *InitialVillageHeaderMessage) GetUpdateInfo() *UpdateInitialVillageHeaderUIInfo {
+func (x *BattleSnapshotMessage) GetBuffs() []*Buff {
 	if x != nil {
-		if x, ok := x.Section.(*InitialVillageHeaderMessage_UpdateInfo); ok {
-			return x.UpdateInfo
-		}
+		return x.Buffs
 	}
 	return nil
 }
 
-type isInitialVillageHeaderMessage_Section interface {
-	isInitialVillageHeaderMessage_Section()
-}
-
-type InitialVillageHeaderMessage_NewRewardRequest struct {
-	NewRewardRequest *NewRewardRequest `protobuf:"bytes,1,opt,name=new_reward_request,json=newRewardRequest,proto3,oneof"`
+func (x *BattleSnapshotMessage) GetRound() int64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
 }
 
-type InitialVillageHeaderMessage_UpdateInfo struct {
-	UpdateInfo *UpdateInitialVillageHeaderUIInfo `protobuf:"bytes,2,opt,name=update_info,json=updateInfo,proto3,oneof"`
+func (x *BattleSnapshotMessage) GetFormat() *BattleFormatMessage {
+	if x != nil {
+		return x.Format
+	}
+	return nil
 }
 
-func (*InitialVillageHeaderMessage_NewRewardRequest) isInitialVillageHeaderMessage_Section() {}
-
-func (*InitialVillageHeaderMessage_UpdateInfo) isInitialVillageHeaderMessage_Section() {}
-
-type NewRewardRequest struct {
+// 战斗的赛制，teams为每一方所属的队伍，active_pets为每一方同时在场的宠物数量
+type BattleFormatMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teams         []int64                `protobuf:"varint,1,rep,packed,name=teams,proto3" json:"teams,omitempty"`
+	ActivePets    int64                  `protobuf:"varint,2,opt,name=active_pets,json=activePets,proto3" json:"active_pets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NewRewardRequest) Reset() {
-	*x = NewRewardRequest{}
-	mi := &file_shared_packets_proto_msgTypes[56]
+func (x *BattleFormatMessage) Reset() {
+	*x = BattleFormatMessage{}
+	mi := &file_shared_packets_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NewRewardRequest) String() string {
+func (x *BattleFormatMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NewRewardRequest) ProtoMessage() {}
+func (*BattleFormatMessage) ProtoMessage() {}
 
-func (x *NewRewardRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[56]
+func (x *BattleFormatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3779,34 +6840,50 @@ func (x *NewRewardRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NewRewardRequest.ProtoReflect.Descriptor instead.
-func (*NewRewardRequest) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{56}
+// Deprecated: Use BattleFormatMessage.ProtoReflect.Descriptor instead.
+func (*BattleFormatMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{96}
 }
 
-type UpdateInitialVillageHeaderUIInfo struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	CanGetNewReward bool                   `protobuf:"varint,1,opt,name=can_get_new_reward,json=canGetNewReward,proto3" json:"can_get_new_reward,omitempty"`
-	CanChallenge    bool                   `protobuf:"varint,2,opt,name=can_challenge,json=canChallenge,proto3" json:"can_challenge,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *BattleFormatMessage) GetTeams() []int64 {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
 }
 
-func (x *UpdateInitialVillageHeaderUIInfo) Reset() {
-	*x = UpdateInitialVillageHeaderUIInfo{}
-	mi := &file_shared_packets_proto_msgTypes[57]
+func (x *BattleFormatMessage) GetActivePets() int64 {
+	if x != nil {
+		return x.ActivePets
+	}
+	return 0
+}
+
+// 一方更换了指定位置的在场宠物
+type PetSwitchedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Slot          int64                  `protobuf:"varint,2,opt,name=slot,proto3" json:"slot,omitempty"`
+	PetPosition   int64                  `protobuf:"varint,3,opt,name=pet_position,json=petPosition,proto3" json:"pet_position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PetSwitchedMessage) Reset() {
+	*x = PetSwitchedMessage{}
+	mi := &file_shared_packets_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateInitialVillageHeaderUIInfo) String() string {
+func (x *PetSwitchedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateInitialVillageHeaderUIInfo) ProtoMessage() {}
+func (*PetSwitchedMessage) ProtoMessage() {}
 
-func (x *UpdateInitialVillageHeaderUIInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[57]
+func (x *PetSwitchedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3817,60 +6894,55 @@ func (x *UpdateInitialVillageHeaderUIInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateInitialVillageHeaderUIInfo.ProtoReflect.Descriptor instead.
-func (*UpdateInitialVillageHeaderUIInfo) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{57}
+// Deprecated: Use PetSwitchedMessage.ProtoReflect.Descriptor instead.
+func (*PetSwitchedMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *UpdateInitialVillageHeaderUIInfo) GetCanGetNewReward() bool {
+func (x *PetSwitchedMessage) GetNumber() int64 {
 	if x != nil {
-		return x.CanGetNewReward
+		return x.Number
 	}
-	return false
+	return 0
 }
 
-func (x *UpdateInitialVillageHeaderUIInfo) GetCanChallenge() bool {
+func (x *PetSwitchedMessage) GetSlot() int64 {
 	if x != nil {
-		return x.CanChallenge
+		return x.Slot
 	}
-	return false
+	return 0
 }
 
-// --------------------------------------战斗系统------------------------
-type BattlePacket struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*BattlePacket_Command
-	//	*BattlePacket_AttackStats
-	//	*BattlePacket_DenyCommand
-	//	*BattlePacket_StartNextRound
-	//	*BattlePacket_BattleEnd
-	//	*BattlePacket_RoundConfirm
-	//	*BattlePacket_ChangePet
-	//	*BattlePacket_ChangePetRequest
-	//	*BattlePacket_SyncBattleInformation
-	//	*BattlePacket_RoundEnd
-	Msg           isBattlePacket_Msg `protobuf_oneof:"msg"`
+func (x *PetSwitchedMessage) GetPetPosition() int64 {
+	if x != nil {
+		return x.PetPosition
+	}
+	return 0
+}
+
+// 对方重连成功
+type PlayerReconnectedMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattlePacket) Reset() {
-	*x = BattlePacket{}
-	mi := &file_shared_packets_proto_msgTypes[58]
+func (x *PlayerReconnectedMessage) Reset() {
+	*x = PlayerReconnectedMessage{}
+	mi := &file_shared_packets_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattlePacket) String() string {
+func (x *PlayerReconnectedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattlePacket) ProtoMessage() {}
+func (*PlayerReconnectedMessage) ProtoMessage() {}
 
-func (x *BattlePacket) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[58]
+func (x *PlayerReconnectedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3881,199 +6953,285 @@ func (x *BattlePacket) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattlePacket.ProtoReflect.Descriptor instead.
-func (*BattlePacket) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{58}
+// Deprecated: Use PlayerReconnectedMessage.ProtoReflect.Descriptor instead.
+func (*PlayerReconnectedMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *BattlePacket) GetMsg() isBattlePacket_Msg {
+func (x *PlayerReconnectedMessage) GetNumber() int64 {
 	if x != nil {
-		return x.Msg
+		return x.Number
 	}
-	return nil
+	return 0
 }
 
-func (x *BattlePacket) GetCommand() *RoundCommandMessage {
+// ---------------------------------排位--------------------------------
+type RankEntryMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int64                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Uid           uint32                 `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	UserName      string                 `protobuf:"bytes,3,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Rating        int64                  `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Wins          int64                  `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int64                  `protobuf:"varint,6,opt,name=losses,proto3" json:"losses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankEntryMessage) Reset() {
+	*x = RankEntryMessage{}
+	mi := &file_shared_packets_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankEntryMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankEntryMessage) ProtoMessage() {}
+
+func (x *RankEntryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[99]
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_Command); ok {
-			return x.Command
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *BattlePacket) GetAttackStats() *AttackStatsMessage {
+// Deprecated: Use RankEntryMessage.ProtoReflect.Descriptor instead.
+func (*RankEntryMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *RankEntryMessage) GetRank() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_AttackStats); ok {
-			return x.AttackStats
-		}
+		return x.Rank
 	}
-	return nil
+	return 0
 }
 
-func (x *BattlePacket) GetDenyCommand() *DenyCommandMessage {
+func (x *RankEntryMessage) GetUid() uint32 {
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_DenyCommand); ok {
-			return x.DenyCommand
-		}
+		return x.Uid
 	}
-	return nil
+	return 0
 }
 
-func (x *BattlePacket) GetStartNextRound() *StartNextRoundMessage {
+func (x *RankEntryMessage) GetUserName() string {
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_StartNextRound); ok {
-			return x.StartNextRound
-		}
+		return x.UserName
 	}
-	return nil
+	return ""
 }
 
-func (x *BattlePacket) GetBattleEnd() *BattleEndMessage {
+func (x *RankEntryMessage) GetRating() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_BattleEnd); ok {
-			return x.BattleEnd
-		}
+		return x.Rating
 	}
-	return nil
+	return 0
 }
 
-func (x *BattlePacket) GetRoundConfirm() *RoundConfirmMessage {
+func (x *RankEntryMessage) GetWins() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_RoundConfirm); ok {
-			return x.RoundConfirm
-		}
+		return x.Wins
 	}
-	return nil
+	return 0
 }
 
-func (x *BattlePacket) GetChangePet() *ChangePetResponseMessage {
+func (x *RankEntryMessage) GetLosses() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_ChangePet); ok {
-			return x.ChangePet
-		}
+		return x.Losses
 	}
-	return nil
+	return 0
 }
 
-func (x *BattlePacket) GetChangePetRequest() *ChangePetRequestMessage {
+// type 0=排行榜前count名，1=自己前后各count名
+type RankListRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          uint32                 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankListRequestMessage) Reset() {
+	*x = RankListRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankListRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankListRequestMessage) ProtoMessage() {}
+
+func (x *RankListRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[100]
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_ChangePetRequest); ok {
-			return x.ChangePetRequest
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *BattlePacket) GetSyncBattleInformation() *SyncBattleInformationMessage {
+// Deprecated: Use RankListRequestMessage.ProtoReflect.Descriptor instead.
+func (*RankListRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *RankListRequestMessage) GetType() uint32 {
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_SyncBattleInformation); ok {
-			return x.SyncBattleInformation
-		}
+		return x.Type
 	}
-	return nil
+	return 0
 }
 
-func (x *BattlePacket) GetRoundEnd() *RoundEndMessage {
+func (x *RankListRequestMessage) GetCount() int64 {
 	if x != nil {
-		if x, ok := x.Msg.(*BattlePacket_RoundEnd); ok {
-			return x.RoundEnd
-		}
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-type isBattlePacket_Msg interface {
-	isBattlePacket_Msg()
+type RankListResponseMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Season        uint32                 `protobuf:"varint,1,opt,name=season,proto3" json:"season,omitempty"`
+	Entries       []*RankEntryMessage    `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Self          *RankEntryMessage      `protobuf:"bytes,3,opt,name=self,proto3" json:"self,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankListResponseMessage) Reset() {
+	*x = RankListResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankListResponseMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankListResponseMessage) ProtoMessage() {}
+
+func (x *RankListResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type BattlePacket_Command struct {
-	Command *RoundCommandMessage `protobuf:"bytes,1,opt,name=command,proto3,oneof"`
+// Deprecated: Use RankListResponseMessage.ProtoReflect.Descriptor instead.
+func (*RankListResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{101}
 }
 
-type BattlePacket_AttackStats struct {
-	AttackStats *AttackStatsMessage `protobuf:"bytes,2,opt,name=attack_stats,json=attackStats,proto3,oneof"`
+func (x *RankListResponseMessage) GetSeason() uint32 {
+	if x != nil {
+		return x.Season
+	}
+	return 0
 }
 
-type BattlePacket_DenyCommand struct {
-	DenyCommand *DenyCommandMessage `protobuf:"bytes,3,opt,name=deny_command,json=denyCommand,proto3,oneof"`
+func (x *RankListResponseMessage) GetEntries() []*RankEntryMessage {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
 }
 
-type BattlePacket_StartNextRound struct {
-	StartNextRound *StartNextRoundMessage `protobuf:"bytes,4,opt,name=start_next_round,json=startNextRound,proto3,oneof"`
+func (x *RankListResponseMessage) GetSelf() *RankEntryMessage {
+	if x != nil {
+		return x.Self
+	}
+	return nil
 }
 
-type BattlePacket_BattleEnd struct {
-	BattleEnd *BattleEndMessage `protobuf:"bytes,5,opt,name=battle_end,json=battleEnd,proto3,oneof"`
+type BattleHistoryRequestMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type BattlePacket_RoundConfirm struct {
-	RoundConfirm *RoundConfirmMessage `protobuf:"bytes,6,opt,name=round_confirm,json=roundConfirm,proto3,oneof"`
+func (x *BattleHistoryRequestMessage) Reset() {
+	*x = BattleHistoryRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type BattlePacket_ChangePet struct {
-	ChangePet *ChangePetResponseMessage `protobuf:"bytes,7,opt,name=change_pet,json=changePet,proto3,oneof"`
+func (x *BattleHistoryRequestMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type BattlePacket_ChangePetRequest struct {
-	ChangePetRequest *ChangePetRequestMessage `protobuf:"bytes,8,opt,name=change_pet_request,json=changePetRequest,proto3,oneof"`
-}
+func (*BattleHistoryRequestMessage) ProtoMessage() {}
 
-type BattlePacket_SyncBattleInformation struct {
-	SyncBattleInformation *SyncBattleInformationMessage `protobuf:"bytes,9,opt,name=sync_battle_information,json=syncBattleInformation,proto3,oneof"`
+func (x *BattleHistoryRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type BattlePacket_RoundEnd struct {
-	RoundEnd *RoundEndMessage `protobuf:"bytes,10,opt,name=round_end,json=roundEnd,proto3,oneof"`
+// Deprecated: Use BattleHistoryRequestMessage.ProtoReflect.Descriptor instead.
+func (*BattleHistoryRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{102}
 }
 
-func (*BattlePacket_Command) isBattlePacket_Msg() {}
-
-func (*BattlePacket_AttackStats) isBattlePacket_Msg() {}
-
-func (*BattlePacket_DenyCommand) isBattlePacket_Msg() {}
-
-func (*BattlePacket_StartNextRound) isBattlePacket_Msg() {}
-
-func (*BattlePacket_BattleEnd) isBattlePacket_Msg() {}
-
-func (*BattlePacket_RoundConfirm) isBattlePacket_Msg() {}
-
-func (*BattlePacket_ChangePet) isBattlePacket_Msg() {}
-
-func (*BattlePacket_ChangePetRequest) isBattlePacket_Msg() {}
-
-func (*BattlePacket_SyncBattleInformation) isBattlePacket_Msg() {}
-
-func (*BattlePacket_RoundEnd) isBattlePacket_Msg() {}
+func (x *BattleHistoryRequestMessage) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
 
-type RoundCommandMessage struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Command:
-	//
-	//	*RoundCommandMessage_ChangePet
-	//	*RoundCommandMessage_Runaway
-	//	*RoundCommandMessage_Attack
-	Command       isRoundCommandMessage_Command `protobuf_oneof:"command"`
+type BattleRecordMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Opponent      string                 `protobuf:"bytes,1,opt,name=opponent,proto3" json:"opponent,omitempty"`
+	Win           bool                   `protobuf:"varint,2,opt,name=win,proto3" json:"win,omitempty"`
+	RatingChange  int64                  `protobuf:"varint,3,opt,name=rating_change,json=ratingChange,proto3" json:"rating_change,omitempty"`
+	Time          int64                  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoundCommandMessage) Reset() {
-	*x = RoundCommandMessage{}
-	mi := &file_shared_packets_proto_msgTypes[59]
+func (x *BattleRecordMessage) Reset() {
+	*x = BattleRecordMessage{}
+	mi := &file_shared_packets_proto_msgTypes[103]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoundCommandMessage) String() string {
+func (x *BattleRecordMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoundCommandMessage) ProtoMessage() {}
+func (*BattleRecordMessage) ProtoMessage() {}
 
-func (x *RoundCommandMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[59]
+func (x *BattleRecordMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[103]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4084,89 +7242,61 @@ func (x *RoundCommandMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoundCommandMessage.ProtoReflect.Descriptor instead.
-func (*RoundCommandMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use BattleRecordMessage.ProtoReflect.Descriptor instead.
+func (*BattleRecordMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{103}
 }
 
-func (x *RoundCommandMessage) GetCommand() isRoundCommandMessage_Command {
+func (x *BattleRecordMessage) GetOpponent() string {
 	if x != nil {
-		return x.Command
+		return x.Opponent
 	}
-	return nil
+	return ""
 }
 
-func (x *RoundCommandMessage) GetChangePet() *ChangePet {
+func (x *BattleRecordMessage) GetWin() bool {
 	if x != nil {
-		if x, ok := x.Command.(*RoundCommandMessage_ChangePet); ok {
-			return x.ChangePet
-		}
+		return x.Win
 	}
-	return nil
+	return false
 }
 
-func (x *RoundCommandMessage) GetRunaway() *RunAway {
+func (x *BattleRecordMessage) GetRatingChange() int64 {
 	if x != nil {
-		if x, ok := x.Command.(*RoundCommandMessage_Runaway); ok {
-			return x.Runaway
-		}
+		return x.RatingChange
 	}
-	return nil
+	return 0
 }
 
-func (x *RoundCommandMessage) GetAttack() *Attack {
+func (x *BattleRecordMessage) GetTime() int64 {
 	if x != nil {
-		if x, ok := x.Command.(*RoundCommandMessage_Attack); ok {
-			return x.Attack
-		}
+		return x.Time
 	}
-	return nil
-}
-
-type isRoundCommandMessage_Command interface {
-	isRoundCommandMessage_Command()
-}
-
-type RoundCommandMessage_ChangePet struct {
-	ChangePet *ChangePet `protobuf:"bytes,1,opt,name=change_pet,json=changePet,proto3,oneof"`
-}
-
-type RoundCommandMessage_Runaway struct {
-	Runaway *RunAway `protobuf:"bytes,2,opt,name=runaway,proto3,oneof"`
-}
-
-type RoundCommandMessage_Attack struct {
-	Attack *Attack `protobuf:"bytes,3,opt,name=attack,proto3,oneof"`
+	return 0
 }
 
-func (*RoundCommandMessage_ChangePet) isRoundCommandMessage_Command() {}
-
-func (*RoundCommandMessage_Runaway) isRoundCommandMessage_Command() {}
-
-func (*RoundCommandMessage_Attack) isRoundCommandMessage_Command() {}
-
-type ChangePet struct {
+type BattleHistoryResponseMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PetPosition   int64                  `protobuf:"varint,1,opt,name=pet_position,json=petPosition,proto3" json:"pet_position,omitempty"`
+	Records       []*BattleRecordMessage `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePet) Reset() {
-	*x = ChangePet{}
-	mi := &file_shared_packets_proto_msgTypes[60]
+func (x *BattleHistoryResponseMessage) Reset() {
+	*x = BattleHistoryResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[104]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePet) String() string {
+func (x *BattleHistoryResponseMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePet) ProtoMessage() {}
+func (*BattleHistoryResponseMessage) ProtoMessage() {}
 
-func (x *ChangePet) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[60]
+func (x *BattleHistoryResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[104]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4177,39 +7307,45 @@ func (x *ChangePet) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePet.ProtoReflect.Descriptor instead.
-func (*ChangePet) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{60}
+// Deprecated: Use BattleHistoryResponseMessage.ProtoReflect.Descriptor instead.
+func (*BattleHistoryResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{104}
 }
 
-func (x *ChangePet) GetPetPosition() int64 {
+func (x *BattleHistoryResponseMessage) GetRecords() []*BattleRecordMessage {
 	if x != nil {
-		return x.PetPosition
+		return x.Records
 	}
-	return 0
+	return nil
 }
 
-type RunAway struct {
+// ---------------------------------排行榜------------------------------
+// board 1=宠物最高等级 2=宠物总数 3=胜场 4=活动积分，window 0=每日 1=每周 2=总榜
+type LeaderboardEntryMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int64                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Uid           uint32                 `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	UserName      string                 `protobuf:"bytes,3,opt,name=user_name,json=userName,proto3" json:"user_name,omitempty"`
+	Score         int64                  `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RunAway) Reset() {
-	*x = RunAway{}
-	mi := &file_shared_packets_proto_msgTypes[61]
+func (x *LeaderboardEntryMessage) Reset() {
+	*x = LeaderboardEntryMessage{}
+	mi := &file_shared_packets_proto_msgTypes[105]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RunAway) String() string {
+func (x *LeaderboardEntryMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunAway) ProtoMessage() {}
+func (*LeaderboardEntryMessage) ProtoMessage() {}
 
-func (x *RunAway) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[61]
+func (x *LeaderboardEntryMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[105]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4220,33 +7356,64 @@ func (x *RunAway) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunAway.ProtoReflect.Descriptor instead.
-func (*RunAway) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{61}
+// Deprecated: Use LeaderboardEntryMessage.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntryMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{105}
 }
 
-type Attack struct {
+func (x *LeaderboardEntryMessage) GetRank() int64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntryMessage) GetUid() uint32 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LeaderboardEntryMessage) GetUserName() string {
+	if x != nil {
+		return x.UserName
+	}
+	return ""
+}
+
+func (x *LeaderboardEntryMessage) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type LeaderboardRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SkillPos      int64                  `protobuf:"varint,1,opt,name=skill_pos,json=skillPos,proto3" json:"skill_pos,omitempty"`
+	Board         uint32                 `protobuf:"varint,1,opt,name=board,proto3" json:"board,omitempty"`
+	Window        uint32                 `protobuf:"varint,2,opt,name=window,proto3" json:"window,omitempty"`
+	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Attack) Reset() {
-	*x = Attack{}
-	mi := &file_shared_packets_proto_msgTypes[62]
+func (x *LeaderboardRequestMessage) Reset() {
+	*x = LeaderboardRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[106]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Attack) String() string {
+func (x *LeaderboardRequestMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Attack) ProtoMessage() {}
+func (*LeaderboardRequestMessage) ProtoMessage() {}
 
-func (x *Attack) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[62]
+func (x *LeaderboardRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[106]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4257,45 +7424,67 @@ func (x *Attack) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Attack.ProtoReflect.Descriptor instead.
-func (*Attack) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{62}
+// Deprecated: Use LeaderboardRequestMessage.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{106}
 }
 
-func (x *Attack) GetSkillPos() int64 {
+func (x *LeaderboardRequestMessage) GetBoard() uint32 {
 	if x != nil {
-		return x.SkillPos
+		return x.Board
 	}
 	return 0
 }
 
-type AttackStatsMessage struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Number         int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
-	SkillId        uint32                 `protobuf:"varint,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
-	PhysicalDamage int64                  `protobuf:"varint,3,opt,name=physical_damage,json=physicalDamage,proto3" json:"physical_damage,omitempty"`
-	MagicDamage    int64                  `protobuf:"varint,4,opt,name=magic_damage,json=magicDamage,proto3" json:"magic_damage,omitempty"`
-	Buffs          []*Buff                `protobuf:"bytes,5,rep,name=buffs,proto3" json:"buffs,omitempty"`
-	PetStats       []*PetStatsMessage     `protobuf:"bytes,6,rep,name=pet_stats,json=petStats,proto3" json:"pet_stats,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *LeaderboardRequestMessage) GetWindow() uint32 {
+	if x != nil {
+		return x.Window
+	}
+	return 0
 }
 
-func (x *AttackStatsMessage) Reset() {
-	*x = AttackStatsMessage{}
-	mi := &file_shared_packets_proto_msgTypes[63]
+func (x *LeaderboardRequestMessage) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *LeaderboardRequestMessage) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type LeaderboardResponseMessage struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Success       bool                       `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reason        string                     `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Board         uint32                     `protobuf:"varint,3,opt,name=board,proto3" json:"board,omitempty"`
+	Window        uint32                     `protobuf:"varint,4,opt,name=window,proto3" json:"window,omitempty"`
+	Page          int64                      `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	Entries       []*LeaderboardEntryMessage `protobuf:"bytes,6,rep,name=entries,proto3" json:"entries,omitempty"`
+	Self          *LeaderboardEntryMessage   `protobuf:"bytes,7,opt,name=self,proto3" json:"self,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardResponseMessage) Reset() {
+	*x = LeaderboardResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[107]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttackStatsMessage) String() string {
+func (x *LeaderboardResponseMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttackStatsMessage) ProtoMessage() {}
+func (*LeaderboardResponseMessage) ProtoMessage() {}
 
-func (x *AttackStatsMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[63]
+func (x *LeaderboardResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[107]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4306,76 +7495,84 @@ func (x *AttackStatsMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttackStatsMessage.ProtoReflect.Descriptor instead.
-func (*AttackStatsMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{63}
+// Deprecated: Use LeaderboardResponseMessage.ProtoReflect.Descriptor instead.
+func (*LeaderboardResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{107}
 }
 
-func (x *AttackStatsMessage) GetNumber() int64 {
+func (x *LeaderboardResponseMessage) GetSuccess() bool {
 	if x != nil {
-		return x.Number
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *AttackStatsMessage) GetSkillId() uint32 {
+func (x *LeaderboardResponseMessage) GetReason() string {
 	if x != nil {
-		return x.SkillId
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *LeaderboardResponseMessage) GetBoard() uint32 {
+	if x != nil {
+		return x.Board
 	}
 	return 0
 }
 
-func (x *AttackStatsMessage) GetPhysicalDamage() int64 {
+func (x *LeaderboardResponseMessage) GetWindow() uint32 {
 	if x != nil {
-		return x.PhysicalDamage
+		return x.Window
 	}
 	return 0
 }
 
-func (x *AttackStatsMessage) GetMagicDamage() int64 {
+func (x *LeaderboardResponseMessage) GetPage() int64 {
 	if x != nil {
-		return x.MagicDamage
+		return x.Page
 	}
 	return 0
 }
 
-func (x *AttackStatsMessage) GetBuffs() []*Buff {
+func (x *LeaderboardResponseMessage) GetEntries() []*LeaderboardEntryMessage {
 	if x != nil {
-		return x.Buffs
+		return x.Entries
 	}
 	return nil
 }
 
-func (x *AttackStatsMessage) GetPetStats() []*PetStatsMessage {
+func (x *LeaderboardResponseMessage) GetSelf() *LeaderboardEntryMessage {
 	if x != nil {
-		return x.PetStats
+		return x.Self
 	}
 	return nil
 }
 
-type Buff struct {
+// ---------------------------------战斗回放----------------------------
+type ReplayCommand struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Level         int64                  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Command       *RoundCommandMessage   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Buff) Reset() {
-	*x = Buff{}
-	mi := &file_shared_packets_proto_msgTypes[64]
+func (x *ReplayCommand) Reset() {
+	*x = ReplayCommand{}
+	mi := &file_shared_packets_proto_msgTypes[108]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Buff) String() string {
+func (x *ReplayCommand) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Buff) ProtoMessage() {}
+func (*ReplayCommand) ProtoMessage() {}
 
-func (x *Buff) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[64]
+func (x *ReplayCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[108]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4386,46 +7583,50 @@ func (x *Buff) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Buff.ProtoReflect.Descriptor instead.
-func (*Buff) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{64}
+// Deprecated: Use ReplayCommand.ProtoReflect.Descriptor instead.
+func (*ReplayCommand) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{108}
 }
 
-func (x *Buff) GetId() uint32 {
+func (x *ReplayCommand) GetNumber() int64 {
 	if x != nil {
-		return x.Id
+		return x.Number
 	}
 	return 0
 }
 
-func (x *Buff) GetLevel() int64 {
+func (x *ReplayCommand) GetCommand() *RoundCommandMessage {
 	if x != nil {
-		return x.Level
+		return x.Command
 	}
-	return 0
+	return nil
 }
 
-type BattleEndStats struct {
+// 战斗中房间读取的一次输入，type 1=回合指令，2=宠物死亡后更换宠物，3=掉线超时认输，此时number为认输的一方
+type ReplayInput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          uint32                 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Commands      []*ReplayCommand       `protobuf:"bytes,2,rep,name=commands,proto3" json:"commands,omitempty"`
+	Number        int64                  `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleEndStats) Reset() {
-	*x = BattleEndStats{}
-	mi := &file_shared_packets_proto_msgTypes[65]
+func (x *ReplayInput) Reset() {
+	*x = ReplayInput{}
+	mi := &file_shared_packets_proto_msgTypes[109]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleEndStats) String() string {
+func (x *ReplayInput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleEndStats) ProtoMessage() {}
+func (*ReplayInput) ProtoMessage() {}
 
-func (x *BattleEndStats) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[65]
+func (x *ReplayInput) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[109]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4436,33 +7637,61 @@ func (x *BattleEndStats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleEndStats.ProtoReflect.Descriptor instead.
-func (*BattleEndStats) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{65}
+// Deprecated: Use ReplayInput.ProtoReflect.Descriptor instead.
+func (*ReplayInput) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{109}
 }
 
-type DenyCommandMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+func (x *ReplayInput) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *ReplayInput) GetCommands() []*ReplayCommand {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+func (x *ReplayInput) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type BattleReplay struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Id            uint64                          `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Seed          uint64                          `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	Teams         []*SyncBattleInformationMessage `protobuf:"bytes,3,rep,name=teams,proto3" json:"teams,omitempty"`
+	Inputs        []*ReplayInput                  `protobuf:"bytes,4,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Winner        int64                           `protobuf:"varint,5,opt,name=winner,proto3" json:"winner,omitempty"`
+	Time          int64                           `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`
+	Uids          []uint32                        `protobuf:"varint,7,rep,packed,name=uids,proto3" json:"uids,omitempty"` // 参与战斗的玩家
+	Format        *BattleFormatMessage            `protobuf:"bytes,8,opt,name=format,proto3" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DenyCommandMessage) Reset() {
-	*x = DenyCommandMessage{}
-	mi := &file_shared_packets_proto_msgTypes[66]
+func (x *BattleReplay) Reset() {
+	*x = BattleReplay{}
+	mi := &file_shared_packets_proto_msgTypes[110]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DenyCommandMessage) String() string {
+func (x *BattleReplay) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DenyCommandMessage) ProtoMessage() {}
+func (*BattleReplay) ProtoMessage() {}
 
-func (x *DenyCommandMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[66]
+func (x *BattleReplay) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[110]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4473,76 +7702,92 @@ func (x *DenyCommandMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DenyCommandMessage.ProtoReflect.Descriptor instead.
-func (*DenyCommandMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{66}
+// Deprecated: Use BattleReplay.ProtoReflect.Descriptor instead.
+func (*BattleReplay) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *DenyCommandMessage) GetReason() string {
+func (x *BattleReplay) GetId() uint64 {
 	if x != nil {
-		return x.Reason
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-type StartNextRoundMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BattleReplay) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
 }
 
-func (x *StartNextRoundMessage) Reset() {
-	*x = StartNextRoundMessage{}
-	mi := &file_shared_packets_proto_msgTypes[67]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *BattleReplay) GetTeams() []*SyncBattleInformationMessage {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
 }
 
-func (x *StartNextRoundMessage) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *BattleReplay) GetInputs() []*ReplayInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
 }
 
-func (*StartNextRoundMessage) ProtoMessage() {}
+func (x *BattleReplay) GetWinner() int64 {
+	if x != nil {
+		return x.Winner
+	}
+	return 0
+}
 
-func (x *StartNextRoundMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[67]
+func (x *BattleReplay) GetTime() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Time
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use StartNextRoundMessage.ProtoReflect.Descriptor instead.
-func (*StartNextRoundMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{67}
+func (x *BattleReplay) GetUids() []uint32 {
+	if x != nil {
+		return x.Uids
+	}
+	return nil
 }
 
-type BattleEndMessage struct {
+func (x *BattleReplay) GetFormat() *BattleFormatMessage {
+	if x != nil {
+		return x.Format
+	}
+	return nil
+}
+
+type ReplayInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Winner        int64                  `protobuf:"varint,1,opt,name=winner,proto3" json:"winner,omitempty"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PlayerNames   []string               `protobuf:"bytes,2,rep,name=player_names,json=playerNames,proto3" json:"player_names,omitempty"`
+	Winner        int64                  `protobuf:"varint,3,opt,name=winner,proto3" json:"winner,omitempty"`
+	Time          int64                  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BattleEndMessage) Reset() {
-	*x = BattleEndMessage{}
-	mi := &file_shared_packets_proto_msgTypes[68]
+func (x *ReplayInfo) Reset() {
+	*x = ReplayInfo{}
+	mi := &file_shared_packets_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BattleEndMessage) String() string {
+func (x *ReplayInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BattleEndMessage) ProtoMessage() {}
+func (*ReplayInfo) ProtoMessage() {}
 
-func (x *BattleEndMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[68]
+func (x *ReplayInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4553,40 +7798,60 @@ func (x *BattleEndMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BattleEndMessage.ProtoReflect.Descriptor instead.
-func (*BattleEndMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{68}
+// Deprecated: Use ReplayInfo.ProtoReflect.Descriptor instead.
+func (*ReplayInfo) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *BattleEndMessage) GetWinner() int64 {
+func (x *ReplayInfo) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReplayInfo) GetPlayerNames() []string {
+	if x != nil {
+		return x.PlayerNames
+	}
+	return nil
+}
+
+func (x *ReplayInfo) GetWinner() int64 {
 	if x != nil {
 		return x.Winner
 	}
 	return 0
 }
 
-// 当客户端回合播放完毕后发送此消息
-type RoundConfirmMessage struct {
+func (x *ReplayInfo) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type ReplayListRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoundConfirmMessage) Reset() {
-	*x = RoundConfirmMessage{}
-	mi := &file_shared_packets_proto_msgTypes[69]
+func (x *ReplayListRequestMessage) Reset() {
+	*x = ReplayListRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[112]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoundConfirmMessage) String() string {
+func (x *ReplayListRequestMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoundConfirmMessage) ProtoMessage() {}
+func (*ReplayListRequestMessage) ProtoMessage() {}
 
-func (x *RoundConfirmMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[69]
+func (x *ReplayListRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[112]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4597,33 +7862,33 @@ func (x *RoundConfirmMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoundConfirmMessage.ProtoReflect.Descriptor instead.
-func (*RoundConfirmMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{69}
+// Deprecated: Use ReplayListRequestMessage.ProtoReflect.Descriptor instead.
+func (*ReplayListRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{112}
 }
 
-// 更换宠物请求
-type ChangePetRequestMessage struct {
+type ReplayListResponseMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Replays       []*ReplayInfo          `protobuf:"bytes,1,rep,name=replays,proto3" json:"replays,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePetRequestMessage) Reset() {
-	*x = ChangePetRequestMessage{}
-	mi := &file_shared_packets_proto_msgTypes[70]
+func (x *ReplayListResponseMessage) Reset() {
+	*x = ReplayListResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[113]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePetRequestMessage) String() string {
+func (x *ReplayListResponseMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePetRequestMessage) ProtoMessage() {}
+func (*ReplayListResponseMessage) ProtoMessage() {}
 
-func (x *ChangePetRequestMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[70]
+func (x *ReplayListResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[113]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4634,34 +7899,40 @@ func (x *ChangePetRequestMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePetRequestMessage.ProtoReflect.Descriptor instead.
-func (*ChangePetRequestMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{70}
+// Deprecated: Use ReplayListResponseMessage.ProtoReflect.Descriptor instead.
+func (*ReplayListResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{113}
 }
 
-// 更换宠物
-type ChangePetResponseMessage struct {
+func (x *ReplayListResponseMessage) GetReplays() []*ReplayInfo {
+	if x != nil {
+		return x.Replays
+	}
+	return nil
+}
+
+type ReplayRequestMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PetPosition   int64                  `protobuf:"varint,2,opt,name=pet_position,json=petPosition,proto3" json:"pet_position,omitempty"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChangePetResponseMessage) Reset() {
-	*x = ChangePetResponseMessage{}
-	mi := &file_shared_packets_proto_msgTypes[71]
+func (x *ReplayRequestMessage) Reset() {
+	*x = ReplayRequestMessage{}
+	mi := &file_shared_packets_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChangePetResponseMessage) String() string {
+func (x *ReplayRequestMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePetResponseMessage) ProtoMessage() {}
+func (*ReplayRequestMessage) ProtoMessage() {}
 
-func (x *ChangePetResponseMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[71]
+func (x *ReplayRequestMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4672,43 +7943,44 @@ func (x *ChangePetResponseMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePetResponseMessage.ProtoReflect.Descriptor instead.
-func (*ChangePetResponseMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{71}
+// Deprecated: Use ReplayRequestMessage.ProtoReflect.Descriptor instead.
+func (*ReplayRequestMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *ChangePetResponseMessage) GetPetPosition() int64 {
+func (x *ReplayRequestMessage) GetId() uint64 {
 	if x != nil {
-		return x.PetPosition
+		return x.Id
 	}
 	return 0
 }
 
-// 同步双方的信息
-type SyncBattleInformationMessage struct {
+// 服务器重新播放战斗后得到的消息流，与观看者在战斗中收到的消息相同
+type ReplayResponseMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Number        int64                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
-	PlayerName    string                 `protobuf:"bytes,2,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
-	PetMessages   []*PetMessage          `protobuf:"bytes,3,rep,name=pet_messages,json=petMessages,proto3" json:"pet_messages,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Replay        *BattleReplay          `protobuf:"bytes,3,opt,name=replay,proto3" json:"replay,omitempty"`
+	Packets       []*BattlePacket        `protobuf:"bytes,4,rep,name=packets,proto3" json:"packets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SyncBattleInformationMessage) Reset() {
-	*x = SyncBattleInformationMessage{}
-	mi := &file_shared_packets_proto_msgTypes[72]
+func (x *ReplayResponseMessage) Reset() {
+	*x = ReplayResponseMessage{}
+	mi := &file_shared_packets_proto_msgTypes[115]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SyncBattleInformationMessage) String() string {
+func (x *ReplayResponseMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SyncBattleInformationMessage) ProtoMessage() {}
+func (*ReplayResponseMessage) ProtoMessage() {}
 
-func (x *SyncBattleInformationMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[72]
+func (x *ReplayResponseMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_packets_proto_msgTypes[115]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4719,66 +7991,37 @@ func (x *SyncBattleInformationMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SyncBattleInformationMessage.ProtoReflect.Descriptor instead.
-func (*SyncBattleInformationMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{72}
+// Deprecated: Use ReplayResponseMessage.ProtoReflect.Descriptor instead.
+func (*ReplayResponseMessage) Descriptor() ([]byte, []int) {
+	return file_shared_packets_proto_rawDescGZIP(), []int{115}
 }
 
-func (x *SyncBattleInformationMessage) GetNumber() int64 {
+func (x *ReplayResponseMessage) GetSuccess() bool {
 	if x != nil {
-		return x.Number
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *SyncBattleInformationMessage) GetPlayerName() string {
+func (x *ReplayResponseMessage) GetReason() string {
 	if x != nil {
-		return x.PlayerName
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *SyncBattleInformationMessage) GetPetMessages() []*PetMessage {
+func (x *ReplayResponseMessage) GetReplay() *BattleReplay {
 	if x != nil {
-		return x.PetMessages
+		return x.Replay
 	}
 	return nil
 }
 
-type RoundEndMessage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *RoundEndMessage) Reset() {
-	*x = RoundEndMessage{}
-	mi := &file_shared_packets_proto_msgTypes[73]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *RoundEndMessage) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*RoundEndMessage) ProtoMessage() {}
-
-func (x *RoundEndMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_packets_proto_msgTypes[73]
+func (x *ReplayResponseMessage) GetPackets() []*BattlePacket {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Packets
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use RoundEndMessage.ProtoReflect.Descriptor instead.
-func (*RoundEndMessage) Descriptor() ([]byte, []int) {
-	return file_shared_packets_proto_rawDescGZIP(), []int{73}
+	return nil
 }
 
 var File_shared_packets_proto protoreflect.FileDescriptor
@@ -4875,7 +8118,7 @@ const file_shared_packets_proto_rawDesc = "" +
 	"\bequipped\x18\x02 \x01(\bR\bequipped\"\x16\n" +
 	"\x14PetBagRequestMessage\">\n" +
 	"\x15PetBagResponseMessage\x12%\n" +
-	"\x03pet\x18\x01 \x03(\v2\x13.packets.PetMessageR\x03pet\"\xbb\x01\n" +
+	"\x03pet\x18\x01 \x03(\v2\x13.packets.PetMessageR\x03pet\"\x8a\x02\n" +
 	"\n" +
 	"PetMessage\x12\x15\n" +
 	"\x06pet_id\x18\x01 \x01(\rR\x05petId\x12\x0e\n" +
@@ -4883,7 +8126,17 @@ const file_shared_packets_proto_rawDesc = "" +
 	"\x03exp\x18\x03 \x01(\x03R\x03exp\x12\x14\n" +
 	"\x05level\x18\x04 \x01(\x03R\x05level\x12'\n" +
 	"\x0fequipped_skills\x18\x05 \x03(\rR\x0eequippedSkills\x125\n" +
-	"\tpet_stats\x18\x06 \x01(\v2\x18.packets.PetStatsMessageR\bpetStats\"\xd7\x01\n" +
+	"\tpet_stats\x18\x06 \x01(\v2\x18.packets.PetStatsMessageR\bpetStats\x121\n" +
+	"\x06talent\x18\a \x01(\v2\x19.packets.PetTalentMessageR\x06talent\x12\x1a\n" +
+	"\bpassives\x18\b \x03(\rR\bpassives\"\xbe\x01\n" +
+	"\x10PetTalentMessage\x12\x16\n" +
+	"\x06nature\x18\x01 \x01(\rR\x06nature\x12\x0e\n" +
+	"\x02hp\x18\x02 \x01(\x03R\x02hp\x12\x12\n" +
+	"\x04mana\x18\x03 \x01(\x03R\x04mana\x12\x1a\n" +
+	"\bstrength\x18\x04 \x01(\x03R\bstrength\x12\"\n" +
+	"\fintelligence\x18\x05 \x01(\x03R\fintelligence\x12\x14\n" +
+	"\x05speed\x18\x06 \x01(\x03R\x05speed\x12\x18\n" +
+	"\adefense\x18\a \x01(\x03R\adefense\"\xd7\x01\n" +
 	"\x0fPetStatsMessage\x12\x15\n" +
 	"\x06max_hp\x18\x01 \x01(\x03R\x05maxHp\x12\x0e\n" +
 	"\x02hp\x18\x02 \x01(\x03R\x02hp\x12\x19\n" +
@@ -4900,6 +8153,12 @@ const file_shared_packets_proto_rawDesc = "" +
 	"\x06pet_id\x18\x03 \x01(\x04R\x05petId\"M\n" +
 	"\x19LearnSkillResponseMessage\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"N\n" +
+	"\x19ForgetSkillRequestMessage\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x03R\bposition\x12\x15\n" +
+	"\x06pet_id\x18\x02 \x01(\x04R\x05petId\"N\n" +
+	"\x1aForgetSkillResponseMessage\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\"/\n" +
 	"\x1dEquippedPetInfoRequestMessage\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\"W\n" +
@@ -4928,17 +8187,59 @@ const file_shared_packets_proto_rawDesc = "" +
 	"\x05count\x18\x03 \x01(\x03R\x05count\"M\n" +
 	"\x19UsePetItemResponseMessage\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason\".\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"w\n" +
 	"\x14BattleRequestMessage\x12\x16\n" +
-	"\x06target\x18\x01 \x01(\rR\x06target\"L\n" +
+	"\x06target\x18\x01 \x01(\rR\x06target\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\rR\x06format\x12/\n" +
+	"\x05stake\x18\x03 \x03(\v2\x19.packets.WagerItemMessageR\x05stake\"L\n" +
+	"\x10WagerItemMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\rR\x05count\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\rR\x04type\"\x97\x01\n" +
 	"\x15BattleInvitingMessage\x12\x16\n" +
 	"\x06roomID\x18\x01 \x01(\rR\x06roomID\x12\x1b\n" +
-	"\tuser_name\x18\x02 \x01(\tR\buserName\"S\n" +
+	"\tuser_name\x18\x02 \x01(\tR\buserName\x12\x18\n" +
+	"\atimeout\x18\x03 \x01(\x03R\atimeout\x12/\n" +
+	"\x05stake\x18\x04 \x03(\v2\x19.packets.WagerItemMessageR\x05stake\"\x82\x01\n" +
+	"\x1cBattleRequestResponseMessage\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06roomID\x18\x03 \x01(\rR\x06roomID\x12\x18\n" +
+	"\atimeout\x18\x04 \x01(\x03R\atimeout\"3\n" +
+	"\x19CancelBattleInviteMessage\x12\x16\n" +
+	"\x06roomID\x18\x01 \x01(\rR\x06roomID\"h\n" +
+	"\x19BattleInviteClosedMessage\x12\x16\n" +
+	"\x06roomID\x18\x01 \x01(\rR\x06roomID\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\rR\x06reason\x12\x1b\n" +
+	"\tuser_name\x18\x03 \x01(\tR\buserName\"S\n" +
 	"\x1dBattleInvitingResponseMessage\x12\x16\n" +
 	"\x06roomID\x18\x01 \x01(\rR\x06roomID\x12\x1a\n" +
 	"\baccepted\x18\x02 \x01(\bR\baccepted\",\n" +
 	"\x12StartBattleMessage\x12\x16\n" +
-	"\x06number\x18\x01 \x01(\x03R\x06number\"\x88\x1b\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\"0\n" +
+	"\x16SpectateRequestMessage\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\rR\x06target\"K\n" +
+	"\x17SpectateResponseMessage\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x15\n" +
+	"\x13StopSpectateMessage\"\x18\n" +
+	"\x16JoinMatchmakingMessage\"\x19\n" +
+	"\x17LeaveMatchmakingMessage\"f\n" +
+	"\x1aMatchmakingResponseMessage\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06queued\x18\x03 \x01(\bR\x06queued\"d\n" +
+	"\x11MatchFoundMessage\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\rR\amatchId\x12\x1a\n" +
+	"\bopponent\x18\x02 \x01(\tR\bopponent\x12\x18\n" +
+	"\atimeout\x18\x03 \x01(\x03R\atimeout\"J\n" +
+	"\x11MatchReadyMessage\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\rR\amatchId\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\"f\n" +
+	"\x15MatchCancelledMessage\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\rR\amatchId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1a\n" +
+	"\brequeued\x18\x03 \x01(\bR\brequeued\"\xc3*\n" +
 	"\x06Packet\x12\x10\n" +
 	"\x03uid\x18\x01 \x01(\rR\x03uid\x12C\n" +
 	"\rlogin_request\x18\x02 \x01(\v2\x1c.packets.LoginRequestMessageH\x00R\floginRequest\x12L\n" +
@@ -4994,7 +8295,33 @@ const file_shared_packets_proto_rawDesc = "" +
 	"sync_state\x18- \x01(\v2\x12.packets.SyncStateH\x00R\tsyncState\x12A\n" +
 	"\rget_area_npcs\x18. \x01(\v2\x1b.packets.GetAreaNPCsMessageH\x00R\vgetAreaNpcs\x12V\n" +
 	"\x14interact_npc_request\x18/ \x01(\v2\".packets.InteractNPCRequestMessageH\x00R\x12interactNpcRequest\x12?\n" +
-	"\fnpc_interact\x180 \x01(\v2\x1a.packets.NPCInteractPacketH\x00R\vnpcInteractB\x05\n" +
+	"\fnpc_interact\x180 \x01(\v2\x1a.packets.NPCInteractPacketH\x00R\vnpcInteract\x12V\n" +
+	"\x14forget_skill_request\x181 \x01(\v2\".packets.ForgetSkillRequestMessageH\x00R\x12forgetSkillRequest\x12Y\n" +
+	"\x15forget_skill_response\x182 \x01(\v2#.packets.ForgetSkillResponseMessageH\x00R\x13forgetSkillResponse\x12S\n" +
+	"\x13replay_list_request\x183 \x01(\v2!.packets.ReplayListRequestMessageH\x00R\x11replayListRequest\x12V\n" +
+	"\x14replay_list_response\x184 \x01(\v2\".packets.ReplayListResponseMessageH\x00R\x12replayListResponse\x12F\n" +
+	"\x0ereplay_request\x185 \x01(\v2\x1d.packets.ReplayRequestMessageH\x00R\rreplayRequest\x12I\n" +
+	"\x0freplay_response\x186 \x01(\v2\x1e.packets.ReplayResponseMessageH\x00R\x0ereplayResponse\x12L\n" +
+	"\x10spectate_request\x187 \x01(\v2\x1f.packets.SpectateRequestMessageH\x00R\x0fspectateRequest\x12O\n" +
+	"\x11spectate_response\x188 \x01(\v2 .packets.SpectateResponseMessageH\x00R\x10spectateResponse\x12C\n" +
+	"\rstop_spectate\x189 \x01(\v2\x1c.packets.StopSpectateMessageH\x00R\fstopSpectate\x12L\n" +
+	"\x10join_matchmaking\x18: \x01(\v2\x1f.packets.JoinMatchmakingMessageH\x00R\x0fjoinMatchmaking\x12O\n" +
+	"\x11leave_matchmaking\x18; \x01(\v2 .packets.LeaveMatchmakingMessageH\x00R\x10leaveMatchmaking\x12X\n" +
+	"\x14matchmaking_response\x18< \x01(\v2#.packets.MatchmakingResponseMessageH\x00R\x13matchmakingResponse\x12=\n" +
+	"\vmatch_found\x18= \x01(\v2\x1a.packets.MatchFoundMessageH\x00R\n" +
+	"matchFound\x12=\n" +
+	"\vmatch_ready\x18> \x01(\v2\x1a.packets.MatchReadyMessageH\x00R\n" +
+	"matchReady\x12I\n" +
+	"\x0fmatch_cancelled\x18? \x01(\v2\x1e.packets.MatchCancelledMessageH\x00R\x0ematchCancelled\x12M\n" +
+	"\x11rank_list_request\x18@ \x01(\v2\x1f.packets.RankListRequestMessageH\x00R\x0frankListRequest\x12P\n" +
+	"\x12rank_list_response\x18A \x01(\v2 .packets.RankListResponseMessageH\x00R\x10rankListResponse\x12\\\n" +
+	"\x16battle_history_request\x18B \x01(\v2$.packets.BattleHistoryRequestMessageH\x00R\x14battleHistoryRequest\x12_\n" +
+	"\x17battle_history_response\x18C \x01(\v2%.packets.BattleHistoryResponseMessageH\x00R\x15battleHistoryResponse\x12U\n" +
+	"\x13leaderboard_request\x18D \x01(\v2\".packets.LeaderboardRequestMessageH\x00R\x12leaderboardRequest\x12X\n" +
+	"\x14leaderboard_response\x18E \x01(\v2#.packets.LeaderboardResponseMessageH\x00R\x13leaderboardResponse\x12_\n" +
+	"\x17battle_request_response\x18F \x01(\v2%.packets.BattleRequestResponseMessageH\x00R\x15battleRequestResponse\x12V\n" +
+	"\x14cancel_battle_invite\x18G \x01(\v2\".packets.CancelBattleInviteMessageH\x00R\x12cancelBattleInvite\x12V\n" +
+	"\x14battle_invite_closed\x18H \x01(\v2\".packets.BattleInviteClosedMessageH\x00R\x12battleInviteClosedB\x05\n" +
 	"\x03msg\"\x99\x01\n" +
 	"\bUiPacket\x121\n" +
 	"\aopen_ui\x18\x01 \x01(\v2\x16.packets.OpenUIMessageH\x00R\x06openUi\x12S\n" +
@@ -5018,7 +8345,7 @@ const file_shared_packets_proto_rawDesc = "" +
 	"\x10NewRewardRequest\"t\n" +
 	" UpdateInitialVillageHeaderUIInfo\x12+\n" +
 	"\x12can_get_new_reward\x18\x01 \x01(\bR\x0fcanGetNewReward\x12#\n" +
-	"\rcan_challenge\x18\x02 \x01(\bR\fcanChallenge\"\xd0\x05\n" +
+	"\rcan_challenge\x18\x02 \x01(\bR\fcanChallenge\"\xda\t\n" +
 	"\fBattlePacket\x128\n" +
 	"\acommand\x18\x01 \x01(\v2\x1c.packets.RoundCommandMessageH\x00R\acommand\x12@\n" +
 	"\fattack_stats\x18\x02 \x01(\v2\x1b.packets.AttackStatsMessageH\x00R\vattackStats\x12@\n" +
@@ -5032,45 +8359,191 @@ const file_shared_packets_proto_rawDesc = "" +
 	"\x12change_pet_request\x18\b \x01(\v2 .packets.ChangePetRequestMessageH\x00R\x10changePetRequest\x12_\n" +
 	"\x17sync_battle_information\x18\t \x01(\v2%.packets.SyncBattleInformationMessageH\x00R\x15syncBattleInformation\x127\n" +
 	"\tround_end\x18\n" +
-	" \x01(\v2\x18.packets.RoundEndMessageH\x00R\broundEndB\x05\n" +
-	"\x03msg\"\xae\x01\n" +
+	" \x01(\v2\x18.packets.RoundEndMessageH\x00R\broundEnd\x12U\n" +
+	"\x13player_disconnected\x18\v \x01(\v2\".packets.PlayerDisconnectedMessageH\x00R\x12playerDisconnected\x12R\n" +
+	"\x12player_reconnected\x18\f \x01(\v2!.packets.PlayerReconnectedMessageH\x00R\x11playerReconnected\x12F\n" +
+	"\x0ecapture_result\x18\r \x01(\v2\x1d.packets.CaptureResultMessageH\x00R\rcaptureResult\x12C\n" +
+	"\x10battle_end_stats\x18\x0e \x01(\v2\x17.packets.BattleEndStatsH\x00R\x0ebattleEndStats\x12I\n" +
+	"\x0fbattle_snapshot\x18\x0f \x01(\v2\x1e.packets.BattleSnapshotMessageH\x00R\x0ebattleSnapshot\x12C\n" +
+	"\rbattle_format\x18\x10 \x01(\v2\x1c.packets.BattleFormatMessageH\x00R\fbattleFormat\x12@\n" +
+	"\fpet_switched\x18\x11 \x01(\v2\x1b.packets.PetSwitchedMessageH\x00R\vpetSwitchedB\x05\n" +
+	"\x03msg\"\xf0\x01\n" +
 	"\x13RoundCommandMessage\x123\n" +
 	"\n" +
 	"change_pet\x18\x01 \x01(\v2\x12.packets.ChangePetH\x00R\tchangePet\x12,\n" +
 	"\arunaway\x18\x02 \x01(\v2\x10.packets.RunAwayH\x00R\arunaway\x12)\n" +
-	"\x06attack\x18\x03 \x01(\v2\x0f.packets.AttackH\x00R\x06attackB\t\n" +
-	"\acommand\".\n" +
+	"\x06attack\x18\x03 \x01(\v2\x0f.packets.AttackH\x00R\x06attack\x12,\n" +
+	"\acapture\x18\x04 \x01(\v2\x10.packets.CaptureH\x00R\acapture\x12\x12\n" +
+	"\x04slot\x18\x05 \x01(\x03R\x04slotB\t\n" +
+	"\acommand\":\n" +
+	"\fBattleTarget\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\x12\x12\n" +
+	"\x04slot\x18\x02 \x01(\x03R\x04slot\".\n" +
 	"\tChangePet\x12!\n" +
 	"\fpet_position\x18\x01 \x01(\x03R\vpetPosition\"\t\n" +
-	"\aRunAway\"%\n" +
+	"\aRunAway\"T\n" +
 	"\x06Attack\x12\x1b\n" +
-	"\tskill_pos\x18\x01 \x01(\x03R\bskillPos\"\xef\x01\n" +
+	"\tskill_pos\x18\x01 \x01(\x03R\bskillPos\x12-\n" +
+	"\x06target\x18\x02 \x01(\v2\x15.packets.BattleTargetR\x06target\"\"\n" +
+	"\aCapture\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\rR\x06itemId\"\xeb\x04\n" +
 	"\x12AttackStatsMessage\x12\x16\n" +
 	"\x06number\x18\x01 \x01(\x03R\x06number\x12\x19\n" +
 	"\bskill_id\x18\x02 \x01(\rR\askillId\x12'\n" +
 	"\x0fphysical_damage\x18\x03 \x01(\x03R\x0ephysicalDamage\x12!\n" +
 	"\fmagic_damage\x18\x04 \x01(\x03R\vmagicDamage\x12#\n" +
 	"\x05buffs\x18\x05 \x03(\v2\r.packets.BuffR\x05buffs\x125\n" +
-	"\tpet_stats\x18\x06 \x03(\v2\x18.packets.PetStatsMessageR\bpetStats\",\n" +
+	"\tpet_stats\x18\x06 \x03(\v2\x18.packets.PetStatsMessageR\bpetStats\x12$\n" +
+	"\reffectiveness\x18\a \x01(\x02R\reffectiveness\x12!\n" +
+	"\fsame_element\x18\b \x01(\bR\vsameElement\x12\x16\n" +
+	"\x06missed\x18\t \x01(\bR\x06missed\x12\x1a\n" +
+	"\bcritical\x18\n" +
+	" \x01(\bR\bcritical\x12\x1a\n" +
+	"\bvariance\x18\v \x01(\x02R\bvariance\x12\x1f\n" +
+	"\vbuff_damage\x18\f \x01(\x03R\n" +
+	"buffDamage\x12%\n" +
+	"\x0ereflect_damage\x18\r \x01(\x03R\rreflectDamage\x12\x12\n" +
+	"\x04heal\x18\x0e \x01(\x03R\x04heal\x12\x12\n" +
+	"\x04slot\x18\x0f \x01(\x03R\x04slot\x12-\n" +
+	"\x06target\x18\x10 \x01(\v2\x15.packets.BattleTargetR\x06target\x12!\n" +
+	"\fmana_restore\x18\x11 \x01(\x03R\vmanaRestore\x12\x1f\n" +
+	"\vself_damage\x18\x12 \x01(\x03R\n" +
+	"selfDamage\"p\n" +
 	"\x04Buff\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x14\n" +
-	"\x05level\x18\x02 \x01(\x03R\x05level\"\x10\n" +
-	"\x0eBattleEndStats\",\n" +
+	"\x05level\x18\x02 \x01(\x03R\x05level\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\x03R\x06number\x12\x16\n" +
+	"\x06rounds\x18\x04 \x01(\x03R\x06rounds\x12\x12\n" +
+	"\x04slot\x18\x05 \x01(\x03R\x04slot\"\xc6\x01\n" +
+	"\x0eBattleEndStats\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\x12\x10\n" +
+	"\x03win\x18\x02 \x01(\bR\x03win\x12(\n" +
+	"\x04pets\x18\x03 \x03(\v2\x14.packets.PetEndStatsR\x04pets\x12*\n" +
+	"\x05items\x18\x04 \x03(\v2\x14.packets.ItemMessageR\x05items\x124\n" +
+	"\tpet_items\x18\x05 \x03(\v2\x17.packets.PetItemMessageR\bpetItems\"y\n" +
+	"\vPetEndStats\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x15\n" +
+	"\x06pet_id\x18\x02 \x01(\rR\x05petId\x12\x10\n" +
+	"\x03exp\x18\x03 \x01(\x03R\x03exp\x12\x14\n" +
+	"\x05level\x18\x04 \x01(\x03R\x05level\x12\x1b\n" +
+	"\tlevel_ups\x18\x05 \x01(\x03R\blevelUps\",\n" +
 	"\x12DenyCommandMessage\x12\x16\n" +
-	"\x06reason\x18\x01 \x01(\tR\x06reason\"\x17\n" +
-	"\x15StartNextRoundMessage\"*\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"1\n" +
+	"\x15StartNextRoundMessage\x12\x18\n" +
+	"\atimeout\x18\x01 \x01(\x03R\atimeout\"*\n" +
 	"\x10BattleEndMessage\x12\x16\n" +
 	"\x06winner\x18\x01 \x01(\x03R\x06winner\"\x15\n" +
-	"\x13RoundConfirmMessage\"\x19\n" +
-	"\x17ChangePetRequestMessage\"=\n" +
+	"\x13RoundConfirmMessage\"G\n" +
+	"\x17ChangePetRequestMessage\x12\x12\n" +
+	"\x04slot\x18\x01 \x01(\x03R\x04slot\x12\x18\n" +
+	"\atimeout\x18\x02 \x01(\x03R\atimeout\"Q\n" +
 	"\x18ChangePetResponseMessage\x12!\n" +
-	"\fpet_position\x18\x02 \x01(\x03R\vpetPosition\"\x8f\x01\n" +
+	"\fpet_position\x18\x02 \x01(\x03R\vpetPosition\x12\x12\n" +
+	"\x04slot\x18\x03 \x01(\x03R\x04slot\"\x8f\x01\n" +
 	"\x1cSyncBattleInformationMessage\x12\x16\n" +
 	"\x06number\x18\x01 \x01(\x03R\x06number\x12\x1f\n" +
 	"\vplayer_name\x18\x02 \x01(\tR\n" +
 	"playerName\x126\n" +
 	"\fpet_messages\x18\x03 \x03(\v2\x13.packets.PetMessageR\vpetMessages\"\x11\n" +
-	"\x0fRoundEndMessageB\rZ\vpkg/packetsb\x06proto3"
+	"\x0fRoundEndMessage\"e\n" +
+	"\x19PlayerDisconnectedMessage\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\x12\x16\n" +
+	"\x06policy\x18\x02 \x01(\rR\x06policy\x12\x18\n" +
+	"\atimeout\x18\x03 \x01(\x03R\atimeout\"y\n" +
+	"\x14CaptureResultMessage\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\x12\x17\n" +
+	"\aitem_id\x18\x02 \x01(\rR\x06itemId\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06chance\x18\x04 \x01(\x02R\x06chance\"\xe8\x01\n" +
+	"\x15BattleSnapshotMessage\x12;\n" +
+	"\x05teams\x18\x01 \x03(\v2%.packets.SyncBattleInformationMessageR\x05teams\x12!\n" +
+	"\fcurrent_pets\x18\x02 \x03(\x03R\vcurrentPets\x12#\n" +
+	"\x05buffs\x18\x03 \x03(\v2\r.packets.BuffR\x05buffs\x12\x14\n" +
+	"\x05round\x18\x04 \x01(\x03R\x05round\x124\n" +
+	"\x06format\x18\x05 \x01(\v2\x1c.packets.BattleFormatMessageR\x06format\"L\n" +
+	"\x13BattleFormatMessage\x12\x14\n" +
+	"\x05teams\x18\x01 \x03(\x03R\x05teams\x12\x1f\n" +
+	"\vactive_pets\x18\x02 \x01(\x03R\n" +
+	"activePets\"c\n" +
+	"\x12PetSwitchedMessage\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\x12\x12\n" +
+	"\x04slot\x18\x02 \x01(\x03R\x04slot\x12!\n" +
+	"\fpet_position\x18\x03 \x01(\x03R\vpetPosition\"2\n" +
+	"\x18PlayerReconnectedMessage\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\"\x99\x01\n" +
+	"\x10RankEntryMessage\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x03R\x04rank\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\rR\x03uid\x12\x1b\n" +
+	"\tuser_name\x18\x03 \x01(\tR\buserName\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x03R\x06rating\x12\x12\n" +
+	"\x04wins\x18\x05 \x01(\x03R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x06 \x01(\x03R\x06losses\"B\n" +
+	"\x16RankListRequestMessage\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\rR\x04type\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\x95\x01\n" +
+	"\x17RankListResponseMessage\x12\x16\n" +
+	"\x06season\x18\x01 \x01(\rR\x06season\x123\n" +
+	"\aentries\x18\x02 \x03(\v2\x19.packets.RankEntryMessageR\aentries\x12-\n" +
+	"\x04self\x18\x03 \x01(\v2\x19.packets.RankEntryMessageR\x04self\"3\n" +
+	"\x1bBattleHistoryRequestMessage\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"|\n" +
+	"\x13BattleRecordMessage\x12\x1a\n" +
+	"\bopponent\x18\x01 \x01(\tR\bopponent\x12\x10\n" +
+	"\x03win\x18\x02 \x01(\bR\x03win\x12#\n" +
+	"\rrating_change\x18\x03 \x01(\x03R\fratingChange\x12\x12\n" +
+	"\x04time\x18\x04 \x01(\x03R\x04time\"V\n" +
+	"\x1cBattleHistoryResponseMessage\x126\n" +
+	"\arecords\x18\x01 \x03(\v2\x1c.packets.BattleRecordMessageR\arecords\"r\n" +
+	"\x17LeaderboardEntryMessage\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x03R\x04rank\x12\x10\n" +
+	"\x03uid\x18\x02 \x01(\rR\x03uid\x12\x1b\n" +
+	"\tuser_name\x18\x03 \x01(\tR\buserName\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x03R\x05score\"q\n" +
+	"\x19LeaderboardRequestMessage\x12\x14\n" +
+	"\x05board\x18\x01 \x01(\rR\x05board\x12\x16\n" +
+	"\x06window\x18\x02 \x01(\rR\x06window\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x03R\x04page\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\"\x82\x02\n" +
+	"\x1aLeaderboardResponseMessage\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05board\x18\x03 \x01(\rR\x05board\x12\x16\n" +
+	"\x06window\x18\x04 \x01(\rR\x06window\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x03R\x04page\x12:\n" +
+	"\aentries\x18\x06 \x03(\v2 .packets.LeaderboardEntryMessageR\aentries\x124\n" +
+	"\x04self\x18\a \x01(\v2 .packets.LeaderboardEntryMessageR\x04self\"_\n" +
+	"\rReplayCommand\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x03R\x06number\x126\n" +
+	"\acommand\x18\x02 \x01(\v2\x1c.packets.RoundCommandMessageR\acommand\"m\n" +
+	"\vReplayInput\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\rR\x04type\x122\n" +
+	"\bcommands\x18\x02 \x03(\v2\x16.packets.ReplayCommandR\bcommands\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\x03R\x06number\"\x93\x02\n" +
+	"\fBattleReplay\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04seed\x18\x02 \x01(\x04R\x04seed\x12;\n" +
+	"\x05teams\x18\x03 \x03(\v2%.packets.SyncBattleInformationMessageR\x05teams\x12,\n" +
+	"\x06inputs\x18\x04 \x03(\v2\x14.packets.ReplayInputR\x06inputs\x12\x16\n" +
+	"\x06winner\x18\x05 \x01(\x03R\x06winner\x12\x12\n" +
+	"\x04time\x18\x06 \x01(\x03R\x04time\x12\x12\n" +
+	"\x04uids\x18\a \x03(\rR\x04uids\x124\n" +
+	"\x06format\x18\b \x01(\v2\x1c.packets.BattleFormatMessageR\x06format\"k\n" +
+	"\n" +
+	"ReplayInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12!\n" +
+	"\fplayer_names\x18\x02 \x03(\tR\vplayerNames\x12\x16\n" +
+	"\x06winner\x18\x03 \x01(\x03R\x06winner\x12\x12\n" +
+	"\x04time\x18\x04 \x01(\x03R\x04time\"\x1a\n" +
+	"\x18ReplayListRequestMessage\"J\n" +
+	"\x19ReplayListResponseMessage\x12-\n" +
+	"\areplays\x18\x01 \x03(\v2\x13.packets.ReplayInfoR\areplays\"&\n" +
+	"\x14ReplayRequestMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"\xa9\x01\n" +
+	"\x15ReplayResponseMessage\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12-\n" +
+	"\x06replay\x18\x03 \x01(\v2\x15.packets.BattleReplayR\x06replay\x12/\n" +
+	"\apackets\x18\x04 \x03(\v2\x15.packets.BattlePacketR\apacketsB\rZ\vpkg/packetsb\x06proto3"
 
 var (
 	file_shared_packets_proto_rawDescOnce sync.Once
@@ -5084,7 +8557,7 @@ func file_shared_packets_proto_rawDescGZIP() []byte {
 	return file_shared_packets_proto_rawDescData
 }
 
-var file_shared_packets_proto_msgTypes = make([]protoimpl.MessageInfo, 74)
+var file_shared_packets_proto_msgTypes = make([]protoimpl.MessageInfo, 116)
 var file_shared_packets_proto_goTypes = []any{
 	(*LoginRequestMessage)(nil),              // 0: packets.LoginRequestMessage
 	(*RegisterRequestMessage)(nil),           // 1: packets.RegisterRequestMessage
@@ -5118,130 +8591,228 @@ var file_shared_packets_proto_goTypes = []any{
 	(*PetBagRequestMessage)(nil),             // 29: packets.PetBagRequestMessage
 	(*PetBagResponseMessage)(nil),            // 30: packets.PetBagResponseMessage
 	(*PetMessage)(nil),                       // 31: packets.PetMessage
-	(*PetStatsMessage)(nil),                  // 32: packets.PetStatsMessage
-	(*SavePetMessage)(nil),                   // 33: packets.SavePetMessage
-	(*LearnSkillRequestMessage)(nil),         // 34: packets.LearnSkillRequestMessage
-	(*LearnSkillResponseMessage)(nil),        // 35: packets.LearnSkillResponseMessage
-	(*EquippedPetInfoRequestMessage)(nil),    // 36: packets.EquippedPetInfoRequestMessage
-	(*EquippedPetInfoResponseMessage)(nil),   // 37: packets.EquippedPetInfoResponseMessage
-	(*AddPetItemMessage)(nil),                // 38: packets.AddPetItemMessage
-	(*DeletePetItemMessage)(nil),             // 39: packets.DeletePetItemMessage
-	(*PetItemMessage)(nil),                   // 40: packets.PetItemMessage
-	(*PetItemBagRequestMessage)(nil),         // 41: packets.PetItemBagRequestMessage
-	(*PetItemBagResponseMessage)(nil),        // 42: packets.PetItemBagResponseMessage
-	(*UsePetItemRequestMessage)(nil),         // 43: packets.UsePetItemRequestMessage
-	(*UsePetItemResponseMessage)(nil),        // 44: packets.UsePetItemResponseMessage
-	(*BattleRequestMessage)(nil),             // 45: packets.BattleRequestMessage
-	(*BattleInvitingMessage)(nil),            // 46: packets.BattleInvitingMessage
-	(*BattleInvitingResponseMessage)(nil),    // 47: packets.BattleInvitingResponseMessage
-	(*StartBattleMessage)(nil),               // 48: packets.StartBattleMessage
-	(*Packet)(nil),                           // 49: packets.Packet
-	(*UiPacket)(nil),                         // 50: packets.UiPacket
-	(*OpenUIMessage)(nil),                    // 51: packets.OpenUIMessage
-	(*InitialPetRequestMessage)(nil),         // 52: packets.InitialPetRequestMessage
-	(*NPCInteractPacket)(nil),                // 53: packets.NPCInteractPacket
-	(*HealMessage)(nil),                      // 54: packets.HealMessage
-	(*InitialVillageHeaderMessage)(nil),      // 55: packets.InitialVillageHeaderMessage
-	(*NewRewardRequest)(nil),                 // 56: packets.NewRewardRequest
-	(*UpdateInitialVillageHeaderUIInfo)(nil), // 57: packets.UpdateInitialVillageHeaderUIInfo
-	(*BattlePacket)(nil),                     // 58: packets.BattlePacket
-	(*RoundCommandMessage)(nil),              // 59: packets.RoundCommandMessage
-	(*ChangePet)(nil),                        // 60: packets.ChangePet
-	(*RunAway)(nil),                          // 61: packets.RunAway
-	(*Attack)(nil),                           // 62: packets.Attack
-	(*AttackStatsMessage)(nil),               // 63: packets.AttackStatsMessage
-	(*Buff)(nil),                             // 64: packets.Buff
-	(*BattleEndStats)(nil),                   // 65: packets.BattleEndStats
-	(*DenyCommandMessage)(nil),               // 66: packets.DenyCommandMessage
-	(*StartNextRoundMessage)(nil),            // 67: packets.StartNextRoundMessage
-	(*BattleEndMessage)(nil),                 // 68: packets.BattleEndMessage
-	(*RoundConfirmMessage)(nil),              // 69: packets.RoundConfirmMessage
-	(*ChangePetRequestMessage)(nil),          // 70: packets.ChangePetRequestMessage
-	(*ChangePetResponseMessage)(nil),         // 71: packets.ChangePetResponseMessage
-	(*SyncBattleInformationMessage)(nil),     // 72: packets.SyncBattleInformationMessage
-	(*RoundEndMessage)(nil),                  // 73: packets.RoundEndMessage
+	(*PetTalentMessage)(nil),                 // 32: packets.PetTalentMessage
+	(*PetStatsMessage)(nil),                  // 33: packets.PetStatsMessage
+	(*SavePetMessage)(nil),                   // 34: packets.SavePetMessage
+	(*LearnSkillRequestMessage)(nil),         // 35: packets.LearnSkillRequestMessage
+	(*LearnSkillResponseMessage)(nil),        // 36: packets.LearnSkillResponseMessage
+	(*ForgetSkillRequestMessage)(nil),        // 37: packets.ForgetSkillRequestMessage
+	(*ForgetSkillResponseMessage)(nil),       // 38: packets.ForgetSkillResponseMessage
+	(*EquippedPetInfoRequestMessage)(nil),    // 39: packets.EquippedPetInfoRequestMessage
+	(*EquippedPetInfoResponseMessage)(nil),   // 40: packets.EquippedPetInfoResponseMessage
+	(*AddPetItemMessage)(nil),                // 41: packets.AddPetItemMessage
+	(*DeletePetItemMessage)(nil),             // 42: packets.DeletePetItemMessage
+	(*PetItemMessage)(nil),                   // 43: packets.PetItemMessage
+	(*PetItemBagRequestMessage)(nil),         // 44: packets.PetItemBagRequestMessage
+	(*PetItemBagResponseMessage)(nil),        // 45: packets.PetItemBagResponseMessage
+	(*UsePetItemRequestMessage)(nil),         // 46: packets.UsePetItemRequestMessage
+	(*UsePetItemResponseMessage)(nil),        // 47: packets.UsePetItemResponseMessage
+	(*BattleRequestMessage)(nil),             // 48: packets.BattleRequestMessage
+	(*WagerItemMessage)(nil),                 // 49: packets.WagerItemMessage
+	(*BattleInvitingMessage)(nil),            // 50: packets.BattleInvitingMessage
+	(*BattleRequestResponseMessage)(nil),     // 51: packets.BattleRequestResponseMessage
+	(*CancelBattleInviteMessage)(nil),        // 52: packets.CancelBattleInviteMessage
+	(*BattleInviteClosedMessage)(nil),        // 53: packets.BattleInviteClosedMessage
+	(*BattleInvitingResponseMessage)(nil),    // 54: packets.BattleInvitingResponseMessage
+	(*StartBattleMessage)(nil),               // 55: packets.StartBattleMessage
+	(*SpectateRequestMessage)(nil),           // 56: packets.SpectateRequestMessage
+	(*SpectateResponseMessage)(nil),          // 57: packets.SpectateResponseMessage
+	(*StopSpectateMessage)(nil),              // 58: packets.StopSpectateMessage
+	(*JoinMatchmakingMessage)(nil),           // 59: packets.JoinMatchmakingMessage
+	(*LeaveMatchmakingMessage)(nil),          // 60: packets.LeaveMatchmakingMessage
+	(*MatchmakingResponseMessage)(nil),       // 61: packets.MatchmakingResponseMessage
+	(*MatchFoundMessage)(nil),                // 62: packets.MatchFoundMessage
+	(*MatchReadyMessage)(nil),                // 63: packets.MatchReadyMessage
+	(*MatchCancelledMessage)(nil),            // 64: packets.MatchCancelledMessage
+	(*Packet)(nil),                           // 65: packets.Packet
+	(*UiPacket)(nil),                         // 66: packets.UiPacket
+	(*OpenUIMessage)(nil),                    // 67: packets.OpenUIMessage
+	(*InitialPetRequestMessage)(nil),         // 68: packets.InitialPetRequestMessage
+	(*NPCInteractPacket)(nil),                // 69: packets.NPCInteractPacket
+	(*HealMessage)(nil),                      // 70: packets.HealMessage
+	(*InitialVillageHeaderMessage)(nil),      // 71: packets.InitialVillageHeaderMessage
+	(*NewRewardRequest)(nil),                 // 72: packets.NewRewardRequest
+	(*UpdateInitialVillageHeaderUIInfo)(nil), // 73: packets.UpdateInitialVillageHeaderUIInfo
+	(*BattlePacket)(nil),                     // 74: packets.BattlePacket
+	(*RoundCommandMessage)(nil),              // 75: packets.RoundCommandMessage
+	(*BattleTarget)(nil),                     // 76: packets.BattleTarget
+	(*ChangePet)(nil),                        // 77: packets.ChangePet
+	(*RunAway)(nil),                          // 78: packets.RunAway
+	(*Attack)(nil),                           // 79: packets.Attack
+	(*Capture)(nil),                          // 80: packets.Capture
+	(*AttackStatsMessage)(nil),               // 81: packets.AttackStatsMessage
+	(*Buff)(nil),                             // 82: packets.Buff
+	(*BattleEndStats)(nil),                   // 83: packets.BattleEndStats
+	(*PetEndStats)(nil),                      // 84: packets.PetEndStats
+	(*DenyCommandMessage)(nil),               // 85: packets.DenyCommandMessage
+	(*StartNextRoundMessage)(nil),            // 86: packets.StartNextRoundMessage
+	(*BattleEndMessage)(nil),                 // 87: packets.BattleEndMessage
+	(*RoundConfirmMessage)(nil),              // 88: packets.RoundConfirmMessage
+	(*ChangePetRequestMessage)(nil),          // 89: packets.ChangePetRequestMessage
+	(*ChangePetResponseMessage)(nil),         // 90: packets.ChangePetResponseMessage
+	(*SyncBattleInformationMessage)(nil),     // 91: packets.SyncBattleInformationMessage
+	(*RoundEndMessage)(nil),                  // 92: packets.RoundEndMessage
+	(*PlayerDisconnectedMessage)(nil),        // 93: packets.PlayerDisconnectedMessage
+	(*CaptureResultMessage)(nil),             // 94: packets.CaptureResultMessage
+	(*BattleSnapshotMessage)(nil),            // 95: packets.BattleSnapshotMessage
+	(*BattleFormatMessage)(nil),              // 96: packets.BattleFormatMessage
+	(*PetSwitchedMessage)(nil),               // 97: packets.PetSwitchedMessage
+	(*PlayerReconnectedMessage)(nil),         // 98: packets.PlayerReconnectedMessage
+	(*RankEntryMessage)(nil),                 // 99: packets.RankEntryMessage
+	(*RankListRequestMessage)(nil),           // 100: packets.RankListRequestMessage
+	(*RankListResponseMessage)(nil),          // 101: packets.RankListResponseMessage
+	(*BattleHistoryRequestMessage)(nil),      // 102: packets.BattleHistoryRequestMessage
+	(*BattleRecordMessage)(nil),              // 103: packets.BattleRecordMessage
+	(*BattleHistoryResponseMessage)(nil),     // 104: packets.BattleHistoryResponseMessage
+	(*LeaderboardEntryMessage)(nil),          // 105: packets.LeaderboardEntryMessage
+	(*LeaderboardRequestMessage)(nil),        // 106: packets.LeaderboardRequestMessage
+	(*LeaderboardResponseMessage)(nil),       // 107: packets.LeaderboardResponseMessage
+	(*ReplayCommand)(nil),                    // 108: packets.ReplayCommand
+	(*ReplayInput)(nil),                      // 109: packets.ReplayInput
+	(*BattleReplay)(nil),                     // 110: packets.BattleReplay
+	(*ReplayInfo)(nil),                       // 111: packets.ReplayInfo
+	(*ReplayListRequestMessage)(nil),         // 112: packets.ReplayListRequestMessage
+	(*ReplayListResponseMessage)(nil),        // 113: packets.ReplayListResponseMessage
+	(*ReplayRequestMessage)(nil),             // 114: packets.ReplayRequestMessage
+	(*ReplayResponseMessage)(nil),            // 115: packets.ReplayResponseMessage
 }
 var file_shared_packets_proto_depIdxs = []int32{
-	16, // 0: packets.MailMessage.items:type_name -> packets.ItemMessage
-	40, // 1: packets.MailMessage.pet_items:type_name -> packets.PetItemMessage
-	26, // 2: packets.GetAreaNPCsMessage.npc_info:type_name -> packets.NPCInfoMessage
-	31, // 3: packets.PetBagResponseMessage.pet:type_name -> packets.PetMessage
-	32, // 4: packets.PetMessage.pet_stats:type_name -> packets.PetStatsMessage
-	31, // 5: packets.EquippedPetInfoResponseMessage.pet:type_name -> packets.PetMessage
-	0,  // 6: packets.Packet.login_request:type_name -> packets.LoginRequestMessage
-	1,  // 7: packets.Packet.register_request:type_name -> packets.RegisterRequestMessage
-	2,  // 8: packets.Packet.ok_response:type_name -> packets.OKResponseMessage
-	3,  // 9: packets.Packet.deny_response:type_name -> packets.DenyResponseMessage
-	4,  // 10: packets.Packet.login_success:type_name -> packets.LoginSuccessMessage
-	7,  // 11: packets.Packet.player_enter:type_name -> packets.PlayerEnterAreaMessage
-	8,  // 12: packets.Packet.player_leave:type_name -> packets.PlayerLeaveAreaMessage
-	9,  // 13: packets.Packet.player_movement:type_name -> packets.PlayerMoveMessage
-	5,  // 14: packets.Packet.player_enter_request:type_name -> packets.PlayerEnterAreaRequestMessage
-	10, // 15: packets.Packet.chat:type_name -> packets.ChatMessage
-	6,  // 16: packets.Packet.player_enter_area_response:type_name -> packets.PlayerEnterAreaResponseMessage
-	12, // 17: packets.Packet.mail:type_name -> packets.MailMessage
-	11, // 18: packets.Packet.mail_request:type_name -> packets.MailRequestMessage
-	13, // 19: packets.Packet.mail_collect:type_name -> packets.MailCollectMessage
-	15, // 20: packets.Packet.mail_delete:type_name -> packets.MailDeleteMessage
-	14, // 21: packets.Packet.mail_collect_response:type_name -> packets.MailCollectResponseMessage
-	17, // 22: packets.Packet.bag_request:type_name -> packets.BagRequestMessage
-	18, // 23: packets.Packet.bag:type_name -> packets.BagMessage
-	19, // 24: packets.Packet.add_bag_item:type_name -> packets.AddBagItemMessage
-	20, // 25: packets.Packet.delete_bag_item:type_name -> packets.DeleteBagItemMessage
-	21, // 26: packets.Packet.use_bag_item_request:type_name -> packets.UseBagItemRequestMessage
-	22, // 27: packets.Packet.use_bag_item_response:type_name -> packets.UseBagItemResponseMessage
-	50, // 28: packets.Packet.ui_packet:type_name -> packets.UiPacket
-	28, // 29: packets.Packet.get_pet:type_name -> packets.GetPetMessage
-	29, // 30: packets.Packet.pet_bag_request:type_name -> packets.PetBagRequestMessage
-	30, // 31: packets.Packet.pet_bag_response:type_name -> packets.PetBagResponseMessage
-	33, // 32: packets.Packet.save_pet:type_name -> packets.SavePetMessage
-	34, // 33: packets.Packet.learn_skill_request:type_name -> packets.LearnSkillRequestMessage
-	35, // 34: packets.Packet.learn_skill_response:type_name -> packets.LearnSkillResponseMessage
-	38, // 35: packets.Packet.add_pet_item:type_name -> packets.AddPetItemMessage
-	39, // 36: packets.Packet.delete_pet_item:type_name -> packets.DeletePetItemMessage
-	41, // 37: packets.Packet.pet_item_bag_request:type_name -> packets.PetItemBagRequestMessage
-	43, // 38: packets.Packet.use_pet_item_request:type_name -> packets.UsePetItemRequestMessage
-	44, // 39: packets.Packet.use_pet_item_response:type_name -> packets.UsePetItemResponseMessage
-	42, // 40: packets.Packet.pet_item_bag_response:type_name -> packets.PetItemBagResponseMessage
-	36, // 41: packets.Packet.equipped_pet_info_request:type_name -> packets.EquippedPetInfoRequestMessage
-	37, // 42: packets.Packet.equipped_pet_info_response:type_name -> packets.EquippedPetInfoResponseMessage
-	58, // 43: packets.Packet.battle_packet:type_name -> packets.BattlePacket
-	45, // 44: packets.Packet.battle_request:type_name -> packets.BattleRequestMessage
-	47, // 45: packets.Packet.battle_inviting_response:type_name -> packets.BattleInvitingResponseMessage
-	46, // 46: packets.Packet.battle_inviting:type_name -> packets.BattleInvitingMessage
-	48, // 47: packets.Packet.start_battle:type_name -> packets.StartBattleMessage
-	23, // 48: packets.Packet.get_area_request:type_name -> packets.GetAreaRequest
-	24, // 49: packets.Packet.sync_state:type_name -> packets.SyncState
-	25, // 50: packets.Packet.get_area_npcs:type_name -> packets.GetAreaNPCsMessage
-	27, // 51: packets.Packet.interact_npc_request:type_name -> packets.InteractNPCRequestMessage
-	53, // 52: packets.Packet.npc_interact:type_name -> packets.NPCInteractPacket
-	51, // 53: packets.UiPacket.open_ui:type_name -> packets.OpenUIMessage
-	52, // 54: packets.UiPacket.initial_pet_request:type_name -> packets.InitialPetRequestMessage
-	54, // 55: packets.NPCInteractPacket.heal:type_name -> packets.HealMessage
-	55, // 56: packets.NPCInteractPacket.initial_village_header:type_name -> packets.InitialVillageHeaderMessage
-	56, // 57: packets.InitialVillageHeaderMessage.new_reward_request:type_name -> packets.NewRewardRequest
-	57, // 58: packets.InitialVillageHeaderMessage.update_info:type_name -> packets.UpdateInitialVillageHeaderUIInfo
-	59, // 59: packets.BattlePacket.command:type_name -> packets.RoundCommandMessage
-	63, // 60: packets.BattlePacket.attack_stats:type_name -> packets.AttackStatsMessage
-	66, // 61: packets.BattlePacket.deny_command:type_name -> packets.DenyCommandMessage
-	67, // 62: packets.BattlePacket.start_next_round:type_name -> packets.StartNextRoundMessage
-	68, // 63: packets.BattlePacket.battle_end:type_name -> packets.BattleEndMessage
-	69, // 64: packets.BattlePacket.round_confirm:type_name -> packets.RoundConfirmMessage
-	71, // 65: packets.BattlePacket.change_pet:type_name -> packets.ChangePetResponseMessage
-	70, // 66: packets.BattlePacket.change_pet_request:type_name -> packets.ChangePetRequestMessage
-	72, // 67: packets.BattlePacket.sync_battle_information:type_name -> packets.SyncBattleInformationMessage
-	73, // 68: packets.BattlePacket.round_end:type_name -> packets.RoundEndMessage
-	60, // 69: packets.RoundCommandMessage.change_pet:type_name -> packets.ChangePet
-	61, // 70: packets.RoundCommandMessage.runaway:type_name -> packets.RunAway
-	62, // 71: packets.RoundCommandMessage.attack:type_name -> packets.Attack
-	64, // 72: packets.AttackStatsMessage.buffs:type_name -> packets.Buff
-	32, // 73: packets.AttackStatsMessage.pet_stats:type_name -> packets.PetStatsMessage
-	31, // 74: packets.SyncBattleInformationMessage.pet_messages:type_name -> packets.PetMessage
-	75, // [75:75] is the sub-list for method output_type
-	75, // [75:75] is the sub-list for method input_type
-	75, // [75:75] is the sub-list for extension type_name
-	75, // [75:75] is the sub-list for extension extendee
-	0,  // [0:75] is the sub-list for field type_name
+	16,  // 0: packets.MailMessage.items:type_name -> packets.ItemMessage
+	43,  // 1: packets.MailMessage.pet_items:type_name -> packets.PetItemMessage
+	26,  // 2: packets.GetAreaNPCsMessage.npc_info:type_name -> packets.NPCInfoMessage
+	31,  // 3: packets.PetBagResponseMessage.pet:type_name -> packets.PetMessage
+	33,  // 4: packets.PetMessage.pet_stats:type_name -> packets.PetStatsMessage
+	32,  // 5: packets.PetMessage.talent:type_name -> packets.PetTalentMessage
+	31,  // 6: packets.EquippedPetInfoResponseMessage.pet:type_name -> packets.PetMessage
+	49,  // 7: packets.BattleRequestMessage.stake:type_name -> packets.WagerItemMessage
+	49,  // 8: packets.BattleInvitingMessage.stake:type_name -> packets.WagerItemMessage
+	0,   // 9: packets.Packet.login_request:type_name -> packets.LoginRequestMessage
+	1,   // 10: packets.Packet.register_request:type_name -> packets.RegisterRequestMessage
+	2,   // 11: packets.Packet.ok_response:type_name -> packets.OKResponseMessage
+	3,   // 12: packets.Packet.deny_response:type_name -> packets.DenyResponseMessage
+	4,   // 13: packets.Packet.login_success:type_name -> packets.LoginSuccessMessage
+	7,   // 14: packets.Packet.player_enter:type_name -> packets.PlayerEnterAreaMessage
+	8,   // 15: packets.Packet.player_leave:type_name -> packets.PlayerLeaveAreaMessage
+	9,   // 16: packets.Packet.player_movement:type_name -> packets.PlayerMoveMessage
+	5,   // 17: packets.Packet.player_enter_request:type_name -> packets.PlayerEnterAreaRequestMessage
+	10,  // 18: packets.Packet.chat:type_name -> packets.ChatMessage
+	6,   // 19: packets.Packet.player_enter_area_response:type_name -> packets.PlayerEnterAreaResponseMessage
+	12,  // 20: packets.Packet.mail:type_name -> packets.MailMessage
+	11,  // 21: packets.Packet.mail_request:type_name -> packets.MailRequestMessage
+	13,  // 22: packets.Packet.mail_collect:type_name -> packets.MailCollectMessage
+	15,  // 23: packets.Packet.mail_delete:type_name -> packets.MailDeleteMessage
+	14,  // 24: packets.Packet.mail_collect_response:type_name -> packets.MailCollectResponseMessage
+	17,  // 25: packets.Packet.bag_request:type_name -> packets.BagRequestMessage
+	18,  // 26: packets.Packet.bag:type_name -> packets.BagMessage
+	19,  // 27: packets.Packet.add_bag_item:type_name -> packets.AddBagItemMessage
+	20,  // 28: packets.Packet.delete_bag_item:type_name -> packets.DeleteBagItemMessage
+	21,  // 29: packets.Packet.use_bag_item_request:type_name -> packets.UseBagItemRequestMessage
+	22,  // 30: packets.Packet.use_bag_item_response:type_name -> packets.UseBagItemResponseMessage
+	66,  // 31: packets.Packet.ui_packet:type_name -> packets.UiPacket
+	28,  // 32: packets.Packet.get_pet:type_name -> packets.GetPetMessage
+	29,  // 33: packets.Packet.pet_bag_request:type_name -> packets.PetBagRequestMessage
+	30,  // 34: packets.Packet.pet_bag_response:type_name -> packets.PetBagResponseMessage
+	34,  // 35: packets.Packet.save_pet:type_name -> packets.SavePetMessage
+	35,  // 36: packets.Packet.learn_skill_request:type_name -> packets.LearnSkillRequestMessage
+	36,  // 37: packets.Packet.learn_skill_response:type_name -> packets.LearnSkillResponseMessage
+	41,  // 38: packets.Packet.add_pet_item:type_name -> packets.AddPetItemMessage
+	42,  // 39: packets.Packet.delete_pet_item:type_name -> packets.DeletePetItemMessage
+	44,  // 40: packets.Packet.pet_item_bag_request:type_name -> packets.PetItemBagRequestMessage
+	46,  // 41: packets.Packet.use_pet_item_request:type_name -> packets.UsePetItemRequestMessage
+	47,  // 42: packets.Packet.use_pet_item_response:type_name -> packets.UsePetItemResponseMessage
+	45,  // 43: packets.Packet.pet_item_bag_response:type_name -> packets.PetItemBagResponseMessage
+	39,  // 44: packets.Packet.equipped_pet_info_request:type_name -> packets.EquippedPetInfoRequestMessage
+	40,  // 45: packets.Packet.equipped_pet_info_response:type_name -> packets.EquippedPetInfoResponseMessage
+	74,  // 46: packets.Packet.battle_packet:type_name -> packets.BattlePacket
+	48,  // 47: packets.Packet.battle_request:type_name -> packets.BattleRequestMessage
+	54,  // 48: packets.Packet.battle_inviting_response:type_name -> packets.BattleInvitingResponseMessage
+	50,  // 49: packets.Packet.battle_inviting:type_name -> packets.BattleInvitingMessage
+	55,  // 50: packets.Packet.start_battle:type_name -> packets.StartBattleMessage
+	23,  // 51: packets.Packet.get_area_request:type_name -> packets.GetAreaRequest
+	24,  // 52: packets.Packet.sync_state:type_name -> packets.SyncState
+	25,  // 53: packets.Packet.get_area_npcs:type_name -> packets.GetAreaNPCsMessage
+	27,  // 54: packets.Packet.interact_npc_request:type_name -> packets.InteractNPCRequestMessage
+	69,  // 55: packets.Packet.npc_interact:type_name -> packets.NPCInteractPacket
+	37,  // 56: packets.Packet.forget_skill_request:type_name -> packets.ForgetSkillRequestMessage
+	38,  // 57: packets.Packet.forget_skill_response:type_name -> packets.ForgetSkillResponseMessage
+	112, // 58: packets.Packet.replay_list_request:type_name -> packets.ReplayListRequestMessage
+	113, // 59: packets.Packet.replay_list_response:type_name -> packets.ReplayListResponseMessage
+	114, // 60: packets.Packet.replay_request:type_name -> packets.ReplayRequestMessage
+	115, // 61: packets.Packet.replay_response:type_name -> packets.ReplayResponseMessage
+	56,  // 62: packets.Packet.spectate_request:type_name -> packets.SpectateRequestMessage
+	57,  // 63: packets.Packet.spectate_response:type_name -> packets.SpectateResponseMessage
+	58,  // 64: packets.Packet.stop_spectate:type_name -> packets.StopSpectateMessage
+	59,  // 65: packets.Packet.join_matchmaking:type_name -> packets.JoinMatchmakingMessage
+	60,  // 66: packets.Packet.leave_matchmaking:type_name -> packets.LeaveMatchmakingMessage
+	61,  // 67: packets.Packet.matchmaking_response:type_name -> packets.MatchmakingResponseMessage
+	62,  // 68: packets.Packet.match_found:type_name -> packets.MatchFoundMessage
+	63,  // 69: packets.Packet.match_ready:type_name -> packets.MatchReadyMessage
+	64,  // 70: packets.Packet.match_cancelled:type_name -> packets.MatchCancelledMessage
+	100, // 71: packets.Packet.rank_list_request:type_name -> packets.RankListRequestMessage
+	101, // 72: packets.Packet.rank_list_response:type_name -> packets.RankListResponseMessage
+	102, // 73: packets.Packet.battle_history_request:type_name -> packets.BattleHistoryRequestMessage
+	104, // 74: packets.Packet.battle_history_response:type_name -> packets.BattleHistoryResponseMessage
+	106, // 75: packets.Packet.leaderboard_request:type_name -> packets.LeaderboardRequestMessage
+	107, // 76: packets.Packet.leaderboard_response:type_name -> packets.LeaderboardResponseMessage
+	51,  // 77: packets.Packet.battle_request_response:type_name -> packets.BattleRequestResponseMessage
+	52,  // 78: packets.Packet.cancel_battle_invite:type_name -> packets.CancelBattleInviteMessage
+	53,  // 79: packets.Packet.battle_invite_closed:type_name -> packets.BattleInviteClosedMessage
+	67,  // 80: packets.UiPacket.open_ui:type_name -> packets.OpenUIMessage
+	68,  // 81: packets.UiPacket.initial_pet_request:type_name -> packets.InitialPetRequestMessage
+	70,  // 82: packets.NPCInteractPacket.heal:type_name -> packets.HealMessage
+	71,  // 83: packets.NPCInteractPacket.initial_village_header:type_name -> packets.InitialVillageHeaderMessage
+	72,  // 84: packets.InitialVillageHeaderMessage.new_reward_request:type_name -> packets.NewRewardRequest
+	73,  // 85: packets.InitialVillageHeaderMessage.update_info:type_name -> packets.UpdateInitialVillageHeaderUIInfo
+	75,  // 86: packets.BattlePacket.command:type_name -> packets.RoundCommandMessage
+	81,  // 87: packets.BattlePacket.attack_stats:type_name -> packets.AttackStatsMessage
+	85,  // 88: packets.BattlePacket.deny_command:type_name -> packets.DenyCommandMessage
+	86,  // 89: packets.BattlePacket.start_next_round:type_name -> packets.StartNextRoundMessage
+	87,  // 90: packets.BattlePacket.battle_end:type_name -> packets.BattleEndMessage
+	88,  // 91: packets.BattlePacket.round_confirm:type_name -> packets.RoundConfirmMessage
+	90,  // 92: packets.BattlePacket.change_pet:type_name -> packets.ChangePetResponseMessage
+	89,  // 93: packets.BattlePacket.change_pet_request:type_name -> packets.ChangePetRequestMessage
+	91,  // 94: packets.BattlePacket.sync_battle_information:type_name -> packets.SyncBattleInformationMessage
+	92,  // 95: packets.BattlePacket.round_end:type_name -> packets.RoundEndMessage
+	93,  // 96: packets.BattlePacket.player_disconnected:type_name -> packets.PlayerDisconnectedMessage
+	98,  // 97: packets.BattlePacket.player_reconnected:type_name -> packets.PlayerReconnectedMessage
+	94,  // 98: packets.BattlePacket.capture_result:type_name -> packets.CaptureResultMessage
+	83,  // 99: packets.BattlePacket.battle_end_stats:type_name -> packets.BattleEndStats
+	95,  // 100: packets.BattlePacket.battle_snapshot:type_name -> packets.BattleSnapshotMessage
+	96,  // 101: packets.BattlePacket.battle_format:type_name -> packets.BattleFormatMessage
+	97,  // 102: packets.BattlePacket.pet_switched:type_name -> packets.PetSwitchedMessage
+	77,  // 103: packets.RoundCommandMessage.change_pet:type_name -> packets.ChangePet
+	78,  // 104: packets.RoundCommandMessage.runaway:type_name -> packets.RunAway
+	79,  // 105: packets.RoundCommandMessage.attack:type_name -> packets.Attack
+	80,  // 106: packets.RoundCommandMessage.capture:type_name -> packets.Capture
+	76,  // 107: packets.Attack.target:type_name -> packets.BattleTarget
+	82,  // 108: packets.AttackStatsMessage.buffs:type_name -> packets.Buff
+	33,  // 109: packets.AttackStatsMessage.pet_stats:type_name -> packets.PetStatsMessage
+	76,  // 110: packets.AttackStatsMessage.target:type_name -> packets.BattleTarget
+	84,  // 111: packets.BattleEndStats.pets:type_name -> packets.PetEndStats
+	16,  // 112: packets.BattleEndStats.items:type_name -> packets.ItemMessage
+	43,  // 113: packets.BattleEndStats.pet_items:type_name -> packets.PetItemMessage
+	31,  // 114: packets.SyncBattleInformationMessage.pet_messages:type_name -> packets.PetMessage
+	91,  // 115: packets.BattleSnapshotMessage.teams:type_name -> packets.SyncBattleInformationMessage
+	82,  // 116: packets.BattleSnapshotMessage.buffs:type_name -> packets.Buff
+	96,  // 117: packets.BattleSnapshotMessage.format:type_name -> packets.BattleFormatMessage
+	99,  // 118: packets.RankListResponseMessage.entries:type_name -> packets.RankEntryMessage
+	99,  // 119: packets.RankListResponseMessage.self:type_name -> packets.RankEntryMessage
+	103, // 120: packets.BattleHistoryResponseMessage.records:type_name -> packets.BattleRecordMessage
+	105, // 121: packets.LeaderboardResponseMessage.entries:type_name -> packets.LeaderboardEntryMessage
+	105, // 122: packets.LeaderboardResponseMessage.self:type_name -> packets.LeaderboardEntryMessage
+	75,  // 123: packets.ReplayCommand.command:type_name -> packets.RoundCommandMessage
+	108, // 124: packets.ReplayInput.commands:type_name -> packets.ReplayCommand
+	91,  // 125: packets.BattleReplay.teams:type_name -> packets.SyncBattleInformationMessage
+	109, // 126: packets.BattleReplay.inputs:type_name -> packets.ReplayInput
+	96,  // 127: packets.BattleReplay.format:type_name -> packets.BattleFormatMessage
+	111, // 128: packets.ReplayListResponseMessage.replays:type_name -> packets.ReplayInfo
+	110, // 129: packets.ReplayResponseMessage.replay:type_name -> packets.BattleReplay
+	74,  // 130: packets.ReplayResponseMessage.packets:type_name -> packets.BattlePacket
+	131, // [131:131] is the sub-list for method output_type
+	131, // [131:131] is the sub-list for method input_type
+	131, // [131:131] is the sub-list for extension type_name
+	131, // [131:131] is the sub-list for extension extendee
+	0,   // [0:131] is the sub-list for field type_name
 }
 
 func init() { file_shared_packets_proto_init() }
@@ -5249,7 +8820,7 @@ func file_shared_packets_proto_init() {
 	if File_shared_packets_proto != nil {
 		return
 	}
-	file_shared_packets_proto_msgTypes[49].OneofWrappers = []any{
+	file_shared_packets_proto_msgTypes[65].OneofWrappers = []any{
 		(*Packet_LoginRequest)(nil),
 		(*Packet_RegisterRequest)(nil),
 		(*Packet_OkResponse)(nil),
@@ -5297,20 +8868,44 @@ func file_shared_packets_proto_init() {
 		(*Packet_GetAreaNpcs)(nil),
 		(*Packet_InteractNpcRequest)(nil),
 		(*Packet_NpcInteract)(nil),
-	}
-	file_shared_packets_proto_msgTypes[50].OneofWrappers = []any{
+		(*Packet_ForgetSkillRequest)(nil),
+		(*Packet_ForgetSkillResponse)(nil),
+		(*Packet_ReplayListRequest)(nil),
+		(*Packet_ReplayListResponse)(nil),
+		(*Packet_ReplayRequest)(nil),
+		(*Packet_ReplayResponse)(nil),
+		(*Packet_SpectateRequest)(nil),
+		(*Packet_SpectateResponse)(nil),
+		(*Packet_StopSpectate)(nil),
+		(*Packet_JoinMatchmaking)(nil),
+		(*Packet_LeaveMatchmaking)(nil),
+		(*Packet_MatchmakingResponse)(nil),
+		(*Packet_MatchFound)(nil),
+		(*Packet_MatchReady)(nil),
+		(*Packet_MatchCancelled)(nil),
+		(*Packet_RankListRequest)(nil),
+		(*Packet_RankListResponse)(nil),
+		(*Packet_BattleHistoryRequest)(nil),
+		(*Packet_BattleHistoryResponse)(nil),
+		(*Packet_LeaderboardRequest)(nil),
+		(*Packet_LeaderboardResponse)(nil),
+		(*Packet_BattleRequestResponse)(nil),
+		(*Packet_CancelBattleInvite)(nil),
+		(*Packet_BattleInviteClosed)(nil),
+	}
+	file_shared_packets_proto_msgTypes[66].OneofWrappers = []any{
 		(*UiPacket_OpenUi)(nil),
 		(*UiPacket_InitialPetRequest)(nil),
 	}
-	file_shared_packets_proto_msgTypes[53].OneofWrappers = []any{
+	file_shared_packets_proto_msgTypes[69].OneofWrappers = []any{
 		(*NPCInteractPacket_Heal)(nil),
 		(*NPCInteractPacket_InitialVillageHeader)(nil),
 	}
-	file_shared_packets_proto_msgTypes[55].OneofWrappers = []any{
+	file_shared_packets_proto_msgTypes[71].OneofWrappers = []any{
 		(*InitialVillageHeaderMessage_NewRewardRequest)(nil),
 		(*InitialVillageHeaderMessage_UpdateInfo)(nil),
 	}
-	file_shared_packets_proto_msgTypes[58].OneofWrappers = []any{
+	file_shared_packets_proto_msgTypes[74].OneofWrappers = []any{
 		(*BattlePacket_Command)(nil),
 		(*BattlePacket_AttackStats)(nil),
 		(*BattlePacket_DenyCommand)(nil),
@@ -5321,11 +8916,19 @@ func file_shared_packets_proto_init() {
 		(*BattlePacket_ChangePetRequest)(nil),
 		(*BattlePacket_SyncBattleInformation)(nil),
 		(*BattlePacket_RoundEnd)(nil),
-	}
-	file_shared_packets_proto_msgTypes[59].OneofWrappers = []any{
+		(*BattlePacket_PlayerDisconnected)(nil),
+		(*BattlePacket_PlayerReconnected)(nil),
+		(*BattlePacket_CaptureResult)(nil),
+		(*BattlePacket_BattleEndStats)(nil),
+		(*BattlePacket_BattleSnapshot)(nil),
+		(*BattlePacket_BattleFormat)(nil),
+		(*BattlePacket_PetSwitched)(nil),
+	}
+	file_shared_packets_proto_msgTypes[75].OneofWrappers = []any{
 		(*RoundCommandMessage_ChangePet)(nil),
 		(*RoundCommandMessage_Runaway)(nil),
 		(*RoundCommandMessage_Attack)(nil),
+		(*RoundCommandMessage_Capture)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -5333,7 +8936,7 @@ func file_shared_packets_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_packets_proto_rawDesc), len(file_shared_packets_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   74,
+			NumMessages:   116,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -97,6 +97,10 @@ func NewPetMessage(pet objects.Pet) *packets.PetMessage {
 	}
 	res.EquippedSkills = equippedSkills
 	res.PetStats = &stats
+	for _, v := range pet.Passives() {
+		res.Passives = append(res.Passives, v.ID())
+	}
+	res.Talent = objects.NewPetTalentMessage(pet.Talent())
 	return &res
 }
 